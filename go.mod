@@ -6,7 +6,9 @@ require (
 	github.com/pkg/errors v0.9.1
 	github.com/spf13/viper v1.18.2
 	github.com/stretchr/testify v1.8.4
+	golang.org/x/crypto v0.17.0
 	golang.org/x/sync v0.5.0
+	golang.org/x/time v0.5.0
 )
 
 require (
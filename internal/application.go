@@ -3,61 +3,581 @@ package main
 import (
 	"context"
 	"log/slog"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/pkg/errors"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/infra/backfill"
 	"github.com/tonytcb/ethereum-blockchain-parser/pkg/infra/ethjsonrpc"
 	"github.com/tonytcb/ethereum-blockchain-parser/pkg/infra/eventlistener"
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/infra/sink"
 	"github.com/tonytcb/ethereum-blockchain-parser/pkg/infra/storage"
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/infra/stream"
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/infra/verifier"
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/infra/webhook"
 )
 
+// defaultNodeStatusInterval is the interval the node status is refreshed on, when none is configured.
+const defaultNodeStatusInterval = time.Minute
+
 type Application struct {
-	cfg           *Config
-	logger        *slog.Logger
-	eventListener domain.EventListener
-	httpServer    *HTTPServer
+	cfg        *Config
+	logger     *slog.Logger
+	chains     []*chainComponents
+	webhooks   *webhook.Dispatcher
+	sinks      *sink.FanOut
+	httpServer *HTTPServer
+}
+
+// chainComponents are the components indexing a single chain.
+type chainComponents struct {
+	cfg           ChainConfig
+	api           *ethjsonrpc.EthJSONRpc
+	repository    *storage.InMemory
+	eventListener *eventlistener.PoolingEventListener
+	tracer        *eventlistener.InternalTransactionTracer
+	pending       *eventlistener.PendingTransactionTracker
+	heads         *eventlistener.HeadTracker
+	gaps          *eventlistener.GapRepairer
+	verifier      *verifier.Verifier
+
+	mu         sync.RWMutex
+	nodeStatus domain.NodeStatus
+	// verified is set once the node passed the check, the chain being started
+	verified bool
 }
 
 func NewApplication(ctx context.Context, cfg *Config, logger *slog.Logger) *Application {
+	// the event listeners outlive the given context, they're closed by Run once the other components are stopped
+	ctx = context.WithoutCancel(ctx)
+
+	var (
+		chains = make([]*chainComponents, len(cfg.Chains))
+		// store holds the data shared by every chain, i.e. webhooks, events and sink cursors
+		store    = storage.NewInMemory()
+		eventLog = newEventLog(cfg, store)
+		events   = stream.NewBroker(eventLog, stream.WithLogger(logger))
+		webhooks = webhook.NewDispatcher(
+			store,
+			webhook.WithLogger(logger),
+			webhook.WithDefaultChain(cfg.Chains[0].ChainID),
+			webhook.WithConfig(&webhook.Config{
+				Workers:        cfg.WebhookWorkers,
+				MaxAttempts:    cfg.WebhookAttempts,
+				InitialBackoff: cfg.WebhookBackoff,
+				MaxBackoff:     cfg.WebhookMaxBackoff,
+				RequestTimeout: cfg.RequestTimeout,
+				QueueSize:      cfg.WebhookQueueSize,
+			}),
+		)
+	)
+
+	var checkpoints backfill.CheckpointStore
+
+	// the jobs of every chain share the file, their IDs holding the chain ID
+	if cfg.BackfillStateFile != "" {
+		checkpoints = storage.NewFileCheckpoints(cfg.BackfillStateFile)
+	}
+
+	for i, chainCfg := range cfg.Chains {
+		chainLogger := logger.With("chain", chainCfg.Name)
+		chains[i] = newChainComponents(ctx, cfg, chainCfg, chainLogger, webhooks, events, checkpoints)
+	}
+
 	var (
-		repository = storage.NewInMemory()
-		api        = ethjsonrpc.NewEthJSONRpc(&ethjsonrpc.Config{
-			APIURL:         cfg.EthereumRPCAPIURL,
+		defaultChain = chains[0]
+		others       = make([]domain.Chain, 0, len(chains)-1)
+	)
+
+	for _, chain := range chains[1:] {
+		others = append(others, domain.Chain{
+			ID:            chain.cfg.ChainID,
+			Repo:          chain.repository,
+			EventListener: chain.eventListener,
+			Verifier:      chain.verifier,
+		})
+	}
+
+	parser := domain.NewParserV2(
+		defaultChain.repository,
+		defaultChain.eventListener,
+		domain.WithLogger(logger),
+		domain.WithDefaultChain(defaultChain.cfg.ChainID),
+		domain.WithVerifier(defaultChain.verifier),
+		domain.WithChains(others...),
+		domain.WithEventSource(events),
+	)
+
+	app := &Application{
+		logger:   logger,
+		cfg:      cfg,
+		chains:   chains,
+		webhooks: webhooks,
+	}
+
+	if sinks := newSinks(cfg); len(sinks) > 0 {
+		app.sinks = sink.NewFanOut(eventLog, eventLog, sinks, sink.WithLogger(logger), sink.WithEventSource(events))
+	}
+
+	app.httpServer = NewHTTPServer(
+		&HTTPConfig{
+			Port:            cfg.HTTPPort,
+			ReadTimeout:     cfg.HTTPReadTimeout,
+			WriteTimeout:    cfg.HTTPWriteTimeout,
+			IdleTimeout:     cfg.HTTPIdleTimeout,
+			ShutdownTimeout: cfg.ShutdownTimeout,
+		},
+		logger,
+		parser,
+		app,
+		webhooks,
+		events,
+	)
+
+	return app
+}
+
+func newChainComponents(
+	ctx context.Context,
+	cfg *Config,
+	chainCfg ChainConfig,
+	logger *slog.Logger,
+	notifier eventlistener.Notifier,
+	publisher eventlistener.EventPublisher,
+	checkpoints backfill.CheckpointStore,
+) *chainComponents {
+	var repository = storage.NewInMemory()
+
+	if checkpoints == nil {
+		checkpoints = repository
+	}
+
+	var (
+		api = ethjsonrpc.NewEthJSONRpc(&ethjsonrpc.Config{
+			APIURL:         chainCfg.RPCAPIURL,
 			RequestTimeout: cfg.RequestTimeout,
+			RateLimit:      cfg.RPCRateLimit,
+			RateBurst:      cfg.RPCRateBurst,
 		})
 		eventListener = eventlistener.NewPoolingEventListener(
 			ctx,
 			api,
 			repository,
-			eventlistener.WithLogger(logger),
-			eventlistener.WithConfig(&eventlistener.Config{PoolingTime: cfg.PoolingTime}),
+			listenerOptions(cfg, chainCfg, logger, api, checkpoints, repository, notifier, publisher)...,
 		)
+	)
+
+	var tracer *eventlistener.InternalTransactionTracer
 
-		parser = domain.NewParser(repository, eventListener)
+	if cfg.TraceInternalTxs {
+		tracer = eventlistener.NewInternalTransactionTracer(
+			api,
+			repository,
+			eventListener,
+			eventlistener.WithTracerLogger(logger),
+			eventlistener.WithTracerPublishers(publisher),
+			// kept apart from the coverage of the logs, not to hide their gaps to the repairer
+			eventlistener.WithTracerCoverage(storage.NewInMemory()),
+			eventlistener.WithTracerConfig(&eventlistener.Config{
+				PoolingTime:   chainCfg.PoolingTime,
+				ChainID:       chainCfg.ChainID,
+				Confirmations: chainCfg.Confirmations,
+			}),
+		)
+	}
 
-		httpServer = NewHTTPServer(cfg.HTTPPort, parser)
+	var pending *eventlistener.PendingTransactionTracker
+
+	if cfg.TrackPendingTxs {
+		pending = eventlistener.NewPendingTransactionTracker(
+			api,
+			repository,
+			eventListener,
+			eventlistener.WithPendingTrackerLogger(logger),
+			eventlistener.WithPendingTrackerConfig(&eventlistener.PendingConfig{
+				PoolingTime: chainCfg.PoolingTime,
+				ChainID:     chainCfg.ChainID,
+				DropTimeout: cfg.PendingDropTime,
+			}),
+		)
+	}
+
+	heads := eventlistener.NewHeadTracker(
+		api,
+		[]eventlistener.EventPublisher{publisher},
+		eventlistener.WithHeadTrackerLogger(logger),
+		eventlistener.WithHeadListeners(eventListener),
+		eventlistener.WithHeadTrackerConfig(&eventlistener.Config{
+			PoolingTime: chainCfg.PoolingTime,
+			ChainID:     chainCfg.ChainID,
+		}),
+	)
+
+	gaps := eventlistener.NewGapRepairer(
+		eventListener,
+		repository,
+		eventlistener.WithGapRepairerLogger(logger),
+		eventlistener.WithGapRepairerConfig(&eventlistener.GapRepairerConfig{Interval: cfg.GapRepairInterval}),
+	)
+
+	integrity := verifier.NewVerifier(
+		api,
+		repository,
+		verifier.WithLogger(logger),
+		verifier.WithRepairer(eventListener),
+		verifier.WithConfig(&verifier.Config{
+			ChunkSize:     cfg.BackfillChunkSize,
+			MaxChunkSize:  cfg.BackfillMaxChunk,
+			Workers:       cfg.BackfillWorkers,
+			Confirmations: chainCfg.Confirmations,
+		}),
 	)
 
-	return &Application{
-		logger:        logger,
-		cfg:           cfg,
+	return &chainComponents{
+		cfg:           chainCfg,
+		api:           api,
+		repository:    repository,
 		eventListener: eventListener,
-		httpServer:    httpServer,
+		tracer:        tracer,
+		pending:       pending,
+		heads:         heads,
+		gaps:          gaps,
+		verifier:      integrity,
+	}
+}
+
+// eventStore holds the log the events are published to, along with the cursors of the sinks fed from it.
+type eventStore interface {
+	stream.EventLog
+	sink.CursorStore
+}
+
+// newEventLog persists the event log and the sink cursors into EVENT_LOG_FILE, so that sinks resume after a restart
+// from the last event they wrote, falling back to the given store.
+func newEventLog(cfg *Config, store *storage.InMemory) eventStore {
+	if cfg.EventLogFile == "" {
+		return store
+	}
+
+	return storage.NewFileEvents(cfg.EventLogFile)
+}
+
+func newSinks(cfg *Config) []sink.EventSink {
+	var sinks = make([]sink.EventSink, 0, len(cfg.Sinks))
+
+	for _, name := range cfg.Sinks {
+		switch strings.TrimSpace(name) {
+		case sinkStdout:
+			sinks = append(sinks, sink.NewStdoutSink())
+		case sinkFile:
+			sinks = append(sinks, sink.NewFileSink(
+				sinkFile,
+				cfg.SinkFilePath,
+				sink.WithRotation(cfg.SinkFileMaxSize, cfg.SinkFileMaxFiles),
+			))
+		}
 	}
+
+	return sinks
 }
 
-//nolint:unparam
+func listenerOptions(
+	cfg *Config,
+	chainCfg ChainConfig,
+	logger *slog.Logger,
+	api *ethjsonrpc.EthJSONRpc,
+	checkpoints backfill.CheckpointStore,
+	coverage eventlistener.CoverageStore,
+	notifier eventlistener.Notifier,
+	publisher eventlistener.EventPublisher,
+) []eventlistener.Options {
+	engine := backfill.NewEngine(
+		api,
+		backfill.WithLogger(logger),
+		backfill.WithCheckpoints(checkpoints),
+		backfill.WithConfig(&backfill.Config{
+			Workers:      cfg.BackfillWorkers,
+			ChunkSize:    cfg.BackfillChunkSize,
+			MaxChunkSize: cfg.BackfillMaxChunk,
+		}),
+	)
+
+	opts := []eventlistener.Options{
+		eventlistener.WithLogger(logger),
+		eventlistener.WithNotifiers(notifier),
+		eventlistener.WithPublishers(publisher),
+		eventlistener.WithBackfill(engine),
+		eventlistener.WithCoverage(coverage),
+		eventlistener.WithConfig(&eventlistener.Config{
+			PoolingTime:    chainCfg.PoolingTime,
+			MaxPoolingTime: chainCfg.MaxPoolingTime,
+			ChainID:        chainCfg.ChainID,
+			Confirmations:  chainCfg.Confirmations,
+		}),
+	}
+
+	if cfg.EnrichReceipts {
+		opts = append(opts, eventlistener.WithEnrichers(eventlistener.NewReceiptEnricher(api)))
+	}
+
+	if cfg.EnrichBlocks {
+		opts = append(opts, eventlistener.WithEnrichers(eventlistener.NewBlockHeaderEnricher(api, cfg.BlockCacheSize)))
+	}
+
+	return opts
+}
+
+// Run runs the application until the context is done or one of its components fails, whose error is returned.
+// Before returning, it closes the event listeners, which store their last records and uninstall their filters.
 func (a *Application) Run(ctx context.Context) error {
-	errGroup, _ := errgroup.WithContext(ctx)
+	errGroup, groupCtx := errgroup.WithContext(ctx)
+
+	errGroup.Go(func() error {
+		return a.httpServer.Run(groupCtx)
+	})
 
 	errGroup.Go(func() error {
-		return a.httpServer.Start()
+		if err := a.webhooks.Run(groupCtx); err != nil && !errors.Is(err, context.Canceled) {
+			return errors.Wrap(err, "webhook dispatcher failed")
+		}
+
+		return nil
+	})
+
+	if a.sinks != nil {
+		errGroup.Go(func() error {
+			return a.sinks.Run(groupCtx)
+		})
+	}
+
+	// the chains start once their node is checked, the HTTP server reporting its status meanwhile
+	for _, chain := range a.chains {
+		chain, logger := chain, a.logger.With("chain", chain.cfg.Name)
+
+		errGroup.Go(func() error {
+			if err := chain.checkNode(groupCtx, a.cfg.WaitForSync, logger); err != nil {
+				if groupCtx.Err() != nil {
+					return nil
+				}
+
+				return errors.Wrapf(err, "chain %s: node check failed", chain.cfg.Name)
+			}
+
+			chain.setVerified()
+
+			if err := chain.eventListener.ResumeBackfills(groupCtx); err != nil {
+				return errors.Wrapf(err, "chain %s: failed to resume backfills", chain.cfg.Name)
+			}
+
+			chain.run(groupCtx, errGroup, a.cfg.StatusInterval, logger)
+
+			return nil
+		})
+	}
+
+	err := errGroup.Wait()
+
+	a.logger.Info("Gracefully shutting down...")
+
+	if stopErr := a.stopChains(); stopErr != nil && err == nil {
+		return stopErr
+	}
+
+	return err
+}
+
+// stopChains closes the event listeners of every chain in parallel, within the shutdown timeout.
+func (a *Application) stopChains() error {
+	ctx, cancel := context.WithTimeout(context.Background(), a.httpServer.cfg.ShutdownTimeout)
+	defer cancel()
+
+	var errGroup errgroup.Group
+
+	for _, chain := range a.chains {
+		chain := chain
+
+		errGroup.Go(func() error {
+			if err := chain.eventListener.Close(ctx); err != nil {
+				a.logger.Error("Failed to close event listener", "chain", chain.cfg.Name, "error", err)
+				return errors.Wrapf(err, "chain %s", chain.cfg.Name)
+			}
+
+			return nil
+		})
+	}
+
+	return errGroup.Wait()
+}
+
+func (c *chainComponents) run(
+	ctx context.Context,
+	group *errgroup.Group,
+	nodeStatusInterval time.Duration,
+	logger *slog.Logger,
+) {
+	group.Go(func() error {
+		return c.heads.Run(ctx)
+	})
+
+	group.Go(func() error {
+		c.refreshNodeStatus(ctx, nodeStatusInterval, logger)
+		return nil
+	})
+
+	group.Go(func() error {
+		return c.gaps.Run(ctx)
 	})
 
-	return nil
+	if c.tracer != nil {
+		group.Go(func() error {
+			if err := c.tracer.Run(ctx); err != nil {
+				logger.Warn("Internal transactions tracing disabled", "error", err)
+			}
+
+			return nil
+		})
+	}
+
+	if c.pending != nil {
+		group.Go(func() error {
+			if err := c.pending.Run(ctx); err != nil {
+				logger.Warn("Pending transactions tracking disabled", "error", err)
+			}
+
+			return nil
+		})
+	}
 }
 
-func (a *Application) Stop() error {
-	return a.httpServer.Stop()
+// checkNode refuses nodes serving another chain than the configured one, and warns about syncing nodes,
+// waiting for them to be synced when wait is set.
+func (c *chainComponents) checkNode(ctx context.Context, wait bool, logger *slog.Logger) error {
+	for {
+		status, err := c.api.NodeStatus(ctx)
+		if err != nil {
+			return err
+		}
+
+		c.setNodeStatus(status)
+
+		if err = status.Verify(c.cfg.ChainID); err != nil {
+			return err
+		}
+
+		logger.Info(
+			"Node checked",
+			"client_version", status.ClientVersion,
+			"network_id", status.NetworkID,
+			"syncing", status.Syncing,
+		)
+
+		if !status.Syncing {
+			return nil
+		}
+
+		logger.Warn(
+			"Node is still syncing",
+			"current_block", status.CurrentBlock,
+			"highest_block", status.HighestBlock,
+			"progress", status.SyncProgress(),
+		)
+
+		if !wait {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(c.cfg.PoolingTime):
+		}
+	}
+}
+
+// refreshNodeStatus checks the node every interval until the context is done, keeping its last status.
+func (c *chainComponents) refreshNodeStatus(ctx context.Context, interval time.Duration, logger *slog.Logger) {
+	if interval <= 0 {
+		interval = defaultNodeStatusInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+			status, err := c.api.NodeStatus(ctx)
+			if err != nil {
+				logger.Warn("Failed to refresh node status", "error", err)
+				continue
+			}
+
+			c.setNodeStatus(status)
+
+			if err = status.Verify(c.cfg.ChainID); err != nil {
+				logger.Error("Node no longer serves the configured chain", "error", err)
+			} else if status.Syncing {
+				logger.Warn("Node is syncing", "progress", status.SyncProgress())
+			}
+		}
+	}
+}
+
+func (c *chainComponents) setNodeStatus(status domain.NodeStatus) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.nodeStatus = status
+}
+
+func (c *chainComponents) setVerified() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.verified = true
+}
+
+// isVerified tells whether the node passed the check.
+func (c *chainComponents) isVerified() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.verified
+}
+
+// status returns the last known status of the node.
+func (c *chainComponents) status() domain.NodeStatus {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.nodeStatus
+}
+
+// NodeStatuses returns the last known status of the node serving each chain.
+func (a *Application) NodeStatuses() []ChainStatus {
+	var statuses = make([]ChainStatus, len(a.chains))
+
+	for i, chain := range a.chains {
+		statuses[i] = ChainStatus{Name: chain.cfg.Name, Node: chain.status()}
+	}
+
+	return statuses
+}
+
+// Ready tells whether the node of every chain passed the check, the subscriptions being accepted from then on.
+func (a *Application) Ready() bool {
+	for _, chain := range a.chains {
+		if !chain.isVerified() {
+			return false
+		}
+	}
+
+	return true
 }
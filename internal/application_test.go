@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/infra/ethjsonrpc"
+)
+
+func TestChainComponents_RefreshNodeStatus(t *testing.T) {
+	var (
+		logger  = slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))
+		results = map[string]interface{}{
+			"eth_chainId":        "0x89",
+			"net_version":        "137",
+			"web3_clientVersion": "Geth/v1.13.5",
+			"eth_syncing":        false,
+		}
+	)
+
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID     int64  `json:"id"`
+			Method string `json:"method"`
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, "invalid request", http.StatusBadRequest)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      request.ID,
+			"result":  results[request.Method],
+		})
+	}))
+	defer node.Close()
+
+	chain := &chainComponents{
+		cfg: ChainConfig{Name: "polygon", ChainID: 137},
+		api: ethjsonrpc.NewEthJSONRpc(&ethjsonrpc.Config{APIURL: node.URL}),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		chain.refreshNodeStatus(ctx, 10*time.Millisecond, logger)
+	}()
+
+	deadline := time.After(time.Second)
+
+	for chain.status().ClientVersion == "" {
+		select {
+		case <-deadline:
+			t.Fatalf("node status never refreshed")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	cancel()
+	<-done
+
+	if got := chain.status(); got.ChainID != 137 || got.CheckedAt.IsZero() {
+		t.Errorf("node status = %+v, want the status of the node", got)
+	}
+}
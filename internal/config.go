@@ -1,27 +1,119 @@
 package main
 
 import (
+	"fmt"
 	"log"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
 )
 
 const (
 	mainEnvFile = "internal/.env"
+
+	sinkStdout = "stdout"
+	sinkFile   = "file"
 )
 
 type Config struct {
 	LogLevel          string        `mapstructure:"LOG_LEVEL"`
 	HTTPPort          string        `mapstructure:"HTTP_PORT"`
+	HTTPReadTimeout   time.Duration `mapstructure:"HTTP_READ_TIMEOUT"`
+	HTTPWriteTimeout  time.Duration `mapstructure:"HTTP_WRITE_TIMEOUT"`
+	HTTPIdleTimeout   time.Duration `mapstructure:"HTTP_IDLE_TIMEOUT"`
+	ShutdownTimeout   time.Duration `mapstructure:"SHUTDOWN_TIMEOUT"`
 	EthereumRPCAPIURL string        `mapstructure:"ETHEREUM_RPC_API_URL"`
+	EthereumChainID   uint64        `mapstructure:"ETHEREUM_CHAIN_ID"`
+	ChainNames        []string      `mapstructure:"CHAINS"`
 	PoolingTime       time.Duration `mapstructure:"POOLING_TIME"`
+	MaxPoolingTime    time.Duration `mapstructure:"MAX_POOLING_TIME"`
+	Confirmations     uint64        `mapstructure:"CONFIRMATIONS"`
 	RequestTimeout    time.Duration `mapstructure:"REQUEST_TIMEOUT"`
+	RPCRateLimit      float64       `mapstructure:"RPC_RATE_LIMIT"`
+	RPCRateBurst      int           `mapstructure:"RPC_RATE_BURST"`
+	BackfillWorkers   int           `mapstructure:"BACKFILL_WORKERS"`
+	BackfillChunkSize uint64        `mapstructure:"BACKFILL_CHUNK_SIZE"`
+	BackfillMaxChunk  uint64        `mapstructure:"BACKFILL_MAX_CHUNK_SIZE"`
+	BackfillStateFile string        `mapstructure:"BACKFILL_CHECKPOINTS_FILE"`
+	GapRepairInterval time.Duration `mapstructure:"GAP_REPAIR_INTERVAL"`
+	EnrichReceipts    bool          `mapstructure:"ENRICH_RECEIPTS"`
+	EnrichBlocks      bool          `mapstructure:"ENRICH_BLOCKS"`
+	BlockCacheSize    int           `mapstructure:"BLOCK_CACHE_SIZE"`
+	TraceInternalTxs  bool          `mapstructure:"TRACE_INTERNAL_TRANSACTIONS"`
+	TrackPendingTxs   bool          `mapstructure:"TRACK_PENDING_TRANSACTIONS"`
+	PendingDropTime   time.Duration `mapstructure:"PENDING_DROP_TIMEOUT"`
+	WaitForSync       bool          `mapstructure:"WAIT_FOR_SYNC"`
+	StatusInterval    time.Duration `mapstructure:"NODE_STATUS_INTERVAL"`
+	WebhookWorkers    int           `mapstructure:"WEBHOOK_WORKERS"`
+	WebhookAttempts   int           `mapstructure:"WEBHOOK_MAX_ATTEMPTS"`
+	WebhookBackoff    time.Duration `mapstructure:"WEBHOOK_INITIAL_BACKOFF"`
+	WebhookMaxBackoff time.Duration `mapstructure:"WEBHOOK_MAX_BACKOFF"`
+	WebhookQueueSize  int           `mapstructure:"WEBHOOK_QUEUE_SIZE"`
+	Sinks             []string      `mapstructure:"SINKS"`
+	SinkFilePath      string        `mapstructure:"SINK_FILE_PATH"`
+	SinkFileMaxSize   int64         `mapstructure:"SINK_FILE_MAX_SIZE"`
+	SinkFileMaxFiles  int           `mapstructure:"SINK_FILE_MAX_FILES"`
+	EventLogFile      string        `mapstructure:"EVENT_LOG_FILE"`
+
+	// Chains is built from CHAINS, the first one being the default chain
+	Chains []ChainConfig `mapstructure:"-"`
+}
+
+// ChainConfig configures a chain named NAME through the CHAIN_<NAME>_* variables,
+// unset poll intervals and confirmations fall back to the global ones.
+type ChainConfig struct {
+	Name           string
+	RPCAPIURL      string
+	ChainID        domain.ChainID
+	PoolingTime    time.Duration
+	MaxPoolingTime time.Duration
+	Confirmations  uint64
 }
 
 func (c *Config) IsValid() error {
+	if len(c.Chains) == 0 {
+		return errors.New("no chain configured")
+	}
+
+	var ids = make(map[domain.ChainID]string, len(c.Chains))
+
+	for _, chain := range c.Chains {
+		if chain.RPCAPIURL == "" {
+			return errors.Errorf("chain %s: missing RPC API URL", chain.Name)
+		}
+
+		if chain.ChainID == 0 {
+			return errors.Errorf("chain %s: missing chain ID", chain.Name)
+		}
+
+		if name, ok := ids[chain.ChainID]; ok {
+			return errors.Errorf("chain %s: chain ID %d already used by %s", chain.Name, chain.ChainID, name)
+		}
+
+		ids[chain.ChainID] = chain.Name
+	}
+
+	if c.WebhookWorkers <= 0 || c.WebhookAttempts <= 0 || c.WebhookQueueSize <= 0 {
+		return errors.New("webhook workers, attempts and queue size must be greater than zero")
+	}
+
+	for _, name := range c.Sinks {
+		switch strings.TrimSpace(name) {
+		case sinkStdout:
+		case sinkFile:
+			if c.SinkFilePath == "" {
+				return errors.New("file sink: missing file path")
+			}
+		default:
+			return errors.Errorf("unknown sink %s", name)
+		}
+	}
+
 	return nil
 }
 
@@ -29,9 +121,40 @@ func (c *Config) LogFields() map[string]interface{} {
 	return map[string]interface{}{
 		"LogLevel":          c.LogLevel,
 		"HTTPPort":          c.HTTPPort,
-		"EthereumRPCAPIURL": c.EthereumRPCAPIURL,
+		"HTTPReadTimeout":   c.HTTPReadTimeout.String(),
+		"HTTPWriteTimeout":  c.HTTPWriteTimeout.String(),
+		"HTTPIdleTimeout":   c.HTTPIdleTimeout.String(),
+		"ShutdownTimeout":   c.ShutdownTimeout.String(),
+		"Chains":            c.Chains,
 		"PoolingTime":       c.PoolingTime.String(),
+		"MaxPoolingTime":    c.MaxPoolingTime.String(),
+		"Confirmations":     c.Confirmations,
 		"RequestTimeout":    c.RequestTimeout.String(),
+		"RPCRateLimit":      c.RPCRateLimit,
+		"RPCRateBurst":      c.RPCRateBurst,
+		"BackfillWorkers":   c.BackfillWorkers,
+		"BackfillChunkSize": c.BackfillChunkSize,
+		"BackfillMaxChunk":  c.BackfillMaxChunk,
+		"BackfillStateFile": c.BackfillStateFile,
+		"GapRepairInterval": c.GapRepairInterval.String(),
+		"EnrichReceipts":    c.EnrichReceipts,
+		"EnrichBlocks":      c.EnrichBlocks,
+		"BlockCacheSize":    c.BlockCacheSize,
+		"TraceInternalTxs":  c.TraceInternalTxs,
+		"TrackPendingTxs":   c.TrackPendingTxs,
+		"PendingDropTime":   c.PendingDropTime.String(),
+		"WaitForSync":       c.WaitForSync,
+		"StatusInterval":    c.StatusInterval.String(),
+		"WebhookWorkers":    c.WebhookWorkers,
+		"WebhookAttempts":   c.WebhookAttempts,
+		"WebhookBackoff":    c.WebhookBackoff.String(),
+		"WebhookMaxBackoff": c.WebhookMaxBackoff.String(),
+		"WebhookQueueSize":  c.WebhookQueueSize,
+		"Sinks":             c.Sinks,
+		"SinkFilePath":      c.SinkFilePath,
+		"SinkFileMaxSize":   c.SinkFileMaxSize,
+		"SinkFileMaxFiles":  c.SinkFileMaxFiles,
+		"EventLogFile":      c.EventLogFile,
 	}
 }
 
@@ -64,5 +187,65 @@ func loadConfig(filenames ...string) (*Config, error) {
 		}
 	}
 
+	cfg.Chains = loadChains(cfg)
+
 	return cfg, nil
 }
+
+// loadChains builds the configuration of every chain listed in CHAINS.
+// With no CHAINS the Ethereum chain is configured from the ETHEREUM_* variables, for backward compatibility.
+func loadChains(cfg *Config) []ChainConfig {
+	if len(cfg.ChainNames) == 0 {
+		chainID := domain.ChainID(cfg.EthereumChainID)
+		if chainID == 0 {
+			chainID = domain.DefaultChainID
+		}
+
+		return []ChainConfig{{
+			Name:           "ethereum",
+			RPCAPIURL:      cfg.EthereumRPCAPIURL,
+			ChainID:        chainID,
+			PoolingTime:    cfg.PoolingTime,
+			MaxPoolingTime: cfg.MaxPoolingTime,
+			Confirmations:  cfg.Confirmations,
+		}}
+	}
+
+	var chains = make([]ChainConfig, 0, len(cfg.ChainNames))
+
+	for _, name := range cfg.ChainNames {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		key := func(suffix string) string {
+			return fmt.Sprintf("CHAIN_%s_%s", strings.ToUpper(name), suffix)
+		}
+
+		chain := ChainConfig{
+			Name:           name,
+			RPCAPIURL:      viper.GetString(key("RPC_API_URL")),
+			ChainID:        domain.ChainID(viper.GetUint64(key("CHAIN_ID"))),
+			PoolingTime:    cfg.PoolingTime,
+			MaxPoolingTime: cfg.MaxPoolingTime,
+			Confirmations:  cfg.Confirmations,
+		}
+
+		if viper.IsSet(key("POOLING_TIME")) {
+			chain.PoolingTime = viper.GetDuration(key("POOLING_TIME"))
+		}
+
+		if viper.IsSet(key("MAX_POOLING_TIME")) {
+			chain.MaxPoolingTime = viper.GetDuration(key("MAX_POOLING_TIME"))
+		}
+
+		if viper.IsSet(key("CONFIRMATIONS")) {
+			chain.Confirmations = viper.GetUint64(key("CONFIRMATIONS"))
+		}
+
+		chains = append(chains, chain)
+	}
+
+	return chains
+}
@@ -1,39 +1,239 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
+	"net"
 	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
 
 	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
 )
 
+const (
+	defaultReadTimeout     = 10 * time.Second
+	defaultWriteTimeout    = 30 * time.Second
+	defaultIdleTimeout     = 2 * time.Minute
+	defaultShutdownTimeout = 15 * time.Second
+)
+
+// HTTPConfig configures the HTTP server, zero timeouts falling back to the default ones.
+type HTTPConfig struct {
+	Port         string
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	IdleTimeout  time.Duration
+	// ShutdownTimeout is the time given to in-flight requests to complete on shutdown
+	ShutdownTimeout time.Duration
+}
+
 type HTTPServer struct {
-	port   string
-	parser domain.Parser
+	cfg      *HTTPConfig
+	logger   *slog.Logger
+	server   *http.Server
+	parser   domain.ParserV2
+	status   NodeStatusReader
+	webhooks WebhookService
+	events   EventStream
+	// shutdown is closed once the server starts shutting down, for long-lived streams to end
+	shutdown chan struct{}
+}
+
+// NodeStatusReader exposes the status of the node serving each configured chain, and whether they all passed the check.
+type NodeStatusReader interface {
+	NodeStatuses() []ChainStatus
+	Ready() bool
 }
 
-func NewHTTPServer(port string, parser domain.Parser) *HTTPServer {
-	return &HTTPServer{port: port, parser: parser}
+// WebhookService registers the webhooks of subscribed addresses, and exposes their deliveries.
+type WebhookService interface {
+	Register(ctx context.Context, webhook domain.Webhook) error
+	Deliveries(ctx context.Context, address domain.Address) ([]domain.WebhookDelivery, error)
+	DeadLetters(ctx context.Context) ([]domain.WebhookDelivery, error)
+	Replay(ctx context.Context, id string) error
 }
 
-func (s *HTTPServer) Start() error {
-	http.HandleFunc("/subscribe", s.subscribeHandler)
-	http.HandleFunc("/transactions", s.getTransactionsHandler)
-	http.HandleFunc("/current-block", s.getCurrentBlockHandler)
+// EventStream streams the events of the indexed records, optionally resuming after a given event.
+type EventStream interface {
+	Subscribe(ctx context.Context, filter domain.EventFilter, opts ...domain.WatchOption) <-chan domain.Event
+	SubscribeSince(
+		ctx context.Context,
+		filter domain.EventFilter,
+		afterID uint64,
+		opts ...domain.WatchOption,
+	) <-chan domain.Event
+}
 
-	go func() {
-		if err := http.ListenAndServe(fmt.Sprintf(":%s", s.port), nil); err != nil {
-			panic(err)
+type ChainStatus struct {
+	Name string            `json:"name"`
+	Node domain.NodeStatus `json:"node"`
+}
+
+func NewHTTPServer(
+	cfg *HTTPConfig,
+	logger *slog.Logger,
+	parser domain.ParserV2,
+	status NodeStatusReader,
+	webhooks WebhookService,
+	events EventStream,
+) *HTTPServer {
+	s := &HTTPServer{
+		cfg:      withDefaultTimeouts(*cfg),
+		logger:   logger,
+		parser:   parser,
+		status:   status,
+		webhooks: webhooks,
+		events:   events,
+		shutdown: make(chan struct{}),
+	}
+
+	s.server = &http.Server{
+		Addr:         fmt.Sprintf(":%s", s.cfg.Port),
+		Handler:      s.router(),
+		ReadTimeout:  s.cfg.ReadTimeout,
+		WriteTimeout: s.cfg.WriteTimeout,
+		IdleTimeout:  s.cfg.IdleTimeout,
+		ErrorLog:     slog.NewLogLogger(logger.Handler(), slog.LevelError),
+	}
+
+	s.server.RegisterOnShutdown(func() {
+		close(s.shutdown)
+	})
+
+	return s
+}
+
+func withDefaultTimeouts(cfg HTTPConfig) *HTTPConfig {
+	if cfg.ReadTimeout <= 0 {
+		cfg.ReadTimeout = defaultReadTimeout
+	}
+
+	if cfg.WriteTimeout <= 0 {
+		cfg.WriteTimeout = defaultWriteTimeout
+	}
+
+	if cfg.IdleTimeout <= 0 {
+		cfg.IdleTimeout = defaultIdleTimeout
+	}
+
+	if cfg.ShutdownTimeout <= 0 {
+		cfg.ShutdownTimeout = defaultShutdownTimeout
+	}
+
+	return &cfg
+}
+
+func (s *HTTPServer) router() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.Handle("/subscribe", routes{http.MethodPost: s.whenReady(s.subscribeHandler)})
+	mux.Handle("/transactions", routes{http.MethodGet: s.getTransactionsHandler})
+	mux.Handle("/transactions/", routes{http.MethodGet: s.getTransactionByHashHandler})
+	mux.Handle("/blocks/", routes{http.MethodGet: s.getBlockTransactionsHandler})
+	mux.Handle("/current-block", routes{http.MethodGet: s.getCurrentBlockHandler})
+	mux.Handle("/pending-transactions", routes{http.MethodGet: s.getPendingTransactionsHandler})
+	mux.Handle("/status", routes{http.MethodGet: s.getStatusHandler})
+	mux.Handle("/subscriptions", routes{http.MethodGet: s.getSubscriptionsHandler})
+	mux.Handle("/subscriptions/", routes{
+		http.MethodGet:  s.getSubscriptionHandler,
+		http.MethodPost: s.whenReady(s.subscriptionActionHandler),
+	})
+	mux.Handle("/webhooks", routes{http.MethodPost: s.whenReady(s.registerWebhookHandler)})
+	mux.Handle("/webhooks/deliveries", routes{http.MethodGet: s.getWebhookDeliveriesHandler})
+	mux.Handle("/webhooks/dead-letters", routes{http.MethodGet: s.getWebhookDeadLettersHandler})
+	mux.Handle("/webhooks/replay", routes{http.MethodPost: s.whenReady(s.replayWebhookHandler)})
+	mux.Handle("/stream", routes{http.MethodGet: s.streamHandler})
+
+	return mux
+}
+
+// whenReady answers with a 503 until the nodes passed the check, for no subscription to be made against a node serving
+// another chain.
+func (s *HTTPServer) whenReady(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.status.Ready() {
+			http.Error(w, "node check in progress", http.StatusServiceUnavailable)
+			return
 		}
+
+		next(w, r)
+	}
+}
+
+// Run serves the HTTP requests until the context is done, then shuts the server down gracefully.
+// It fails straight away when the port can't be listened on.
+func (s *HTTPServer) Run(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.server.Addr)
+	if err != nil {
+		return errors.Wrapf(err, "failed to listen on %s", s.server.Addr)
+	}
+
+	s.logger.Info("HTTP server listening", "address", listener.Addr().String())
+
+	served := make(chan error, 1)
+
+	go func() {
+		served <- s.server.Serve(listener)
 	}()
 
+	select {
+	case err = <-served:
+		return errors.Wrap(err, "failed to serve HTTP requests")
+	case <-ctx.Done():
+	}
+
+	return s.Shutdown()
+}
+
+// Shutdown stops accepting connections and waits up to the shutdown timeout for the in-flight requests,
+// closing the remaining connections past it.
+func (s *HTTPServer) Shutdown() error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.cfg.ShutdownTimeout)
+	defer cancel()
+
+	if err := s.server.Shutdown(ctx); err != nil {
+		_ = s.server.Close()
+		return errors.Wrap(err, "failed to shutdown the HTTP server gracefully")
+	}
+
+	s.logger.Info("HTTP server stopped")
+
 	return nil
 }
 
-func (s *HTTPServer) Stop() error {
-	return nil // @TODO implement me
+// routes maps the methods accepted by an endpoint to their handler, the other ones being answered with a 405.
+type routes map[string]http.HandlerFunc
+
+func (r routes) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	handler, ok := r[req.Method]
+	if !ok && req.Method == http.MethodHead {
+		handler, ok = r[http.MethodGet]
+	}
+
+	if !ok {
+		var allowed = make([]string, 0, len(r))
+
+		for method := range r {
+			allowed = append(allowed, method)
+		}
+
+		sort.Strings(allowed)
+
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	handler(w, req)
 }
 
 func (s *HTTPServer) subscribeHandler(w http.ResponseWriter, req *http.Request) {
@@ -47,7 +247,15 @@ func (s *HTTPServer) subscribeHandler(w http.ResponseWriter, req *http.Request)
 	}()
 
 	type payloadRequest struct {
-		Address string `json:"address"`
+		Address string         `json:"address"`
+		Chain   domain.ChainID `json:"chain"`
+		// Interval is a duration, e.g. "30s", the address being polled on every new head when empty
+		Interval string                      `json:"interval"`
+		Priority domain.SubscriptionPriority `json:"priority"`
+		Webhook  *struct {
+			URL    string `json:"url"`
+			Secret string `json:"secret"`
+		} `json:"webhook"`
 	}
 
 	data := &payloadRequest{}
@@ -57,25 +265,121 @@ func (s *HTTPServer) subscribeHandler(w http.ResponseWriter, req *http.Request)
 		return
 	}
 
-	if s.parser.Subscribe(data.Address) {
-		w.WriteHeader(http.StatusCreated)
+	address, err := domain.NewAddress(data.Address)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	opts, err := subscribeOptions(data.Interval, data.Priority)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var hook *domain.Webhook
+
+	if data.Webhook != nil {
+		webhook, err := domain.NewWebhook(data.Chain, address, data.Webhook.URL, data.Webhook.Secret)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		hook = &webhook
+	}
+
+	if err = s.parser.Subscribe(req.Context(), data.Chain, data.Address, opts...); err != nil {
+		writeError(w, err)
 		return
 	}
 
-	http.Error(w, "error to subscribe", http.StatusServiceUnavailable)
+	// the webhook is registered once subscribed, a failed subscription keeping the webhook already registered, if any.
+	// The first poll waits for the pooling time or the next head, so the webhook is notified of the first records.
+	if hook != nil {
+		if err = s.webhooks.Register(req.Context(), *hook); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+// subscribeOptions parses the polling options of a subscription, validated before anything is registered.
+func subscribeOptions(interval string, priority domain.SubscriptionPriority) ([]domain.SubscribeOption, error) {
+	var opts []domain.SubscribeOption
+
+	if interval != "" {
+		d, err := time.ParseDuration(interval)
+		if err != nil {
+			return nil, errors.Wrapf(domain.ErrInvalidPolling, "interval %q", interval)
+		}
+
+		opts = append(opts, domain.WithPollingInterval(d))
+	}
+
+	if priority != "" {
+		opts = append(opts, domain.WithPriority(priority))
+	}
+
+	if _, err := domain.NewSubscribeOptions(opts...); err != nil {
+		return nil, err
+	}
+
+	return opts, nil
 }
 
 func (s *HTTPServer) getTransactionsHandler(w http.ResponseWriter, req *http.Request) {
 	var (
-		address      = req.URL.Query().Get("address")
-		transactions = s.parser.GetTransactions(address)
-		output       = map[string]interface{}{
-			"count":        len(transactions),
-			"transactions": transactions,
-		}
+		query   = req.URL.Query()
+		address = query.Get("address")
 	)
 
-	response, err := json.Marshal(output)
+	addr, err := domain.NewAddress(address)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	from, err := parseUnixTimestamp(query.Get("from"))
+	if err != nil {
+		http.Error(w, "invalid from timestamp", http.StatusBadRequest)
+		return
+	}
+
+	to, err := parseUnixTimestamp(query.Get("to"))
+	if err != nil {
+		http.Error(w, "invalid to timestamp", http.StatusBadRequest)
+		return
+	}
+
+	chain, err := parseChain(query.Get("chain"))
+	if err != nil {
+		http.Error(w, "invalid chain", http.StatusBadRequest)
+		return
+	}
+
+	txQuery, err := parseTransactionQuery(addr, query)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// the time range is filtered, ordered and paged along with the other filters
+	txQuery.FromTime, txQuery.ToTime = from, to
+
+	page, err := s.parser.QueryTransactions(req.Context(), chain, txQuery)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	response, err := json.Marshal(map[string]interface{}{
+		"count":        len(page.Transactions),
+		"transactions": page.Transactions,
+		"next_cursor":  page.NextCursor,
+	})
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -85,11 +389,90 @@ func (s *HTTPServer) getTransactionsHandler(w http.ResponseWriter, req *http.Req
 	_, _ = w.Write(response)
 }
 
-func (s *HTTPServer) getCurrentBlockHandler(w http.ResponseWriter, _ *http.Request) {
-	blockNumber := s.parser.GetCurrentBlock()
+// getTransactionByHashHandler serves /transactions/{hash}.
+func (s *HTTPServer) getTransactionByHashHandler(w http.ResponseWriter, req *http.Request) {
+	chain, err := parseChain(req.URL.Query().Get("chain"))
+	if err != nil {
+		http.Error(w, "invalid chain", http.StatusBadRequest)
+		return
+	}
+
+	hash := strings.TrimPrefix(req.URL.Path, "/transactions/")
+
+	transactions, err := s.parser.GetTransactionsByHash(req.Context(), chain, hash)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
 
-	if blockNumber == 0 {
-		http.Error(w, "", http.StatusNotFound)
+	writeTransactions(w, transactions)
+}
+
+// getBlockTransactionsHandler serves /blocks/{number}/transactions.
+func (s *HTTPServer) getBlockTransactionsHandler(w http.ResponseWriter, req *http.Request) {
+	number, ok := strings.CutSuffix(strings.TrimPrefix(req.URL.Path, "/blocks/"), "/transactions")
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+
+	blockNumber, err := strconv.ParseUint(number, 10, 64)
+	if err != nil {
+		http.Error(w, "invalid block number", http.StatusBadRequest)
+		return
+	}
+
+	chain, err := parseChain(req.URL.Query().Get("chain"))
+	if err != nil {
+		http.Error(w, "invalid chain", http.StatusBadRequest)
+		return
+	}
+
+	transactions, err := s.parser.GetBlockTransactions(req.Context(), chain, blockNumber)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeTransactions(w, transactions)
+}
+
+func (s *HTTPServer) getPendingTransactionsHandler(w http.ResponseWriter, req *http.Request) {
+	chain, err := parseChain(req.URL.Query().Get("chain"))
+	if err != nil {
+		http.Error(w, "invalid chain", http.StatusBadRequest)
+		return
+	}
+
+	transactions, err := s.parser.GetPendingTransactions(req.Context(), chain, req.URL.Query().Get("address"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	response, err := json.Marshal(map[string]interface{}{
+		"count":        len(transactions),
+		"transactions": transactions,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(response)
+}
+
+func (s *HTTPServer) getCurrentBlockHandler(w http.ResponseWriter, req *http.Request) {
+	chain, err := parseChain(req.URL.Query().Get("chain"))
+	if err != nil {
+		http.Error(w, "invalid chain", http.StatusBadRequest)
+		return
+	}
+
+	blockNumber, err := s.parser.GetCurrentBlock(req.Context(), chain)
+	if err != nil {
+		writeError(w, err)
 		return
 	}
 
@@ -102,3 +485,385 @@ func (s *HTTPServer) getCurrentBlockHandler(w http.ResponseWriter, _ *http.Reque
 	w.Header().Set("Content-Type", "application/json")
 	_, _ = w.Write(response)
 }
+
+func (s *HTTPServer) getStatusHandler(w http.ResponseWriter, _ *http.Request) {
+	response, err := json.Marshal(map[string]interface{}{"chains": s.status.NodeStatuses()})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(response)
+}
+
+func (s *HTTPServer) getSubscriptionsHandler(w http.ResponseWriter, req *http.Request) {
+	chain, err := parseChain(req.URL.Query().Get("chain"))
+	if err != nil {
+		http.Error(w, "invalid chain", http.StatusBadRequest)
+		return
+	}
+
+	subscriptions, err := s.parser.GetSubscriptions(req.Context(), chain)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"count":         len(subscriptions),
+		"subscriptions": subscriptions,
+	})
+}
+
+// getSubscriptionHandler serves /subscriptions/{address} and /subscriptions/{address}/gaps.
+func (s *HTTPServer) getSubscriptionHandler(w http.ResponseWriter, req *http.Request) {
+	chain, err := parseChain(req.URL.Query().Get("chain"))
+	if err != nil {
+		http.Error(w, "invalid chain", http.StatusBadRequest)
+		return
+	}
+
+	address, resource, _ := strings.Cut(strings.TrimPrefix(req.URL.Path, "/subscriptions/"), "/")
+
+	switch resource {
+	case "":
+	case "gaps":
+		s.getSubscriptionGaps(w, req, chain, address)
+		return
+	default:
+		http.NotFound(w, req)
+		return
+	}
+
+	subscription, err := s.parser.GetSubscription(req.Context(), chain, address)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, subscription)
+}
+
+// getSubscriptionGaps lists the gaps detected in the blocks indexed for the address, along with their repair.
+func (s *HTTPServer) getSubscriptionGaps(w http.ResponseWriter, req *http.Request, chain domain.ChainID, address string) {
+	gaps, err := s.parser.GetSubscriptionGaps(req.Context(), chain, address)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"count": len(gaps),
+		"gaps":  gaps,
+	})
+}
+
+// subscriptionActionHandler serves /subscriptions/{address}/pause, /subscriptions/{address}/resume,
+// /subscriptions/{address}/rescan and /subscriptions/{address}/verify.
+func (s *HTTPServer) subscriptionActionHandler(w http.ResponseWriter, req *http.Request) {
+	address, action, ok := strings.Cut(strings.TrimPrefix(req.URL.Path, "/subscriptions/"), "/")
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+
+	chain, err := parseChain(req.URL.Query().Get("chain"))
+	if err != nil {
+		http.Error(w, "invalid chain", http.StatusBadRequest)
+		return
+	}
+
+	switch action {
+	case "pause":
+		err = s.parser.PauseSubscription(req.Context(), chain, address)
+	case "resume":
+		err = s.parser.ResumeSubscription(req.Context(), chain, address)
+	case "rescan":
+		s.rescanSubscription(w, req, chain, address)
+		return
+	case "verify":
+		s.verifySubscription(w, req, chain, address)
+		return
+	default:
+		http.NotFound(w, req)
+		return
+	}
+
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// rescanSubscription starts the rescan of the address from the from_block parameter, its progress being reported by
+// /subscriptions/{address}.
+func (s *HTTPServer) rescanSubscription(w http.ResponseWriter, req *http.Request, chain domain.ChainID, address string) {
+	fromBlock, err := strconv.ParseUint(req.URL.Query().Get("from_block"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid from_block", http.StatusBadRequest)
+		return
+	}
+
+	if err = s.parser.RescanSubscription(req.Context(), chain, address, fromBlock); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", "/subscriptions/"+address)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// verifySubscription compares the stored records of the address within [from_block, to_block] with the logs of the
+// node, repairing the blocks found to differ when repair is set, and returns the report.
+func (s *HTTPServer) verifySubscription(w http.ResponseWriter, req *http.Request, chain domain.ChainID, address string) {
+	query := req.URL.Query()
+
+	fromBlock, err := strconv.ParseUint(query.Get("from_block"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid from_block", http.StatusBadRequest)
+		return
+	}
+
+	toBlock, err := strconv.ParseUint(query.Get("to_block"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid to_block", http.StatusBadRequest)
+		return
+	}
+
+	var repair bool
+
+	if value := query.Get("repair"); value != "" {
+		if repair, err = strconv.ParseBool(value); err != nil {
+			http.Error(w, "invalid repair", http.StatusBadRequest)
+			return
+		}
+	}
+
+	// verifying a wide range outlives the server write timeout
+	if err = http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+		http.Error(w, "verifying not supported", http.StatusInternalServerError)
+		return
+	}
+
+	report, err := s.parser.VerifySubscription(req.Context(), chain, address, fromBlock, toBlock, repair)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, report)
+}
+
+func (s *HTTPServer) registerWebhookHandler(w http.ResponseWriter, req *http.Request) {
+	var data struct {
+		Chain   domain.ChainID `json:"chain"`
+		Address string         `json:"address"`
+		URL     string         `json:"url"`
+		Secret  string         `json:"secret"`
+	}
+
+	if err := json.NewDecoder(req.Body).Decode(&data); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	address, err := domain.NewAddress(data.Address)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	hook, err := domain.NewWebhook(data.Chain, address, data.URL, data.Secret)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err = s.webhooks.Register(req.Context(), hook); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (s *HTTPServer) getWebhookDeliveriesHandler(w http.ResponseWriter, req *http.Request) {
+	address, err := domain.NewAddress(req.URL.Query().Get("address"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	deliveries, err := s.webhooks.Deliveries(req.Context(), address)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeDeliveries(w, deliveries)
+}
+
+func (s *HTTPServer) getWebhookDeadLettersHandler(w http.ResponseWriter, req *http.Request) {
+	deliveries, err := s.webhooks.DeadLetters(req.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeDeliveries(w, deliveries)
+}
+
+func (s *HTTPServer) replayWebhookHandler(w http.ResponseWriter, req *http.Request) {
+	var data struct {
+		ID string `json:"id"`
+	}
+
+	if err := json.NewDecoder(req.Body).Decode(&data); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err := s.webhooks.Replay(req.Context(), data.ID)
+
+	switch {
+	case errors.Is(err, domain.ErrDeliveryNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+	default:
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+// writeError maps the typed errors of the parser to their status code.
+func writeError(w http.ResponseWriter, err error) {
+	var status int
+
+	switch {
+	case errors.Is(err, domain.ErrInvalidAddress),
+		errors.Is(err, domain.ErrInvalidHash),
+		errors.Is(err, domain.ErrInvalidQuery),
+		errors.Is(err, domain.ErrInvalidCursor),
+		errors.Is(err, domain.ErrInvalidBlockRange),
+		errors.Is(err, domain.ErrInvalidPolling):
+		status = http.StatusBadRequest
+	case errors.Is(err, domain.ErrAlreadySubscribed),
+		errors.Is(err, domain.ErrInvalidTransition),
+		errors.Is(err, domain.ErrRescanInProgress):
+		status = http.StatusConflict
+	case errors.Is(err, domain.ErrNotFound), errors.Is(err, domain.ErrNotSubscribed):
+		status = http.StatusNotFound
+	case errors.Is(err, domain.ErrUpstreamUnavailable), errors.Is(err, domain.ErrListenerClosed):
+		status = http.StatusServiceUnavailable
+	default:
+		status = http.StatusInternalServerError
+	}
+
+	http.Error(w, err.Error(), status)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	response, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(response)
+}
+
+func writeTransactions(w http.ResponseWriter, transactions []domain.Transaction) {
+	response, err := json.Marshal(map[string]interface{}{
+		"count":        len(transactions),
+		"transactions": transactions,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(response)
+}
+
+func writeDeliveries(w http.ResponseWriter, deliveries []domain.WebhookDelivery) {
+	response, err := json.Marshal(map[string]interface{}{
+		"count":      len(deliveries),
+		"deliveries": deliveries,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(response)
+}
+
+// parseTransactionQuery parses the pagination, sorting and filtering parameters of a transactions query.
+func parseTransactionQuery(address domain.Address, values url.Values) (domain.TransactionQuery, error) {
+	var (
+		query = domain.TransactionQuery{
+			Address:   address,
+			Topic0:    values.Get("topic0"),
+			Direction: domain.Direction(values.Get("direction")),
+			Order:     domain.SortOrder(values.Get("order")),
+			Cursor:    values.Get("cursor"),
+		}
+		err error
+	)
+
+	if v := values.Get("contract"); v != "" {
+		if query.Contract, err = domain.NewAddress(v); err != nil {
+			return query, fmt.Errorf("invalid contract: %w", err)
+		}
+	}
+
+	for name, field := range map[string]*uint64{"from_block": &query.FromBlock, "to_block": &query.ToBlock} {
+		if v := values.Get(name); v != "" {
+			if *field, err = strconv.ParseUint(v, 10, 64); err != nil {
+				return query, fmt.Errorf("invalid %s", name)
+			}
+		}
+	}
+
+	if v := values.Get("limit"); v != "" {
+		if query.Limit, err = strconv.Atoi(v); err != nil {
+			return query, errors.New("invalid limit")
+		}
+	}
+
+	return query, nil
+}
+
+// parseChain parses the optional chain ID parameter, zero targeting the default chain.
+func parseChain(v string) (domain.ChainID, error) {
+	if v == "" {
+		return 0, nil
+	}
+
+	id, err := strconv.ParseUint(v, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return domain.ChainID(id), nil
+}
+
+// parseUnixTimestamp parses a unix timestamp in seconds, an empty value returns the zero time.
+func parseUnixTimestamp(v string) (time.Time, error) {
+	if v == "" {
+		return time.Time{}, nil
+	}
+
+	seconds, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Unix(seconds, 0), nil
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/infra/storage"
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/mocks"
+)
+
+const testAddress = domain.Address("0x35fa164735182de50811e8e2e824cfb9b6118ac2")
+
+func newTestServer(parser domain.ParserV2, webhooks WebhookService) *HTTPServer {
+	logger := slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))
+
+	return NewHTTPServer(&HTTPConfig{}, logger, parser, fakeStatus{ready: true}, webhooks, nil)
+}
+
+// fakeStatus tells whether the nodes passed the check.
+type fakeStatus struct {
+	NodeStatusReader
+	ready bool
+}
+
+func (f fakeStatus) Ready() bool {
+	return f.ready
+}
+
+func TestHTTPServer_GetTransactionsByTime(t *testing.T) {
+	repo := storage.NewInMemory()
+
+	// a block every 100 seconds, the second one holding two logs
+	err := repo.Add(context.Background(), testAddress, []domain.Transaction{
+		{Hash: "0x1", DecimalBlockNumber: 1, Timestamp: 100},
+		{Hash: "0x2", DecimalBlockNumber: 2, Timestamp: 200, LogIndex: 0},
+		{Hash: "0x2", DecimalBlockNumber: 2, Timestamp: 200, LogIndex: 1},
+		{Hash: "0x3", DecimalBlockNumber: 3, Timestamp: 300},
+		{Hash: "0x4", DecimalBlockNumber: 4, Timestamp: 400},
+	})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	server := newTestServer(domain.NewParserV2(repo, mocks.NewEventListener(t)), nil)
+
+	type page struct {
+		Transactions []domain.Transaction `json:"transactions"`
+		NextCursor   string               `json:"next_cursor"`
+	}
+
+	get := func(query url.Values) page {
+		t.Helper()
+
+		rec := httptest.NewRecorder()
+		server.router().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/transactions?"+query.Encode(), nil))
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET /transactions?%s = %d %s", query.Encode(), rec.Code, rec.Body)
+		}
+
+		var got page
+		if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+
+		return got
+	}
+
+	var (
+		query  = url.Values{"address": {testAddress.Hex()}, "from": {"200"}, "to": {"350"}, "limit": {"2"}}
+		blocks []uint64
+	)
+
+	for {
+		got := get(query)
+
+		if len(got.Transactions) > 2 {
+			t.Fatalf("GET /transactions = %d records, want the limit of 2", len(got.Transactions))
+		}
+
+		for _, v := range got.Transactions {
+			blocks = append(blocks, v.DecimalBlockNumber)
+		}
+
+		if got.NextCursor == "" {
+			break
+		}
+
+		query.Set("cursor", got.NextCursor)
+	}
+
+	if want := []uint64{2, 2, 3}; !reflect.DeepEqual(blocks, want) {
+		t.Errorf("GET /transactions blocks = %v, want %v", blocks, want)
+	}
+
+	query = url.Values{"address": {testAddress.Hex()}, "from": {"200"}, "order": {"desc"}, "limit": {"1"}}
+
+	if got := get(query); len(got.Transactions) != 1 || got.Transactions[0].DecimalBlockNumber != 4 {
+		t.Errorf("GET /transactions = %v, want the last block first", got.Transactions)
+	}
+}
+
+// fakeWebhooks records the webhooks registered.
+type fakeWebhooks struct {
+	WebhookService
+	registered []domain.Webhook
+}
+
+func (f *fakeWebhooks) Register(_ context.Context, webhook domain.Webhook) error {
+	f.registered = append(f.registered, webhook)
+	return nil
+}
+
+func TestHTTPServer_SubscribeWithWebhook(t *testing.T) {
+	tests := []struct {
+		name         string
+		subscribeErr error
+		wantStatus   int
+		wantHooks    int
+	}{
+		{
+			name:       "should register the webhook once subscribed",
+			wantStatus: http.StatusCreated,
+			wantHooks:  1,
+		},
+		{
+			name:         "should keep the webhook already registered when the subscription fails",
+			subscribeErr: domain.ErrAlreadySubscribed,
+			wantStatus:   http.StatusConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := mocks.NewParserV2(t)
+			parser.EXPECT().Subscribe(mock.Anything, domain.ChainID(0), testAddress.Hex()).
+				Return(tt.subscribeErr).
+				Once()
+
+			webhooks := &fakeWebhooks{}
+			body := `{"address": "` + testAddress.Hex() + `", "webhook": {"url": "https://example.com", "secret": "s"}}`
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/subscribe", strings.NewReader(body))
+			newTestServer(parser, webhooks).router().ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus || len(webhooks.registered) != tt.wantHooks {
+				t.Errorf("POST /subscribe = %d with %d webhook(s), want %d with %d",
+					rec.Code, len(webhooks.registered), tt.wantStatus, tt.wantHooks)
+			}
+		})
+	}
+}
+
+func TestHTTPServer_NotReady(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))
+	webhooks := &fakeWebhooks{}
+
+	// the parser mock fails the test on any subscription made
+	server := NewHTTPServer(&HTTPConfig{}, logger, mocks.NewParserV2(t), fakeStatus{}, webhooks, nil)
+
+	pause := "/subscriptions/" + testAddress.Hex() + "/pause"
+
+	requests := map[string]string{
+		"/subscribe":       `{"address": "` + testAddress.Hex() + `"}`,
+		pause:              ``,
+		"/webhooks":        `{"address": "` + testAddress.Hex() + `", "url": "https://example.com"}`,
+		"/webhooks/replay": `{"id": "1"}`,
+	}
+
+	for path, body := range requests {
+		rec := httptest.NewRecorder()
+		server.router().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+
+		if rec.Code != http.StatusServiceUnavailable {
+			t.Errorf("POST %s = %d, want %d until the nodes are checked", path, rec.Code, http.StatusServiceUnavailable)
+		}
+	}
+
+	if len(webhooks.registered) != 0 {
+		t.Errorf("webhooks registered = %v, want none until the nodes are checked", webhooks.registered)
+	}
+}
+
+func TestHTTPServer_VerifyOutlivesWriteTimeout(t *testing.T) {
+	parser := mocks.NewParserV2(t)
+	parser.EXPECT().VerifySubscription(mock.Anything, domain.ChainID(0), testAddress.Hex(), uint64(1), uint64(10), false).
+		RunAndReturn(func(context.Context, domain.ChainID, string, uint64, uint64, bool) (domain.IntegrityReport, error) {
+			time.Sleep(time.Millisecond * 100)
+			return domain.IntegrityReport{Address: testAddress, FromBlock: 1, ToBlock: 10}, nil
+		}).
+		Once()
+
+	server := httptest.NewUnstartedServer(newTestServer(parser, nil).router())
+	server.Config.WriteTimeout = time.Millisecond * 20
+	server.Start()
+	defer server.Close()
+
+	endpoint := server.URL + "/subscriptions/" + testAddress.Hex() + "/verify?from_block=1&to_block=10"
+
+	resp, err := http.Post(endpoint, "application/json", http.NoBody)
+	if err != nil {
+		t.Fatalf("POST /verify error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	var report domain.IntegrityReport
+	if err = json.NewDecoder(resp.Body).Decode(&report); err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("POST /verify = %d, error = %v, want the report past the write timeout", resp.StatusCode, err)
+	}
+}
@@ -11,37 +11,40 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == verifyCommand {
+		os.Exit(runVerify(os.Args[2:], os.Stdout, os.Stderr))
+	}
+
 	cfg, err := loadConfig()
 	if err != nil {
 		panic(errors.Wrap(err, "failed to load config"))
 	}
 
+	if err = cfg.IsValid(); err != nil {
+		panic(errors.Wrap(err, "invalid config"))
+	}
+
 	logger := buildLogger(cfg)
 	slog.SetDefault(logger)
 
 	logger.Info("Starting app", "configs", cfg.LogFields())
 
-	appCtx, cancel := context.WithCancel(context.Background())
-
-	application := NewApplication(appCtx, cfg, logger)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	if err = application.Run(appCtx); err != nil {
-		logger.Error("failed to run application:", "error", err)
-	}
-
-	done := make(chan os.Signal, 1)
-	signal.Notify(done, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
-
-	<-done
+	// the application stops gracefully on the first signal, a second one killing it
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
 
-	logger.Info("Gracefully shutting down...")
+	application := NewApplication(ctx, cfg, logger)
 
-	cancel()
-
-	if err = application.Stop(); err != nil {
-		logger.Error("failed to stop application: %v", err)
-		return
+	if err = application.Run(ctx); err != nil {
+		panic(errors.Wrap(err, "failed to run application"))
 	}
+
+	logger.Info("Application stopped")
 }
 
 func buildLogger(cfg *Config) *slog.Logger {
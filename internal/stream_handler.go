@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+const streamKeepAliveInterval = 15 * time.Second
+
+// streamHandler pushes the events of the indexed records as Server-Sent Events, optionally filtered by a comma
+// separated list of addresses. Clients resume after the last event they've seen through the Last-Event-ID header,
+// or the last_event_id query param.
+func (s *HTTPServer) streamHandler(w http.ResponseWriter, req *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	filter, err := parseEventFilter(req.URL.Query()["address"])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	lastEventID := req.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = req.URL.Query().Get("last_event_id")
+	}
+
+	var events <-chan domain.Event
+
+	if lastEventID != "" {
+		afterID, err := strconv.ParseUint(lastEventID, 10, 64)
+		if err != nil {
+			http.Error(w, "invalid last event ID", http.StatusBadRequest)
+			return
+		}
+
+		events = s.events.SubscribeSince(req.Context(), filter, afterID)
+	} else {
+		events = s.events.Subscribe(req.Context(), filter)
+	}
+
+	// streams outlive the server write timeout
+	if err = http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(streamKeepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-req.Context().Done():
+			return
+
+		case <-s.shutdown:
+			return
+
+		case <-keepAlive.C:
+			if _, err = fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+
+		case event, ok := <-events:
+			// closed when the client is too slow, it's expected to reconnect with its last event ID
+			if !ok {
+				return
+			}
+
+			data, err := json.Marshal(event)
+			if err != nil {
+				return
+			}
+
+			if _, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// parseEventFilter parses the address params, each one being possibly a comma separated list.
+func parseEventFilter(params []string) (domain.EventFilter, error) {
+	var filter domain.EventFilter
+
+	for _, param := range params {
+		for _, v := range strings.Split(param, ",") {
+			if v = strings.TrimSpace(v); v == "" {
+				continue
+			}
+
+			address, err := domain.NewAddress(v)
+			if err != nil {
+				return domain.EventFilter{}, err
+			}
+
+			filter.Addresses = append(filter.Addresses, address)
+		}
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/infra/verifier"
+)
+
+const (
+	verifyCommand = "verify"
+
+	// exit codes of the verify command
+	exitConsistent   = 0
+	exitInconsistent = 1
+	exitFailure      = 2
+)
+
+// runVerify runs the verify command against a running app, which holds the indexed records: it verifies the records
+// of an address within a block range, optionally repairing them, and writes the report as a diff, or as JSON.
+// A repair is followed by a second verification, whose report is written as well. It exits with 1 when differences
+// remain, and 2 on failure.
+func runVerify(args []string, stdout, stderr io.Writer) int {
+	var (
+		flags     = flag.NewFlagSet(verifyCommand, flag.ContinueOnError)
+		apiURL    = flags.String("url", "http://localhost:8080", "URL of the running app")
+		address   = flags.String("address", "", "address to verify")
+		chain     = flags.Uint64("chain", 0, "ID of the chain, the default chain of the app when zero")
+		fromBlock = flags.Uint64("from", 0, "first block to verify")
+		toBlock   = flags.Uint64("to", 0, "last block to verify, which must be confirmed")
+		repair    = flags.Bool("repair", false, "index the blocks found to differ again")
+		asJSON    = flags.Bool("json", false, "write the report as JSON")
+		timeout   = flags.Duration("timeout", 5*time.Minute, "timeout of the verification")
+	)
+
+	flags.SetOutput(stderr)
+
+	if err := flags.Parse(args); err != nil {
+		return exitFailure
+	}
+
+	if *address == "" {
+		_, _ = fmt.Fprintln(stderr, "missing -address")
+		flags.Usage()
+
+		return exitFailure
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	query := url.Values{
+		"from_block": {strconv.FormatUint(*fromBlock, 10)},
+		"to_block":   {strconv.FormatUint(*toBlock, 10)},
+		"repair":     {strconv.FormatBool(*repair)},
+	}
+
+	if *chain != 0 {
+		query.Set("chain", strconv.FormatUint(*chain, 10))
+	}
+
+	endpoint := *apiURL + "/subscriptions/" + *address + "/verify?"
+
+	report, err := verify(ctx, endpoint+query.Encode(), stdout, *asJSON)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "failed to verify: %v\n", err)
+		return exitFailure
+	}
+
+	switch {
+	case report.RepairError != "":
+		return exitFailure
+	case report.Consistent():
+		return exitConsistent
+	case !*repair:
+		return exitInconsistent
+	}
+
+	// the repaired blocks are verified again, the exit code telling whether the repair converged
+	query.Set("repair", "false")
+
+	if report, err = verify(ctx, endpoint+query.Encode(), stdout, *asJSON); err != nil {
+		_, _ = fmt.Fprintf(stderr, "failed to verify the repair: %v\n", err)
+		return exitFailure
+	}
+
+	if !report.Consistent() {
+		return exitInconsistent
+	}
+
+	return exitConsistent
+}
+
+// verify requests a verification, writing its report as a diff or as JSON.
+func verify(ctx context.Context, endpoint string, w io.Writer, asJSON bool) (domain.IntegrityReport, error) {
+	report, err := requestVerification(ctx, endpoint)
+	if err != nil {
+		return domain.IntegrityReport{}, err
+	}
+
+	if asJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(report)
+	} else {
+		err = verifier.WriteReport(w, report)
+	}
+
+	if err != nil {
+		return domain.IntegrityReport{}, errors.Wrap(err, "failed to write report")
+	}
+
+	return report, nil
+}
+
+func requestVerification(ctx context.Context, endpoint string) (domain.IntegrityReport, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, http.NoBody)
+	if err != nil {
+		return domain.IntegrityReport{}, errors.Wrap(err, "failed to build request")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return domain.IntegrityReport{}, errors.Wrap(err, "failed to call the app")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return domain.IntegrityReport{}, errors.Errorf("unexpected status code %d: %s", resp.StatusCode, body)
+	}
+
+	var report domain.IntegrityReport
+
+	if err = json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		return domain.IntegrityReport{}, errors.Wrap(err, "failed to decode report")
+	}
+
+	return report, nil
+}
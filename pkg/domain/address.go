@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+var addressRegex = regexp.MustCompile("^0x[0-9a-fA-F]{40}$")
+
+// Address is an Ethereum address held in its canonical lowercase form, so that the same account
+// is always represented by the same value regardless of the casing it was given with.
+// It's rendered with the EIP-55 checksum casing on JSON.
+type Address string
+
+// NewAddress validates and normalises the given address.
+// Mixed-case addresses must carry a valid EIP-55 checksum, all lower or upper case ones are accepted as is.
+// @see https://eips.ethereum.org/EIPS/eip-55
+func NewAddress(v string) (Address, error) {
+	if !addressRegex.MatchString(v) {
+		return "", ErrInvalidAddress
+	}
+
+	address := HexToAddress(v)
+
+	hex := v[2:]
+	if strings.ToLower(hex) != hex && strings.ToUpper(hex) != hex && address.Hex() != v {
+		return "", ErrInvalidAddressChecksum
+	}
+
+	return address, nil
+}
+
+// HexToAddress normalises an address without validating it, to be used with trusted values such as node responses.
+func HexToAddress(v string) Address {
+	if len(v) >= 2 && (v[:2] == "0X") {
+		v = "0x" + v[2:]
+	}
+
+	return Address(strings.ToLower(v))
+}
+
+// String returns the canonical lowercase form of the address.
+func (a Address) String() string {
+	return string(a)
+}
+
+// Hex returns the address with the EIP-55 checksum casing.
+func (a Address) Hex() string {
+	if len(a) != 42 {
+		return string(a)
+	}
+
+	var (
+		lower = []byte(a[2:])
+		hash  = sha3.NewLegacyKeccak256()
+	)
+
+	_, _ = hash.Write(lower)
+	digest := hash.Sum(nil)
+
+	for i, c := range lower {
+		nibble := digest[i/2]
+		if i%2 == 0 {
+			nibble >>= 4
+		}
+
+		if c >= 'a' && c <= 'f' && nibble&0xf >= 8 {
+			lower[i] = c - 'a' + 'A'
+		}
+	}
+
+	return "0x" + string(lower)
+}
+
+func (a Address) IsZero() bool {
+	return a == ""
+}
+
+func (a Address) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.Hex())
+}
+
+func (a *Address) UnmarshalJSON(data []byte) error {
+	var v string
+
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	if v == "" {
+		*a = ""
+		return nil
+	}
+
+	address, err := NewAddress(v)
+	if err != nil {
+		return err
+	}
+
+	*a = address
+
+	return nil
+}
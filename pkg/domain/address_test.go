@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNewAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Address
+		wantErr error
+	}{
+		{
+			name:  "should accept a valid checksummed address",
+			input: "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+			want:  "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed",
+		},
+		{
+			name:  "should accept an all lowercase address",
+			input: "0xfb6916095ca1df60bb79ce92ce3ea74c37c5d359",
+			want:  "0xfb6916095ca1df60bb79ce92ce3ea74c37c5d359",
+		},
+		{
+			name:  "should accept an all uppercase address",
+			input: "0xFB6916095CA1DF60BB79CE92CE3EA74C37C5D359",
+			want:  "0xfb6916095ca1df60bb79ce92ce3ea74c37c5d359",
+		},
+		{
+			name:    "should reject a mixed case address with an invalid checksum",
+			input:   "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD",
+			wantErr: ErrInvalidAddressChecksum,
+		},
+		{
+			name:    "should reject an address with invalid length",
+			input:   "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeA",
+			wantErr: ErrInvalidAddress,
+		},
+		{
+			name:    "should reject an address without 0x prefix",
+			input:   "5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+			wantErr: ErrInvalidAddress,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewAddress(tt.input)
+			if err != tt.wantErr {
+				t.Errorf("NewAddress() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("NewAddress() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddress_Hex(t *testing.T) {
+	// test vectors from EIP-55
+	tests := []string{
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+		"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+	}
+	for _, want := range tests {
+		t.Run(want, func(t *testing.T) {
+			if got := HexToAddress(want).Hex(); got != want {
+				t.Errorf("Hex() got = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestAddress_JSON(t *testing.T) {
+	var v struct {
+		Address Address `json:"address"`
+		Empty   Address `json:"empty,omitempty"`
+	}
+
+	if err := json.Unmarshal([]byte(`{"address":"0x5AAEB6053F3E94C9B9A09F33669435E7EF1BEAED"}`), &v); err != nil {
+		t.Fatalf("expected error to be nil, got: %v", err)
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("expected error to be nil, got: %v", err)
+	}
+
+	if string(data) != `{"address":"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"}` {
+		t.Errorf("unexpected json: %s", data)
+	}
+
+	if err = json.Unmarshal([]byte(`{"address":"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD"}`), &v); err == nil {
+		t.Errorf("expected invalid checksum error")
+	}
+}
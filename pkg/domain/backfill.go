@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"time"
+)
+
+// BackfillProgress tracks the indexing of the blocks [FromBlock, ToBlock] of an address, e.g. by a rescan.
+type BackfillProgress struct {
+	FromBlock uint64 `json:"fromBlock"`
+	ToBlock   uint64 `json:"toBlock"`
+	// ScannedBlock is the last block indexed, the blocks before it being indexed as well, zero until the first one is
+	ScannedBlock uint64 `json:"scannedBlock,omitempty"`
+	Records      int    `json:"records"`
+	// BlocksPerSecond is the throughput since the backfill started or resumed, EstimatedFinishAt being derived from it
+	BlocksPerSecond   float64   `json:"blocksPerSecond"`
+	EstimatedFinishAt time.Time `json:"estimatedFinishAt,omitempty"`
+	Error             string    `json:"error,omitempty"`
+	StartedAt         time.Time `json:"startedAt"`
+	FinishedAt        time.Time `json:"finishedAt,omitempty"`
+}
+
+// Running tells whether the backfill is still in progress.
+func (p BackfillProgress) Running() bool {
+	return p.FinishedAt.IsZero()
+}
+
+// Percent returns the share of the blocks indexed so far, from 0 to 100.
+func (p BackfillProgress) Percent() float64 {
+	if p.ScannedBlock < p.FromBlock {
+		return 0
+	}
+
+	return float64(p.ScannedBlock-p.FromBlock+1) / float64(p.ToBlock-p.FromBlock+1) * 100
+}
+
+// BackfillCheckpoint is the progress of the backfill of the blocks [FromBlock, ToBlock] of an address: NextBlock is the
+// first block not written yet, from which it resumes after a restart.
+type BackfillCheckpoint struct {
+	Address   Address `json:"address"`
+	FromBlock uint64  `json:"fromBlock"`
+	NextBlock uint64  `json:"nextBlock"`
+	ToBlock   uint64  `json:"toBlock"`
+}
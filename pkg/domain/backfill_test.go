@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestBackfillProgress_Percent(t *testing.T) {
+	tests := []struct {
+		name     string
+		progress BackfillProgress
+		want     float64
+	}{
+		{
+			name:     "should be zero before the first chunk",
+			progress: BackfillProgress{FromBlock: 100, ToBlock: 199},
+			want:     0,
+		},
+		{
+			name:     "should count the scanned blocks",
+			progress: BackfillProgress{FromBlock: 100, ToBlock: 199, ScannedBlock: 124},
+			want:     25,
+		},
+		{
+			name:     "should be complete once the last block is scanned",
+			progress: BackfillProgress{FromBlock: 100, ToBlock: 100, ScannedBlock: 100},
+			want:     100,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.progress.Percent(); got != tt.want {
+				t.Errorf("Percent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
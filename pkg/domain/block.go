@@ -0,0 +1,46 @@
+package domain
+
+import "github.com/pkg/errors"
+
+type BlockHeader struct {
+	Number        uint64    `json:"number"`
+	Hash          string    `json:"hash"`
+	Timestamp     int64     `json:"timestamp"`
+	BaseFeePerGas *Quantity `json:"baseFeePerGas,omitempty"`
+	Miner         Address   `json:"miner"`
+
+	// Transactions holds the hashes of the transactions included in the block
+	Transactions []string `json:"transactions,omitempty"`
+}
+
+func NewBlockHeader(number, hash, timestamp, baseFeePerGas, miner string) (BlockHeader, error) {
+	decimalNumber, err := hexToUint64(number)
+	if err != nil {
+		return BlockHeader{}, errors.Wrap(err, "invalid block number")
+	}
+
+	decimalTimestamp, err := hexToDecimalString(timestamp)
+	if err != nil {
+		return BlockHeader{}, errors.Wrap(err, "invalid timestamp")
+	}
+
+	var decimalBaseFeePerGas *Quantity
+
+	// baseFeePerGas is only present on post London blocks
+	if baseFeePerGas != "" {
+		fee, err := ParseQuantity(baseFeePerGas)
+		if err != nil {
+			return BlockHeader{}, errors.Wrap(err, "invalid base fee per gas")
+		}
+
+		decimalBaseFeePerGas = &fee
+	}
+
+	return BlockHeader{
+		Number:        decimalNumber,
+		Hash:          hash,
+		Timestamp:     decimalTimestamp,
+		BaseFeePerGas: decimalBaseFeePerGas,
+		Miner:         HexToAddress(miner),
+	}, nil
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewBlockHeader(t *testing.T) {
+	baseFee := QuantityFromUint64(1000000000)
+
+	type args struct {
+		number        string
+		hash          string
+		timestamp     string
+		baseFeePerGas string
+		miner         string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    BlockHeader
+		wantErr bool
+	}{
+		{
+			name: "should return expected block header",
+			args: args{
+				number:        "0x12a30a0",
+				hash:          "0xacfdbd8d63fbb7cdea95b2d85be04ee5ecd8eef222a2861e246b8454bcf3952c",
+				timestamp:     "0x6606d9bb",
+				baseFeePerGas: "0x3b9aca00",
+				miner:         "0x95222290dd7278aa3ddd389cc1e1d165cc4bafe5",
+			},
+			want: BlockHeader{
+				Number:        19542176,
+				Hash:          "0xacfdbd8d63fbb7cdea95b2d85be04ee5ecd8eef222a2861e246b8454bcf3952c",
+				Timestamp:     1711724987,
+				BaseFeePerGas: &baseFee,
+				Miner:         "0x95222290dd7278aa3ddd389cc1e1d165cc4bafe5",
+			},
+			wantErr: false,
+		},
+		{
+			name: "should accept pre London blocks without base fee",
+			args: args{
+				number:    "0x41",
+				hash:      "0x1",
+				timestamp: "0x400",
+			},
+			want: BlockHeader{
+				Number:    65,
+				Hash:      "0x1",
+				Timestamp: 1024,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should error on invalid timestamp",
+			args: args{
+				number:    "0x41",
+				timestamp: "0x",
+			},
+			want:    BlockHeader{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewBlockHeader(tt.args.number, tt.args.hash, tt.args.timestamp, tt.args.baseFeePerGas, tt.args.miner)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewBlockHeader() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewBlockHeader() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
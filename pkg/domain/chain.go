@@ -0,0 +1,15 @@
+package domain
+
+// ChainID is the EIP-155 identifier of a chain, e.g. 1 for Ethereum mainnet.
+type ChainID uint64
+
+const DefaultChainID ChainID = 1
+
+// Chain groups the components indexing a single chain.
+type Chain struct {
+	ID            ChainID
+	Repo          RepositoryReader
+	EventListener EventListener
+	// Verifier is optional, the records of a chain without one can't be verified
+	Verifier Verifier
+}
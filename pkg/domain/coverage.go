@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// BlockRange is the blocks [FromBlock, ToBlock].
+type BlockRange struct {
+	FromBlock uint64 `json:"fromBlock"`
+	ToBlock   uint64 `json:"toBlock"`
+}
+
+// MergeBlockRanges returns the given ranges sorted, the overlapping and adjacent ones being merged together.
+func MergeBlockRanges(ranges []BlockRange) []BlockRange {
+	if len(ranges) == 0 {
+		return nil
+	}
+
+	sorted := make([]BlockRange, len(ranges))
+	copy(sorted, ranges)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].FromBlock < sorted[j].FromBlock
+	})
+
+	merged := []BlockRange{sorted[0]}
+
+	for _, r := range sorted[1:] {
+		last := &merged[len(merged)-1]
+
+		if r.FromBlock > last.ToBlock+1 {
+			merged = append(merged, r)
+			continue
+		}
+
+		if r.ToBlock > last.ToBlock {
+			last.ToBlock = r.ToBlock
+		}
+	}
+
+	return merged
+}
+
+// BlockRangeGaps returns the ranges missing between the first and the last block of the given ones.
+func BlockRangeGaps(ranges []BlockRange) []BlockRange {
+	var (
+		merged = MergeBlockRanges(ranges)
+		gaps   []BlockRange
+	)
+
+	for i := 1; i < len(merged); i++ {
+		gaps = append(gaps, BlockRange{FromBlock: merged[i-1].ToBlock + 1, ToBlock: merged[i].FromBlock - 1})
+	}
+
+	return gaps
+}
+
+type GapState string
+
+const (
+	GapDetected  GapState = "detected"
+	GapRepairing GapState = "repairing"
+	GapRepaired  GapState = "repaired"
+	// GapFailed is the state of a gap whose last repair failed, it's retried on the next run
+	GapFailed GapState = "failed"
+)
+
+// Gap is a range of blocks missing from the indexed ones of an address, e.g. while the service was down, along with
+// its repair.
+type Gap struct {
+	Address    Address   `json:"address"`
+	FromBlock  uint64    `json:"fromBlock"`
+	ToBlock    uint64    `json:"toBlock"`
+	State      GapState  `json:"state"`
+	Records    int       `json:"records"`
+	Error      string    `json:"error,omitempty"`
+	DetectedAt time.Time `json:"detectedAt"`
+	RepairedAt time.Time `json:"repairedAt,omitempty"`
+}
+
+func (g Gap) Range() BlockRange {
+	return BlockRange{FromBlock: g.FromBlock, ToBlock: g.ToBlock}
+}
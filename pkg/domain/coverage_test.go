@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeBlockRanges(t *testing.T) {
+	tests := []struct {
+		name   string
+		ranges []BlockRange
+		want   []BlockRange
+	}{
+		{
+			name:   "should be empty without ranges",
+			ranges: nil,
+			want:   nil,
+		},
+		{
+			name:   "should merge overlapping and adjacent ranges",
+			ranges: []BlockRange{{150, 160}, {100, 120}, {121, 130}, {110, 125}},
+			want:   []BlockRange{{100, 130}, {150, 160}},
+		},
+		{
+			name:   "should absorb the ranges within another one",
+			ranges: []BlockRange{{100, 200}, {120, 130}},
+			want:   []BlockRange{{100, 200}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MergeBlockRanges(tt.ranges); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MergeBlockRanges() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBlockRangeGaps(t *testing.T) {
+	tests := []struct {
+		name   string
+		ranges []BlockRange
+		want   []BlockRange
+	}{
+		{
+			name:   "should have no gap within contiguous ranges",
+			ranges: []BlockRange{{100, 120}, {121, 130}},
+			want:   nil,
+		},
+		{
+			name:   "should return the blocks missing between the ranges",
+			ranges: []BlockRange{{200, 210}, {100, 120}, {150, 160}},
+			want:   []BlockRange{{121, 149}, {161, 199}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BlockRangeGaps(tt.ranges); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BlockRangeGaps() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,10 +1,50 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
+
+// Typed errors, the specific ones below wrap them so that callers can check errors.Is against the category.
+var (
+	ErrNotFound            = errors.New("not found")
+	ErrUpstreamUnavailable = errors.New("upstream unavailable")
+)
 
 var (
 	ErrNotSubscribed     = errors.New("not subscribed")
 	ErrAlreadySubscribed = errors.New("already subscribed")
-	ErrAddressNotFound   = errors.New("address not found")
-	ErrBlockNotFound     = errors.New("block not found")
+	ErrListenerClosed    = errors.New("event listener closed")
+	ErrAddressNotFound   = fmt.Errorf("address %w", ErrNotFound)
+	ErrBlockNotFound     = fmt.Errorf("block %w", ErrNotFound)
+	ErrUnknownChain      = fmt.Errorf("chain %w", ErrNotFound)
+
+	ErrSubscriptionNotFound = fmt.Errorf("subscription %w", ErrNotFound)
+	ErrInvalidTransition    = errors.New("invalid subscription state transition")
+	ErrRescanInProgress     = errors.New("rescan already in progress")
+	ErrInvalidBlockRange    = errors.New("invalid block range")
+	ErrInvalidPolling       = errors.New("invalid polling options")
+
+	ErrInvalidAddress         = errors.New("invalid address")
+	ErrInvalidAddressChecksum = fmt.Errorf("%w checksum", ErrInvalidAddress)
+	ErrInvalidHash            = errors.New("invalid transaction hash")
+
+	ErrTracingNotSupported = errors.New("tracing not supported by the node")
+	// ErrTooManyResults is returned by nodes refusing to return the logs of a too large block range
+	ErrTooManyResults      = errors.New("too many results")
+	ErrTransactionNotFound = fmt.Errorf("transaction %w", ErrNotFound)
+	// ErrFilterNotFound is returned by nodes no longer holding a filter, e.g. expired for not being polled in time
+	ErrFilterNotFound = fmt.Errorf("filter %w", ErrNotFound)
+
+	ErrChainIDMismatch = errors.New("chain ID mismatch")
+
+	ErrInvalidWebhookURL    = errors.New("invalid webhook URL")
+	ErrMissingWebhookSecret = errors.New("missing webhook secret")
+	ErrWebhookNotFound      = fmt.Errorf("webhook %w", ErrNotFound)
+	ErrDeliveryNotFound     = fmt.Errorf("webhook delivery %w", ErrNotFound)
+
+	ErrWatchNotSupported = errors.New("watch not supported without an event source")
+
+	ErrInvalidQuery  = errors.New("invalid query")
+	ErrInvalidCursor = errors.New("invalid cursor")
 )
@@ -0,0 +1,131 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+type EventType string
+
+const (
+	EventTransactionAdded   EventType = "transaction.added"
+	EventTransactionRemoved EventType = "transaction.removed"
+	EventNewHead            EventType = "head.new"
+	EventSubscriptionError  EventType = "subscription.error"
+	// EventDiscarded stands for the events discarded from the log before being read, up to its ID
+	EventDiscarded EventType = "events.discarded"
+)
+
+// Event notifies a change of the indexed records. Its ID is a sequence assigned once the event is stored,
+// allowing consumers to resume after the last event they've seen.
+type Event struct {
+	ID          uint64       `json:"id"`
+	Type        EventType    `json:"type"`
+	ChainID     ChainID      `json:"chainId"`
+	Address     Address      `json:"address,omitempty"`
+	Transaction *Transaction `json:"transaction,omitempty"`
+	BlockNumber uint64       `json:"blockNumber,omitempty"`
+	Error       string       `json:"error,omitempty"`
+	CreatedAt   time.Time    `json:"createdAt"`
+}
+
+// NewTransactionEvents creates an event of the given type for each transaction.
+func NewTransactionEvents(eventType EventType, address Address, transactions []Transaction, at time.Time) []Event {
+	var events = make([]Event, len(transactions))
+
+	for i := range transactions {
+		tx := transactions[i]
+
+		events[i] = Event{
+			Type:        eventType,
+			ChainID:     tx.ChainID,
+			Address:     address,
+			Transaction: &tx,
+			CreatedAt:   at,
+		}
+	}
+
+	return events
+}
+
+// NewHeadEvent creates the event of a new block on the given chain.
+func NewHeadEvent(chainID ChainID, blockNumber uint64, at time.Time) Event {
+	return Event{Type: EventNewHead, ChainID: chainID, BlockNumber: blockNumber, CreatedAt: at}
+}
+
+// NewSubscriptionErrorEvent creates the event of an error processing the subscription of an address.
+func NewSubscriptionErrorEvent(chainID ChainID, address Address, err error, at time.Time) Event {
+	return Event{Type: EventSubscriptionError, ChainID: chainID, Address: address, Error: err.Error(), CreatedAt: at}
+}
+
+// NewDiscardedEvent creates the event standing for the events fromID to toID, discarded from the log before being
+// read. It takes the ID of the last one, for the consumers to resume after it.
+func NewDiscardedEvent(fromID, toID uint64, at time.Time) Event {
+	return Event{
+		ID:        toID,
+		Type:      EventDiscarded,
+		Error:     fmt.Sprintf("events %d to %d discarded before being read", fromID, toID),
+		CreatedAt: at,
+	}
+}
+
+// EventFilter selects events, an empty filter matches every event.
+// Addresses only apply to the events of an address, chain wide events such as new heads match any address.
+type EventFilter struct {
+	Addresses []Address
+	Types     []EventType
+}
+
+func (f EventFilter) Matches(event Event) bool {
+	if len(f.Types) > 0 && !contains(f.Types, event.Type) {
+		return false
+	}
+
+	if len(f.Addresses) == 0 || event.Address == "" {
+		return true
+	}
+
+	return contains(f.Addresses, event.Address)
+}
+
+type SlowConsumerPolicy string
+
+const (
+	// SlowConsumerBlock blocks the producer until the consumer catches up
+	SlowConsumerBlock SlowConsumerPolicy = "block"
+	// SlowConsumerDropOldest discards the oldest buffered events to make room for the new ones
+	SlowConsumerDropOldest SlowConsumerPolicy = "drop_oldest"
+	// SlowConsumerDisconnect closes the channel of the consumer
+	SlowConsumerDisconnect SlowConsumerPolicy = "disconnect"
+)
+
+// WatchOptions configures the buffering of the events delivered to a consumer.
+type WatchOptions struct {
+	BufferSize int
+	Policy     SlowConsumerPolicy
+}
+
+type WatchOption func(*WatchOptions)
+
+// WithBufferSize sets the number of events buffered before the slow consumer policy kicks in.
+func WithBufferSize(size int) WatchOption {
+	return func(o *WatchOptions) {
+		o.BufferSize = size
+	}
+}
+
+func WithSlowConsumerPolicy(policy SlowConsumerPolicy) WatchOption {
+	return func(o *WatchOptions) {
+		o.Policy = policy
+	}
+}
+
+func contains[T comparable](values []T, v T) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+
+	return false
+}
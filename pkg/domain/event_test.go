@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewTransactionEvents(t *testing.T) {
+	var (
+		at           = time.Unix(1711724987, 0)
+		transactions = []Transaction{{ChainID: 8453, Hash: "0x1"}, {ChainID: 8453, Hash: "0x2"}}
+	)
+
+	events := NewTransactionEvents(EventTransactionRemoved, "0x123", transactions, at)
+
+	if len(events) != 2 {
+		t.Fatalf("NewTransactionEvents() = %v events, want 2", len(events))
+	}
+
+	for i, v := range events {
+		if v.Type != EventTransactionRemoved || v.ChainID != 8453 || v.Address != "0x123" || !v.CreatedAt.Equal(at) {
+			t.Errorf("NewTransactionEvents() event = %v", v)
+		}
+		if v.Transaction.Hash != transactions[i].Hash {
+			t.Errorf("NewTransactionEvents() transaction = %v, want %v", v.Transaction.Hash, transactions[i].Hash)
+		}
+	}
+}
+
+func TestEventFilter_Matches(t *testing.T) {
+	var (
+		event = Event{Type: EventTransactionAdded, Address: "0x1"}
+		head  = NewHeadEvent(1, 65, time.Now())
+	)
+
+	tests := []struct {
+		name   string
+		filter EventFilter
+		event  *Event
+		want   bool
+	}{
+		{
+			name:   "should match any event without addresses",
+			filter: EventFilter{},
+			want:   true,
+		},
+		{
+			name:   "should match a listed address",
+			filter: EventFilter{Addresses: []Address{"0x2", "0x1"}},
+			want:   true,
+		},
+		{
+			name:   "should not match other addresses",
+			filter: EventFilter{Addresses: []Address{"0x2"}},
+			want:   false,
+		},
+		{
+			name:   "should not match other types",
+			filter: EventFilter{Types: []EventType{EventTransactionRemoved}},
+			want:   false,
+		},
+		{
+			name:   "should match chain wide events whatever the addresses",
+			filter: EventFilter{Addresses: []Address{"0x2"}},
+			event:  &head,
+			want:   true,
+		},
+		{
+			name:   "should filter chain wide events by type",
+			filter: EventFilter{Addresses: []Address{"0x2"}, Types: []EventType{EventTransactionAdded}},
+			event:  &head,
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := event
+			if tt.event != nil {
+				e = *tt.event
+			}
+
+			if got := tt.filter.Matches(e); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
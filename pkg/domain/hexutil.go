@@ -6,6 +6,7 @@ package domain
 
 import (
 	"math/big"
+	"strconv"
 
 	"github.com/pkg/errors"
 )
@@ -26,7 +27,7 @@ func init() {
 	}
 }
 
-// Decode decodes a hex string with 0x prefix.
+// Decode decodes a hex string with 0x prefix, failing when it overflows an int64.
 func hexToDecimalString(input string) (int64, error) {
 	raw, err := checkNumber(input)
 	if err != nil {
@@ -53,10 +54,45 @@ func hexToDecimalString(input string) (int64, error) {
 		end = start
 	}
 	dec := new(big.Int).SetBits(words)
+	if !dec.IsInt64() {
+		return 0, errors.New("hex number > 63 bits")
+	}
 
 	return dec.Int64(), nil
 }
 
+// HexToInt64 decodes an Ethereum hex quantity, such as a nonce.
+func HexToInt64(input string) (int64, error) {
+	return hexToDecimalString(input)
+}
+
+// hexToUint64 decodes a hex quantity of up to 64 bits, such as a block number.
+func hexToUint64(input string) (uint64, error) {
+	raw, err := checkNumber(input)
+	if err != nil {
+		return 0, err
+	}
+	if len(raw) > 16 {
+		return 0, errors.New("hex number > 64 bits")
+	}
+	for i := 0; i < len(raw); i++ {
+		if decodeNibble(raw[i]) == badNibble {
+			return 0, errors.Errorf("invalid hex string")
+		}
+	}
+
+	return strconv.ParseUint(raw, 16, 64)
+}
+
+// HexToUint64 decodes an Ethereum hex quantity of up to 64 bits, such as a block number.
+func HexToUint64(input string) (uint64, error) {
+	return hexToUint64(input)
+}
+
+func toHexQuantity(v uint64) string {
+	return "0x" + strconv.FormatUint(v, 16)
+}
+
 func has0xPrefix(input string) bool {
 	return len(input) >= 2 && input[0] == '0' && (input[1] == 'x' || input[1] == 'X')
 }
@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// Verifier compares the records stored for an address with the logs of the node, repairing the blocks found to differ
+// when asked to.
+type Verifier interface {
+	Verify(ctx context.Context, address Address, fromBlock, toBlock uint64, repair bool) (IntegrityReport, error)
+}
+
+// RecordMismatch is a record stored with another block than the one of the node, e.g. after a missed reorg.
+type RecordMismatch struct {
+	Stored   Transaction `json:"stored"`
+	Expected Transaction `json:"expected"`
+}
+
+// IntegrityReport is the difference between the records stored for an address within [FromBlock, ToBlock] and the
+// logs returned by the node for the same blocks.
+type IntegrityReport struct {
+	Address   Address `json:"address"`
+	FromBlock uint64  `json:"fromBlock"`
+	ToBlock   uint64  `json:"toBlock"`
+	// Expected and Stored are the number of logs returned by the node and of records stored
+	Expected int `json:"expected"`
+	Stored   int `json:"stored"`
+	// Missing are the logs of the node not stored, Extra the records stored the node doesn't know about
+	Missing    []Transaction    `json:"missing"`
+	Extra      []Transaction    `json:"extra"`
+	Mismatched []RecordMismatch `json:"mismatched"`
+	// Repaired are the block ranges indexed again, and RepairError the failure of their repair
+	Repaired    []BlockRange `json:"repaired,omitempty"`
+	RepairError string       `json:"repairError,omitempty"`
+	VerifiedAt  time.Time    `json:"verifiedAt"`
+}
+
+// Consistent tells whether the stored records match the logs of the node.
+func (r IntegrityReport) Consistent() bool {
+	return len(r.Missing) == 0 && len(r.Extra) == 0 && len(r.Mismatched) == 0
+}
+
+// Diff fills the report with the differences between the logs of the node and the stored records, matched by
+// transaction hash and log index. The records which aren't logs, e.g. internal transactions, are ignored.
+func (r *IntegrityReport) Diff(expected, stored []Transaction) {
+	var byKey = make(map[string]Transaction, len(stored))
+
+	for _, v := range stored {
+		if v.Type == TransactionTypeInternal {
+			continue
+		}
+
+		byKey[v.Key()] = v
+		r.Stored++
+	}
+
+	for _, v := range expected {
+		r.Expected++
+
+		existing, ok := byKey[v.Key()]
+
+		switch {
+		case !ok:
+			r.Missing = append(r.Missing, v)
+		case existing.DecimalBlockNumber != v.DecimalBlockNumber || existing.BlockHash != v.BlockHash:
+			r.Mismatched = append(r.Mismatched, RecordMismatch{Stored: existing, Expected: v})
+		}
+
+		delete(byKey, v.Key())
+	}
+
+	for _, v := range byKey {
+		r.Extra = append(r.Extra, v)
+	}
+
+	sort.Slice(r.Extra, func(i, j int) bool {
+		return r.Extra[i].DecimalBlockNumber < r.Extra[j].DecimalBlockNumber
+	})
+}
+
+// AffectedRanges returns the merged block ranges holding a difference, the blocks of both sides of a mismatch
+// included.
+func (r IntegrityReport) AffectedRanges() []BlockRange {
+	var ranges []BlockRange
+
+	add := func(block uint64) {
+		ranges = append(ranges, BlockRange{FromBlock: block, ToBlock: block})
+	}
+
+	for _, v := range r.Missing {
+		add(v.DecimalBlockNumber)
+	}
+
+	for _, v := range r.Extra {
+		add(v.DecimalBlockNumber)
+	}
+
+	for _, v := range r.Mismatched {
+		add(v.Stored.DecimalBlockNumber)
+		add(v.Expected.DecimalBlockNumber)
+	}
+
+	return MergeBlockRanges(ranges)
+}
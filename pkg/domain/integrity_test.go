@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIntegrityReport_Diff(t *testing.T) {
+	var (
+		expected = []Transaction{
+			{Hash: "0x1", BlockHash: "0xa", DecimalBlockNumber: 100},
+			{Hash: "0x2", BlockHash: "0xb", DecimalBlockNumber: 101},
+			{Hash: "0x3", BlockHash: "0xc", DecimalBlockNumber: 105},
+		}
+		stored = []Transaction{
+			{Hash: "0x1", BlockHash: "0xa", DecimalBlockNumber: 100},
+			{Hash: "0x3", BlockHash: "0xf", DecimalBlockNumber: 104},
+			{Hash: "0x4", BlockHash: "0xd", DecimalBlockNumber: 102},
+			{Hash: "0x5", Type: TransactionTypeInternal, TraceAddress: "0", DecimalBlockNumber: 103},
+		}
+	)
+
+	var report IntegrityReport
+	report.Diff(expected, stored)
+
+	if report.Expected != 3 || report.Stored != 3 || report.Consistent() {
+		t.Fatalf("Diff() = %+v, want 3 logs compared with 3 records, the internal one ignored", report)
+	}
+
+	if len(report.Missing) != 1 || report.Missing[0].Hash != "0x2" {
+		t.Errorf("Diff() missing = %v, want 0x2", report.Missing)
+	}
+
+	if len(report.Extra) != 1 || report.Extra[0].Hash != "0x4" {
+		t.Errorf("Diff() extra = %v, want 0x4", report.Extra)
+	}
+
+	if len(report.Mismatched) != 1 || report.Mismatched[0].Stored.BlockHash != "0xf" {
+		t.Errorf("Diff() mismatched = %v, want 0x3 stored on another block", report.Mismatched)
+	}
+
+	want := []BlockRange{{FromBlock: 101, ToBlock: 102}, {FromBlock: 104, ToBlock: 105}}
+
+	if got := report.AffectedRanges(); !reflect.DeepEqual(got, want) {
+		t.Errorf("AffectedRanges() = %v, want %v", got, want)
+	}
+}
+
+func TestIntegrityReport_Consistent(t *testing.T) {
+	var (
+		// the logs of a single transaction
+		records = []Transaction{
+			{Hash: "0x1", BlockHash: "0xa", DecimalBlockNumber: 100, LogIndex: 0},
+			{Hash: "0x1", BlockHash: "0xa", DecimalBlockNumber: 100, LogIndex: 1},
+			{Hash: "0x1", BlockHash: "0xa", DecimalBlockNumber: 100, LogIndex: 2},
+		}
+		report IntegrityReport
+	)
+
+	report.Diff(records, records)
+
+	if !report.Consistent() || report.Expected != 3 || report.Stored != 3 || len(report.AffectedRanges()) != 0 {
+		t.Errorf("Diff() = %+v, want consistent", report)
+	}
+
+	report = IntegrityReport{}
+	report.Diff(records, records[:2])
+
+	if len(report.Missing) != 1 || report.Missing[0].LogIndex != 2 {
+		t.Errorf("Diff() missing = %v, want the log 2 of 0x1", report.Missing)
+	}
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+)
+
+// InternalCall is a message call executed by a contract during a transaction, as reported by the node tracers.
+type InternalCall struct {
+	TransactionHash string
+	BlockNumber     uint64
+	BlockHash       string
+	CallType        string
+	From            string
+	To              string
+	Value           Quantity
+	TraceAddress    []int
+}
+
+// TransfersValue reports whether the call moved ETH, delegate and static calls never do.
+func (c InternalCall) TransfersValue() bool {
+	switch strings.ToLower(c.CallType) {
+	case "delegatecall", "staticcall":
+		return false
+	}
+
+	return c.Value.Sign() > 0
+}
+
+// Touches reports whether the given address is the sender or the receiver of the call.
+func (c InternalCall) Touches(address Address) bool {
+	return HexToAddress(c.From) == address || HexToAddress(c.To) == address
+}
+
+func (c InternalCall) traceAddressString() string {
+	var parts = make([]string, len(c.TraceAddress))
+
+	for i, v := range c.TraceAddress {
+		parts[i] = strconv.Itoa(v)
+	}
+
+	return strings.Join(parts, "_")
+}
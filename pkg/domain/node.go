@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// NodeStatus describes the node serving a chain, as reported by eth_chainId, net_version,
+// eth_syncing and web3_clientVersion.
+type NodeStatus struct {
+	ChainID       ChainID   `json:"chainId"`
+	NetworkID     string    `json:"networkId"`
+	ClientVersion string    `json:"clientVersion"`
+	Syncing       bool      `json:"syncing"`
+	CurrentBlock  uint64    `json:"currentBlock,omitempty"`
+	HighestBlock  uint64    `json:"highestBlock,omitempty"`
+	CheckedAt     time.Time `json:"checkedAt"`
+}
+
+// Verify checks the node serves the expected chain.
+func (s NodeStatus) Verify(expected ChainID) error {
+	if s.ChainID != expected {
+		return errors.Wrapf(ErrChainIDMismatch, "expected chain %d, node serves chain %d", expected, s.ChainID)
+	}
+
+	return nil
+}
+
+// SyncProgress returns the percentage of blocks the node has synced, 100 when it isn't syncing.
+func (s NodeStatus) SyncProgress() float64 {
+	if !s.Syncing || s.HighestBlock == 0 {
+		return 100
+	}
+
+	return float64(s.CurrentBlock) * 100 / float64(s.HighestBlock)
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNodeStatus_Verify(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   NodeStatus
+		expected ChainID
+		wantErr  error
+	}{
+		{
+			name:     "should accept the expected chain",
+			status:   NodeStatus{ChainID: 1, NetworkID: "1"},
+			expected: 1,
+		},
+		{
+			name:     "should refuse a node serving another chain",
+			status:   NodeStatus{ChainID: 11155111, NetworkID: "11155111"},
+			expected: 1,
+			wantErr:  ErrChainIDMismatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.status.Verify(tt.expected); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNodeStatus_SyncProgress(t *testing.T) {
+	tests := []struct {
+		name   string
+		status NodeStatus
+		want   float64
+	}{
+		{
+			name:   "should be complete when not syncing",
+			status: NodeStatus{},
+			want:   100,
+		},
+		{
+			name:   "should report the synced percentage",
+			status: NodeStatus{Syncing: true, CurrentBlock: 250, HighestBlock: 1000},
+			want:   25,
+		},
+		{
+			name:   "should be complete while the highest block is unknown",
+			status: NodeStatus{Syncing: true},
+			want:   100,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.status.SyncProgress(); got != tt.want {
+				t.Errorf("SyncProgress() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,25 +2,75 @@ package domain
 
 import (
 	"context"
-	"errors"
 	"log/slog"
 	"math"
-	"regexp"
+	"time"
 )
 
+// Parser queries the indexed data of the default chain. It's an adapter of ParserV2 swallowing errors, kept for
+// backward compatibility: its methods are frozen, new ones going to ParserV2.
 type Parser interface {
 	GetCurrentBlock() int
 	Subscribe(address string) bool
 	GetTransactions(address string) []Transaction
 }
 
+// ParserExtensions are the methods the Parser returned by NewParser implements besides the frozen ones, reached
+// through a type assertion. Methods without a chain parameter operate on the default chain.
+type ParserExtensions interface {
+	Parser
+
+	GetTransactionsByTime(address string, from, to time.Time) []Transaction
+	GetPendingTransactions(address string) []PendingTransaction
+
+	GetCurrentBlockOn(chain ChainID) int
+	SubscribeOn(chain ChainID, address string) bool
+	GetTransactionsOn(chain ChainID, address string) []Transaction
+
+	// QueryTransactions returns a page of the records matching the query, an invalid query failing with ErrInvalidQuery.
+	QueryTransactions(query TransactionQuery) (TransactionPage, error)
+	QueryTransactionsOn(chain ChainID, query TransactionQuery) (TransactionPage, error)
+
+	// GetTransactionsByHash and GetBlockTransactions return the stored records across every subscribed address.
+	GetTransactionsByHash(hash string) []Transaction
+	GetTransactionsByHashOn(chain ChainID, hash string) []Transaction
+	GetBlockTransactions(blockNumber uint64) []Transaction
+	GetBlockTransactionsOn(chain ChainID, blockNumber uint64) []Transaction
+
+	// Watch streams the events matching the filter until the context is done.
+	Watch(ctx context.Context, filter EventFilter, opts ...WatchOption) (<-chan Event, error)
+}
+
 type RepositoryReader interface {
-	GetTransactions(ctx context.Context, address string) ([]Transaction, error)
-	GetLatestBlock(ctx context.Context) (int64, error)
+	GetTransactions(ctx context.Context, address Address) ([]Transaction, error)
+	GetTransactionsByBlockRange(ctx context.Context, address Address, fromBlock, toBlock uint64) ([]Transaction, error)
+	QueryTransactions(ctx context.Context, query TransactionQuery) (TransactionPage, error)
+	GetTransactionsByHash(ctx context.Context, hash string) ([]Transaction, error)
+	GetTransactionsByBlock(ctx context.Context, blockNumber uint64) ([]Transaction, error)
+	GetBlockRange(ctx context.Context, from, to time.Time) (uint64, uint64, error)
+	GetPendingTransactions(ctx context.Context, address Address) ([]PendingTransaction, error)
+	GetLatestBlock(ctx context.Context) (uint64, error)
+	GetGaps(ctx context.Context, address Address) ([]Gap, error)
 }
 
 type EventListener interface {
-	Listen(ctx context.Context, address string) error
+	// Listen subscribes to the address, failing with ErrInvalidPolling on invalid options.
+	Listen(ctx context.Context, address Address, opts ...SubscribeOption) error
+	// Subscription returns the subscription of the address, failing with ErrSubscriptionNotFound when it never was.
+	Subscription(address Address) (Subscription, error)
+	Subscriptions() []Subscription
+	// Pause stops indexing the address, keeping its cursor, and Resume catches up the missed records before
+	// indexing it again.
+	Pause(ctx context.Context, address Address) error
+	Resume(ctx context.Context, address Address) error
+	// Rescan re-indexes the address from the given block up to the last confirmed one in the background, its progress
+	// being reported by the subscription.
+	Rescan(ctx context.Context, address Address, fromBlock uint64) error
+}
+
+// EventSource streams the live events of the indexed records.
+type EventSource interface {
+	Subscribe(ctx context.Context, filter EventFilter, opts ...WatchOption) <-chan Event
 }
 
 type Options func(*parser)
@@ -31,66 +81,164 @@ func WithLogger(l *slog.Logger) Options {
 	}
 }
 
+// WithDefaultChain sets the ID of the chain indexed by the repository and event listener given to NewParser.
+func WithDefaultChain(id ChainID) Options {
+	return func(e *parser) {
+		e.defaultChain = id
+	}
+}
+
+// WithVerifier sets the verifier of the records of the default chain.
+func WithVerifier(verifier Verifier) Options {
+	return func(e *parser) {
+		e.verifier = verifier
+	}
+}
+
+// WithChains registers additional chains.
+func WithChains(chains ...Chain) Options {
+	return func(e *parser) {
+		for _, chain := range chains {
+			e.chains[chain.ID] = chain
+		}
+	}
+}
+
+// WithEventSource sets the source of the events streamed by Watch.
+func WithEventSource(source EventSource) Options {
+	return func(e *parser) {
+		e.events = source
+	}
+}
+
+// parser implements ParserV2.
 type parser struct {
-	logger        *slog.Logger
-	repo          RepositoryReader
-	eventListener EventListener
+	logger       *slog.Logger
+	defaultChain ChainID
+	chains       map[ChainID]Chain
+	events       EventSource
+	verifier     Verifier
 }
 
-func NewParser(repo RepositoryReader, eventListener EventListener, opts ...Options) Parser {
+func newParser(repo RepositoryReader, eventListener EventListener, opts ...Options) *parser {
 	p := &parser{
-		logger:        slog.Default(),
-		repo:          repo,
-		eventListener: eventListener,
+		logger:       slog.Default(),
+		defaultChain: DefaultChainID,
+		chains:       make(map[ChainID]Chain),
 	}
 
 	for _, opt := range opts {
 		opt(p)
 	}
 
+	p.chains[p.defaultChain] = Chain{
+		ID:            p.defaultChain,
+		Repo:          repo,
+		EventListener: eventListener,
+		Verifier:      p.verifier,
+	}
+
 	return p
 }
 
-func (p *parser) GetCurrentBlock() int {
-	blockNumber, err := p.repo.GetLatestBlock(context.Background())
+func NewParser(repo RepositoryReader, eventListener EventListener, opts ...Options) Parser {
+	p := newParser(repo, eventListener, opts...)
+
+	return &parserAdapter{parser: p, logger: p.logger, defaultChain: p.defaultChain}
+}
+
+// parserAdapter implements Parser on top of ParserV2, returning zero values on errors.
+type parserAdapter struct {
+	parser       ParserV2
+	logger       *slog.Logger
+	defaultChain ChainID
+}
+
+func (a *parserAdapter) GetCurrentBlock() int {
+	return a.GetCurrentBlockOn(a.defaultChain)
+}
+
+func (a *parserAdapter) GetCurrentBlockOn(chainID ChainID) int {
+	blockNumber, err := a.parser.GetCurrentBlock(context.Background(), chainID)
 	if err != nil {
 		return 0
 	}
 
 	// Just to keep the proposed interface, we are returning zero in case of conversion overflow
-	if blockNumber > math.MaxInt32 {
-		p.logger.Error("Block number int32 overflow", "blockNumber", blockNumber)
+	if blockNumber > math.MaxInt {
+		a.logger.Error("Block number int overflow", "blockNumber", blockNumber)
 		return 0
 	}
 
 	return int(blockNumber)
 }
 
-func (p *parser) GetTransactions(address string) []Transaction {
-	transactions, err := p.repo.GetTransactions(context.Background(), address)
-	if (err != nil && errors.Is(err, ErrAddressNotFound)) || (transactions == nil) {
-		return []Transaction{}
-	}
+func (a *parserAdapter) Watch(ctx context.Context, filter EventFilter, opts ...WatchOption) (<-chan Event, error) {
+	return a.parser.Watch(ctx, filter, opts...)
+}
+
+func (a *parserAdapter) GetTransactions(address string) []Transaction {
+	return a.GetTransactionsOn(a.defaultChain, address)
+}
+
+func (a *parserAdapter) GetTransactionsOn(chainID ChainID, address string) []Transaction {
+	transactions, _ := a.parser.GetTransactions(context.Background(), chainID, address)
 
 	return transactions
 }
 
-func (p *parser) Subscribe(address string) bool {
-	if !isValidAddress(address) {
-		return false
-	}
+func (a *parserAdapter) QueryTransactions(query TransactionQuery) (TransactionPage, error) {
+	return a.QueryTransactionsOn(a.defaultChain, query)
+}
+
+func (a *parserAdapter) QueryTransactionsOn(chainID ChainID, query TransactionQuery) (TransactionPage, error) {
+	return a.parser.QueryTransactions(context.Background(), chainID, query)
+}
+
+func (a *parserAdapter) GetTransactionsByHash(hash string) []Transaction {
+	return a.GetTransactionsByHashOn(a.defaultChain, hash)
+}
+
+func (a *parserAdapter) GetTransactionsByHashOn(chainID ChainID, hash string) []Transaction {
+	transactions, _ := a.parser.GetTransactionsByHash(context.Background(), chainID, hash)
+
+	return transactions
+}
+
+func (a *parserAdapter) GetBlockTransactions(blockNumber uint64) []Transaction {
+	return a.GetBlockTransactionsOn(a.defaultChain, blockNumber)
+}
+
+func (a *parserAdapter) GetBlockTransactionsOn(chainID ChainID, blockNumber uint64) []Transaction {
+	transactions, _ := a.parser.GetBlockTransactions(context.Background(), chainID, blockNumber)
+
+	return transactions
+}
+
+// GetTransactionsByTime returns the transactions included in blocks produced within [from, to].
+// A zero from or to means the range is unbounded on that side.
+func (a *parserAdapter) GetTransactionsByTime(address string, from, to time.Time) []Transaction {
+	transactions, _ := a.parser.GetTransactionsByTime(context.Background(), a.defaultChain, address, from, to)
+
+	return transactions
+}
 
-	err := p.eventListener.Listen(context.Background(), address)
+// GetPendingTransactions returns the mempool transactions seen for the given address, along with their state.
+func (a *parserAdapter) GetPendingTransactions(address string) []PendingTransaction {
+	transactions, _ := a.parser.GetPendingTransactions(context.Background(), a.defaultChain, address)
+
+	return transactions
+}
+
+func (a *parserAdapter) Subscribe(address string) bool {
+	return a.SubscribeOn(a.defaultChain, address)
+}
+
+func (a *parserAdapter) SubscribeOn(chainID ChainID, address string) bool {
+	err := a.parser.Subscribe(context.Background(), chainID, address)
 	if err != nil {
-		p.logger.Error("Failed to subscribe to address", "error", err)
+		a.logger.Error("Failed to subscribe to address", "error", err, "address", address, "chain", chainID)
 	}
 
 	return err == nil
 }
-
-// isValidAddress checks if the given string is a valid Ethereum address
-// @see https://goethereumbook.org/en/address-check/
-func isValidAddress(v string) bool {
-	re := regexp.MustCompile("^0x[0-9a-fA-F]{40}$")
-	return re.MatchString(v)
-}
@@ -22,7 +22,7 @@ func TestParserIntegrationTest(t *testing.T) {
 	var (
 		api         = mocks.NewEthJSONAPI(t)
 		poolingTime = time.Millisecond * 10
-		address     = "0x35fA164735182de50811E8e2E824cFb9B6118ac2"
+		address     = domain.HexToAddress("0x35fA164735182de50811E8e2E824cFb9B6118ac2")
 		filterID    = "0x1"
 	)
 
@@ -82,7 +82,7 @@ func TestParserIntegrationTest(t *testing.T) {
 	)
 
 	t.Run("GetTransactions should return zero when not subscribed", func(t *testing.T) {
-		transactions := parser.GetTransactions(address)
+		transactions := parser.GetTransactions(address.Hex())
 		if len(transactions) != 0 {
 			t.Errorf("expected zero transactions, got %d", len(transactions))
 		}
@@ -94,14 +94,14 @@ func TestParserIntegrationTest(t *testing.T) {
 	})
 
 	t.Run("Should subscribe and return 3 transactions on GetTransactions successfully", func(t *testing.T) {
-		if success := parser.Subscribe(address); !success {
+		if success := parser.Subscribe(address.Hex()); !success {
 			t.Fatalf("expected true, got false")
 		}
 
 		// waiting for the event listener to fetch the transactions
 		time.Sleep(poolingTime * 5)
 
-		transactions := parser.GetTransactions(address)
+		transactions := parser.GetTransactions(address.Hex())
 		if len(transactions) != 3 {
 			t.Errorf("expected zero transactions, got %d", len(transactions))
 		}
@@ -2,10 +2,13 @@ package domain_test
 
 import (
 	"bytes"
+	"context"
 	"log/slog"
 	"math"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -34,7 +37,7 @@ func Test_parser_GetCurrentBlock(t *testing.T) {
 				logger: logger,
 				repo: func(t *testing.T) domain.RepositoryReader {
 					repo := mocks.NewRepositoryReader(t)
-					repo.EXPECT().GetLatestBlock(mock.Anything).Return(int64(0), domain.ErrBlockNotFound).Once()
+					repo.EXPECT().GetLatestBlock(mock.Anything).Return(uint64(0), domain.ErrBlockNotFound).Once()
 					return repo
 				},
 				eventListener: nil,
@@ -42,12 +45,12 @@ func Test_parser_GetCurrentBlock(t *testing.T) {
 			want: 0,
 		},
 		{
-			name: "should return 0 when repository's response overflow int32 max value",
+			name: "should return 0 when repository's response overflow int max value",
 			fields: fields{
 				logger: logger,
 				repo: func(t *testing.T) domain.RepositoryReader {
 					repo := mocks.NewRepositoryReader(t)
-					repo.EXPECT().GetLatestBlock(mock.Anything).Return(math.MaxInt32+1, nil).Once()
+					repo.EXPECT().GetLatestBlock(mock.Anything).Return(uint64(math.MaxInt)+1, nil).Once()
 					return repo
 				},
 				eventListener: nil,
@@ -60,7 +63,7 @@ func Test_parser_GetCurrentBlock(t *testing.T) {
 				logger: logger,
 				repo: func(t *testing.T) domain.RepositoryReader {
 					repo := mocks.NewRepositoryReader(t)
-					repo.EXPECT().GetLatestBlock(mock.Anything).Return(int64(65), nil).Once()
+					repo.EXPECT().GetLatestBlock(mock.Anything).Return(uint64(65), nil).Once()
 					return repo
 				},
 				eventListener: nil,
@@ -86,10 +89,11 @@ func Test_parser_GetCurrentBlock(t *testing.T) {
 func Test_parser_GetTransactions(t *testing.T) {
 	var (
 		logger       = slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))
+		address      = domain.Address("0x35fa164735182de50811e8e2e824cfb9b6118ac2")
 		transactions = []domain.Transaction{
 			{
 				Hash:    "0x1",
-				Address: "0x123",
+				Address: address,
 			},
 		}
 	)
@@ -113,12 +117,12 @@ func Test_parser_GetTransactions(t *testing.T) {
 				logger: logger,
 				repo: func(t *testing.T) domain.RepositoryReader {
 					repo := mocks.NewRepositoryReader(t)
-					repo.EXPECT().GetTransactions(mock.Anything, "0x123").Return(nil, domain.ErrAddressNotFound).Once()
+					repo.EXPECT().GetTransactions(mock.Anything, address).Return(nil, domain.ErrAddressNotFound).Once()
 					return repo
 				},
 			},
 			args: args{
-				address: "0x123",
+				address: address.Hex(),
 			},
 			want: []domain.Transaction{},
 		},
@@ -128,10 +132,23 @@ func Test_parser_GetTransactions(t *testing.T) {
 				logger: logger,
 				repo: func(t *testing.T) domain.RepositoryReader {
 					repo := mocks.NewRepositoryReader(t)
-					repo.EXPECT().GetTransactions(mock.Anything, "0x123").Return(nil, errors.New("network error")).Once()
+					repo.EXPECT().GetTransactions(mock.Anything, address).Return(nil, errors.New("network error")).Once()
 					return repo
 				},
 			},
+			args: args{
+				address: address.Hex(),
+			},
+			want: []domain.Transaction{},
+		},
+		{
+			name: "should return empty transactions list on invalid address",
+			fields: fields{
+				logger: logger,
+				repo: func(t *testing.T) domain.RepositoryReader {
+					return mocks.NewRepositoryReader(t)
+				},
+			},
 			args: args{
 				address: "0x123",
 			},
@@ -143,12 +160,12 @@ func Test_parser_GetTransactions(t *testing.T) {
 				logger: logger,
 				repo: func(t *testing.T) domain.RepositoryReader {
 					repo := mocks.NewRepositoryReader(t)
-					repo.EXPECT().GetTransactions(mock.Anything, "0x123").Return(transactions, nil).Once()
+					repo.EXPECT().GetTransactions(mock.Anything, address).Return(transactions, nil).Once()
 					return repo
 				},
 			},
 			args: args{
-				address: "0x123",
+				address: address.Hex(),
 			},
 			want: transactions,
 		},
@@ -189,7 +206,7 @@ func Test_parser_Subscribe(t *testing.T) {
 				logger: logger,
 				eventListener: func(t *testing.T) domain.EventListener {
 					el := mocks.NewEventListener(t)
-					el.EXPECT().Listen(mock.Anything, "0x35fA164735182de50811E8e2E824cFb9B6118ac2").Return(nil).Once()
+					el.EXPECT().Listen(mock.Anything, domain.Address("0x35fa164735182de50811e8e2e824cfb9b6118ac2")).Return(nil).Once()
 					return el
 				},
 			},
@@ -211,13 +228,41 @@ func Test_parser_Subscribe(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "should return false on invalid address checksum",
+			fields: fields{
+				logger: logger,
+				eventListener: func(*testing.T) domain.EventListener {
+					return nil
+				},
+			},
+			args: args{
+				address: "0x35Fa164735182de50811E8e2E824cFb9B6118ac2",
+			},
+			want: false,
+		},
+		{
+			name: "should subscribe the same address regardless of its casing",
+			fields: fields{
+				logger: logger,
+				eventListener: func(t *testing.T) domain.EventListener {
+					el := mocks.NewEventListener(t)
+					el.EXPECT().Listen(mock.Anything, domain.Address("0x35fa164735182de50811e8e2e824cfb9b6118ac2")).Return(nil).Once()
+					return el
+				},
+			},
+			args: args{
+				address: "0x35FA164735182DE50811E8E2E824CFB9B6118AC2",
+			},
+			want: true,
+		},
 		{
 			name: "should return false when already subscribed",
 			fields: fields{
 				logger: logger,
 				eventListener: func(t *testing.T) domain.EventListener {
 					el := mocks.NewEventListener(t)
-					el.EXPECT().Listen(mock.Anything, "0x35fA164735182de50811E8e2E824cFb9B6118ac2").
+					el.EXPECT().Listen(mock.Anything, domain.Address("0x35fa164735182de50811e8e2e824cfb9b6118ac2")).
 						Return(domain.ErrAlreadySubscribed).
 						Once()
 					return el
@@ -240,3 +285,266 @@ func Test_parser_Subscribe(t *testing.T) {
 		})
 	}
 }
+
+func Test_parser_GetTransactionsByTime(t *testing.T) {
+	var (
+		address      = domain.Address("0x35fa164735182de50811e8e2e824cfb9b6118ac2")
+		from         = time.Unix(100, 0)
+		to           = time.Unix(200, 0)
+		transactions = []domain.Transaction{
+			{
+				Hash:               "0x1",
+				Address:            address,
+				DecimalBlockNumber: 2,
+				Timestamp:          150,
+			},
+		}
+	)
+
+	tests := []struct {
+		name string
+		repo func(*testing.T) domain.RepositoryReader
+		want []domain.Transaction
+	}{
+		{
+			name: "should return empty transactions list when there are no blocks in the time range",
+			repo: func(t *testing.T) domain.RepositoryReader {
+				repo := mocks.NewRepositoryReader(t)
+				repo.EXPECT().GetBlockRange(mock.Anything, from, to).Return(uint64(0), uint64(0), domain.ErrBlockNotFound).Once()
+				return repo
+			},
+			want: []domain.Transaction{},
+		},
+		{
+			name: "should return the transactions within the resolved block range",
+			repo: func(t *testing.T) domain.RepositoryReader {
+				repo := mocks.NewRepositoryReader(t)
+				repo.EXPECT().GetBlockRange(mock.Anything, from, to).Return(uint64(2), uint64(5), nil).Once()
+				repo.EXPECT().GetTransactionsByBlockRange(mock.Anything, address, uint64(2), uint64(5)).
+					Return(transactions, nil).
+					Once()
+				return repo
+			},
+			want: transactions,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := domain.NewParser(tt.repo(t), nil)
+			got := p.(domain.ParserExtensions).GetTransactionsByTime(address.Hex(), from, to)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetTransactionsByTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parser_QueryTransactions(t *testing.T) {
+	var (
+		address = domain.Address("0x35fa164735182de50811e8e2e824cfb9b6118ac2")
+		page    = domain.TransactionPage{
+			Transactions: []domain.Transaction{{Hash: "0x1", Address: address, DecimalBlockNumber: 2}},
+			NextCursor:   "next",
+		}
+	)
+
+	tests := []struct {
+		name    string
+		query   domain.TransactionQuery
+		repo    func(*testing.T) domain.RepositoryReader
+		want    domain.TransactionPage
+		wantErr error
+	}{
+		{
+			name:  "should refuse an invalid query without querying the repository",
+			query: domain.TransactionQuery{Address: address, FromBlock: 10, ToBlock: 5},
+			repo: func(t *testing.T) domain.RepositoryReader {
+				return mocks.NewRepositoryReader(t)
+			},
+			want:    domain.TransactionPage{Transactions: []domain.Transaction{}},
+			wantErr: domain.ErrInvalidQuery,
+		},
+		{
+			name:  "should return an empty page when the address is unknown",
+			query: domain.TransactionQuery{Address: address},
+			repo: func(t *testing.T) domain.RepositoryReader {
+				repo := mocks.NewRepositoryReader(t)
+				repo.EXPECT().QueryTransactions(mock.Anything, mock.Anything).
+					Return(domain.TransactionPage{}, domain.ErrAddressNotFound).
+					Once()
+				return repo
+			},
+			want: domain.TransactionPage{Transactions: []domain.Transaction{}},
+		},
+		{
+			name:  "should query the repository with the default order and limit",
+			query: domain.TransactionQuery{Address: address, Direction: domain.DirectionIn},
+			repo: func(t *testing.T) domain.RepositoryReader {
+				repo := mocks.NewRepositoryReader(t)
+				repo.EXPECT().QueryTransactions(mock.Anything, domain.TransactionQuery{
+					Address:   address,
+					Direction: domain.DirectionIn,
+					Order:     domain.SortAscending,
+					Limit:     domain.DefaultQueryLimit,
+				}).Return(page, nil).Once()
+				return repo
+			},
+			want: page,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := domain.NewParser(tt.repo(t), nil)
+
+			got, err := p.(domain.ParserExtensions).QueryTransactions(tt.query)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("QueryTransactions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("QueryTransactions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parser_GetTransactionsByHash(t *testing.T) {
+	var (
+		hash         = "0x3585a53d05d27d622e3c5be8c34b5c4a6ad101929f3efbbac84dfd0129c67cc7"
+		transactions = []domain.Transaction{{Hash: hash, Address: "0x1"}, {Hash: hash, Address: "0x2"}}
+	)
+
+	tests := []struct {
+		name string
+		hash string
+		repo func(*testing.T) domain.RepositoryReader
+		want []domain.Transaction
+	}{
+		{
+			name: "should return empty transactions list on invalid hash",
+			hash: "0x123",
+			repo: func(t *testing.T) domain.RepositoryReader {
+				return mocks.NewRepositoryReader(t)
+			},
+			want: []domain.Transaction{},
+		},
+		{
+			name: "should return empty transactions list when the transaction is unknown",
+			hash: hash,
+			repo: func(t *testing.T) domain.RepositoryReader {
+				repo := mocks.NewRepositoryReader(t)
+				repo.EXPECT().GetTransactionsByHash(mock.Anything, hash).
+					Return([]domain.Transaction{}, domain.ErrTransactionNotFound).
+					Once()
+				return repo
+			},
+			want: []domain.Transaction{},
+		},
+		{
+			name: "should return empty transactions list on hash without 0x prefix",
+			hash: strings.ToUpper(hash[2:]),
+			repo: func(t *testing.T) domain.RepositoryReader {
+				return mocks.NewRepositoryReader(t)
+			},
+			want: []domain.Transaction{},
+		},
+		{
+			name: "should look up the lowercase hash, returning the records of every address",
+			hash: "0x" + strings.ToUpper(hash[2:]),
+			repo: func(t *testing.T) domain.RepositoryReader {
+				repo := mocks.NewRepositoryReader(t)
+				repo.EXPECT().GetTransactionsByHash(mock.Anything, hash).Return(transactions, nil).Once()
+				return repo
+			},
+			want: transactions,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := domain.NewParser(tt.repo(t), nil)
+			if got := p.(domain.ParserExtensions).GetTransactionsByHash(tt.hash); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetTransactionsByHash() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parser_MultiChain(t *testing.T) {
+	var (
+		address = domain.Address("0x35fa164735182de50811e8e2e824cfb9b6118ac2")
+		base    = domain.ChainID(8453)
+
+		mainnetRepo = mocks.NewRepositoryReader(t)
+		baseRepo    = mocks.NewRepositoryReader(t)
+		baseEL      = mocks.NewEventListener(t)
+
+		baseTransactions = []domain.Transaction{{ChainID: base, Hash: "0x1", Address: address}}
+	)
+
+	baseRepo.EXPECT().GetLatestBlock(mock.Anything).Return(uint64(65), nil).Once()
+	baseRepo.EXPECT().GetTransactions(mock.Anything, address).Return(baseTransactions, nil).Once()
+	baseEL.EXPECT().Listen(mock.Anything, address).Return(nil).Once()
+
+	p := domain.NewParser(
+		mainnetRepo,
+		mocks.NewEventListener(t),
+		domain.WithChains(domain.Chain{ID: base, Repo: baseRepo, EventListener: baseEL}),
+	).(domain.ParserExtensions)
+
+	if got := p.SubscribeOn(base, address.Hex()); !got {
+		t.Errorf("SubscribeOn() = %v, want true", got)
+	}
+
+	if got := p.GetCurrentBlockOn(base); got != 65 {
+		t.Errorf("GetCurrentBlockOn() = %v, want 65", got)
+	}
+
+	if got := p.GetTransactionsOn(base, address.Hex()); !reflect.DeepEqual(got, baseTransactions) {
+		t.Errorf("GetTransactionsOn() = %v, want %v", got, baseTransactions)
+	}
+
+	// unknown chains never reach any repository or event listener
+	if got := p.SubscribeOn(10, address.Hex()); got {
+		t.Errorf("SubscribeOn() on unknown chain = %v, want false", got)
+	}
+
+	if got := p.GetCurrentBlockOn(10); got != 0 {
+		t.Errorf("GetCurrentBlockOn() on unknown chain = %v, want 0", got)
+	}
+
+	if got := p.GetTransactionsOn(10, address.Hex()); len(got) != 0 {
+		t.Errorf("GetTransactionsOn() on unknown chain = %v, want empty", got)
+	}
+}
+
+func Test_parser_Watch(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		filter = domain.EventFilter{Addresses: []domain.Address{"0x35fa164735182de50811e8e2e824cfb9b6118ac2"}}
+		events = make(chan domain.Event)
+	)
+
+	p := domain.NewParser(mocks.NewRepositoryReader(t), mocks.NewEventListener(t)).(domain.ParserExtensions)
+
+	if _, err := p.Watch(ctx, filter); !errors.Is(err, domain.ErrWatchNotSupported) {
+		t.Fatalf("Watch() error = %v, want %v", err, domain.ErrWatchNotSupported)
+	}
+
+	source := mocks.NewEventSource(t)
+	source.EXPECT().Subscribe(ctx, filter, mock.Anything).Return(events).Once()
+
+	p = domain.NewParser(
+		mocks.NewRepositoryReader(t),
+		mocks.NewEventListener(t),
+		domain.WithEventSource(source),
+	).(domain.ParserExtensions)
+
+	got, err := p.Watch(ctx, filter, domain.WithSlowConsumerPolicy(domain.SlowConsumerDropOldest))
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+
+	if got != (<-chan domain.Event)(events) {
+		t.Errorf("Watch() returned another channel than the event source one")
+	}
+}
@@ -0,0 +1,368 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ParserV2 queries the indexed data, reporting failures through typed errors: ErrInvalidAddress, ErrAlreadySubscribed,
+// ErrNotFound and ErrUpstreamUnavailable, to be checked with errors.Is. A zero chain targets the default chain.
+type ParserV2 interface {
+	GetCurrentBlock(ctx context.Context, chain ChainID) (uint64, error)
+	Subscribe(ctx context.Context, chain ChainID, address string, opts ...SubscribeOption) error
+	GetTransactions(ctx context.Context, chain ChainID, address string) ([]Transaction, error)
+	GetTransactionsByTime(ctx context.Context, chain ChainID, address string, from, to time.Time) ([]Transaction, error)
+	GetPendingTransactions(ctx context.Context, chain ChainID, address string) ([]PendingTransaction, error)
+	QueryTransactions(ctx context.Context, chain ChainID, query TransactionQuery) (TransactionPage, error)
+	GetTransactionsByHash(ctx context.Context, chain ChainID, hash string) ([]Transaction, error)
+	GetBlockTransactions(ctx context.Context, chain ChainID, blockNumber uint64) ([]Transaction, error)
+
+	// GetSubscription and GetSubscriptions return the lifecycle state of the subscriptions, the stopped ones included.
+	GetSubscription(ctx context.Context, chain ChainID, address string) (Subscription, error)
+	GetSubscriptions(ctx context.Context, chain ChainID) ([]Subscription, error)
+	PauseSubscription(ctx context.Context, chain ChainID, address string) error
+	ResumeSubscription(ctx context.Context, chain ChainID, address string) error
+	// RescanSubscription re-indexes the address from the given block while it's still indexed live, returning once
+	// the rescan is started.
+	RescanSubscription(ctx context.Context, chain ChainID, address string, fromBlock uint64) error
+	// GetSubscriptionGaps returns the ranges of blocks found missing from the indexed ones, and their repair.
+	GetSubscriptionGaps(ctx context.Context, chain ChainID, address string) ([]Gap, error)
+	// VerifySubscription compares the stored records of the address within the block range with the logs of the
+	// node, repairing the blocks found to differ when repair is set.
+	VerifySubscription(
+		ctx context.Context,
+		chain ChainID,
+		address string,
+		fromBlock, toBlock uint64,
+		repair bool,
+	) (IntegrityReport, error)
+
+	// Watch streams the events matching the filter until the context is done.
+	Watch(ctx context.Context, filter EventFilter, opts ...WatchOption) (<-chan Event, error)
+}
+
+func NewParserV2(repo RepositoryReader, eventListener EventListener, opts ...Options) ParserV2 {
+	return newParser(repo, eventListener, opts...)
+}
+
+func (p *parser) chain(id ChainID) (Chain, error) {
+	if id == 0 {
+		id = p.defaultChain
+	}
+
+	chain, ok := p.chains[id]
+	if !ok {
+		return Chain{}, errors.Wrapf(ErrUnknownChain, "chain %d", id)
+	}
+
+	return chain, nil
+}
+
+// GetCurrentBlock returns the last block parsed on the chain, failing with ErrBlockNotFound when none was.
+func (p *parser) GetCurrentBlock(ctx context.Context, chainID ChainID) (uint64, error) {
+	chain, err := p.chain(chainID)
+	if err != nil {
+		return 0, err
+	}
+
+	return chain.Repo.GetLatestBlock(ctx)
+}
+
+func (p *parser) Subscribe(ctx context.Context, chainID ChainID, address string, opts ...SubscribeOption) error {
+	chain, err := p.chain(chainID)
+	if err != nil {
+		return err
+	}
+
+	addr, err := NewAddress(address)
+	if err != nil {
+		return err
+	}
+
+	return chain.EventListener.Listen(ctx, addr, opts...)
+}
+
+// GetTransactions returns the records of the address, an address without records returning an empty list.
+func (p *parser) GetTransactions(ctx context.Context, chainID ChainID, address string) ([]Transaction, error) {
+	chain, err := p.chain(chainID)
+	if err != nil {
+		return []Transaction{}, err
+	}
+
+	addr, err := NewAddress(address)
+	if err != nil {
+		return []Transaction{}, err
+	}
+
+	return emptyOnNotFound(chain.Repo.GetTransactions(ctx, addr))
+}
+
+// GetTransactionsByTime returns the transactions included in blocks produced within [from, to].
+// A zero from or to means the range is unbounded on that side.
+func (p *parser) GetTransactionsByTime(
+	ctx context.Context,
+	chainID ChainID,
+	address string,
+	from, to time.Time,
+) ([]Transaction, error) {
+	chain, err := p.chain(chainID)
+	if err != nil {
+		return []Transaction{}, err
+	}
+
+	addr, err := NewAddress(address)
+	if err != nil {
+		return []Transaction{}, err
+	}
+
+	fromBlock, toBlock, err := chain.Repo.GetBlockRange(ctx, from, to)
+	if errors.Is(err, ErrNotFound) {
+		return []Transaction{}, nil
+	}
+
+	if err != nil {
+		return []Transaction{}, err
+	}
+
+	return emptyOnNotFound(chain.Repo.GetTransactionsByBlockRange(ctx, addr, fromBlock, toBlock))
+}
+
+// GetPendingTransactions returns the mempool transactions seen for the given address, along with their state.
+func (p *parser) GetPendingTransactions(
+	ctx context.Context,
+	chainID ChainID,
+	address string,
+) ([]PendingTransaction, error) {
+	chain, err := p.chain(chainID)
+	if err != nil {
+		return []PendingTransaction{}, err
+	}
+
+	addr, err := NewAddress(address)
+	if err != nil {
+		return []PendingTransaction{}, err
+	}
+
+	transactions, err := chain.Repo.GetPendingTransactions(ctx, addr)
+	if errors.Is(err, ErrNotFound) || (err == nil && transactions == nil) {
+		return []PendingTransaction{}, nil
+	}
+
+	return transactions, err
+}
+
+// QueryTransactions returns a page of the records matching the query, an invalid query failing with ErrInvalidQuery.
+// A time range is resolved into the range of the known blocks produced within it.
+func (p *parser) QueryTransactions(ctx context.Context, chainID ChainID, query TransactionQuery) (TransactionPage, error) {
+	var empty = TransactionPage{Transactions: []Transaction{}}
+
+	query, err := query.Normalize()
+	if err != nil {
+		return empty, err
+	}
+
+	chain, err := p.chain(chainID)
+	if err != nil {
+		return empty, err
+	}
+
+	if query.HasTimeRange() {
+		fromBlock, toBlock, err := chain.Repo.GetBlockRange(ctx, query.FromTime, query.ToTime)
+		if errors.Is(err, ErrNotFound) {
+			return empty, nil
+		}
+
+		if err != nil {
+			return empty, err
+		}
+
+		var ok bool
+		if query, ok = query.WithinBlocks(fromBlock, toBlock); !ok {
+			return empty, nil
+		}
+	}
+
+	page, err := chain.Repo.QueryTransactions(ctx, query)
+	if errors.Is(err, ErrNotFound) {
+		return empty, nil
+	}
+
+	if err != nil {
+		return empty, err
+	}
+
+	return page, nil
+}
+
+// GetTransactionsByHash returns the records of the transaction across every subscribed address,
+// failing with ErrTransactionNotFound when there are none.
+func (p *parser) GetTransactionsByHash(ctx context.Context, chainID ChainID, hash string) ([]Transaction, error) {
+	chain, err := p.chain(chainID)
+	if err != nil {
+		return []Transaction{}, err
+	}
+
+	hash, err = NewHash(hash)
+	if err != nil {
+		return []Transaction{}, err
+	}
+
+	transactions, err := chain.Repo.GetTransactionsByHash(ctx, hash)
+	if err != nil {
+		return []Transaction{}, err
+	}
+
+	if len(transactions) == 0 {
+		return []Transaction{}, ErrTransactionNotFound
+	}
+
+	return transactions, nil
+}
+
+// GetBlockTransactions returns the records of the block across every subscribed address.
+func (p *parser) GetBlockTransactions(ctx context.Context, chainID ChainID, blockNumber uint64) ([]Transaction, error) {
+	chain, err := p.chain(chainID)
+	if err != nil {
+		return []Transaction{}, err
+	}
+
+	return emptyOnNotFound(chain.Repo.GetTransactionsByBlock(ctx, blockNumber))
+}
+
+// GetSubscription returns the subscription of the address, failing with ErrSubscriptionNotFound when it never was.
+func (p *parser) GetSubscription(_ context.Context, chainID ChainID, address string) (Subscription, error) {
+	chain, err := p.chain(chainID)
+	if err != nil {
+		return Subscription{}, err
+	}
+
+	addr, err := NewAddress(address)
+	if err != nil {
+		return Subscription{}, err
+	}
+
+	return chain.EventListener.Subscription(addr)
+}
+
+func (p *parser) GetSubscriptions(_ context.Context, chainID ChainID) ([]Subscription, error) {
+	chain, err := p.chain(chainID)
+	if err != nil {
+		return []Subscription{}, err
+	}
+
+	return chain.EventListener.Subscriptions(), nil
+}
+
+// PauseSubscription stops indexing the address until it's resumed, failing with ErrNotSubscribed when it's not
+// subscribed, or ErrInvalidTransition when it can't be paused, e.g. being already paused.
+func (p *parser) PauseSubscription(ctx context.Context, chainID ChainID, address string) error {
+	chain, err := p.chain(chainID)
+	if err != nil {
+		return err
+	}
+
+	addr, err := NewAddress(address)
+	if err != nil {
+		return err
+	}
+
+	return chain.EventListener.Pause(ctx, addr)
+}
+
+// ResumeSubscription catches up the records missed by a paused subscription, then indexes the address again.
+func (p *parser) ResumeSubscription(ctx context.Context, chainID ChainID, address string) error {
+	chain, err := p.chain(chainID)
+	if err != nil {
+		return err
+	}
+
+	addr, err := NewAddress(address)
+	if err != nil {
+		return err
+	}
+
+	return chain.EventListener.Resume(ctx, addr)
+}
+
+// RescanSubscription supersedes the stored records of the address from the given block onward with the ones fetched
+// again from the node, failing with ErrRescanInProgress when the address is already being rescanned.
+func (p *parser) RescanSubscription(ctx context.Context, chainID ChainID, address string, fromBlock uint64) error {
+	chain, err := p.chain(chainID)
+	if err != nil {
+		return err
+	}
+
+	addr, err := NewAddress(address)
+	if err != nil {
+		return err
+	}
+
+	return chain.EventListener.Rescan(ctx, addr, fromBlock)
+}
+
+// Watch streams the events matching the filter: new and removed records, new heads and subscription errors.
+// Events are buffered up to the configured size, then handled by the slow consumer policy.
+// The channel is closed once the context is done, or when a slow consumer is disconnected.
+func (p *parser) Watch(ctx context.Context, filter EventFilter, opts ...WatchOption) (<-chan Event, error) {
+	if p.events == nil {
+		return nil, ErrWatchNotSupported
+	}
+
+	return p.events.Subscribe(ctx, filter, opts...), nil
+}
+
+// emptyOnNotFound turns a missing list of records into an empty one.
+func emptyOnNotFound(transactions []Transaction, err error) ([]Transaction, error) {
+	if errors.Is(err, ErrNotFound) || (err == nil && transactions == nil) {
+		return []Transaction{}, nil
+	}
+
+	if err != nil {
+		return []Transaction{}, err
+	}
+
+	return transactions, nil
+}
+
+// GetSubscriptionGaps returns the gaps detected in the blocks indexed for the address, the repaired ones included.
+func (p *parser) GetSubscriptionGaps(ctx context.Context, chainID ChainID, address string) ([]Gap, error) {
+	chain, err := p.chain(chainID)
+	if err != nil {
+		return []Gap{}, err
+	}
+
+	addr, err := NewAddress(address)
+	if err != nil {
+		return []Gap{}, err
+	}
+
+	return chain.Repo.GetGaps(ctx, addr)
+}
+
+// VerifySubscription reports the differences between the stored records of the address and the logs of the node,
+// failing with ErrInvalidBlockRange when the range isn't confirmed yet.
+func (p *parser) VerifySubscription(
+	ctx context.Context,
+	chainID ChainID,
+	address string,
+	fromBlock, toBlock uint64,
+	repair bool,
+) (IntegrityReport, error) {
+	chain, err := p.chain(chainID)
+	if err != nil {
+		return IntegrityReport{}, err
+	}
+
+	if chain.Verifier == nil {
+		return IntegrityReport{}, errors.Errorf("no verifier configured for chain %d", chain.ID)
+	}
+
+	addr, err := NewAddress(address)
+	if err != nil {
+		return IntegrityReport{}, err
+	}
+
+	return chain.Verifier.Verify(ctx, addr, fromBlock, toBlock, repair)
+}
@@ -0,0 +1,246 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/mocks"
+)
+
+func Test_parserV2_Subscribe(t *testing.T) {
+	const address = "0x35fa164735182de50811e8e2e824cfb9b6118ac2"
+
+	tests := []struct {
+		name     string
+		chain    domain.ChainID
+		address  string
+		listener func(*testing.T) domain.EventListener
+		wantErr  error
+	}{
+		{
+			name:    "should fail on invalid address",
+			address: "0x123",
+			listener: func(t *testing.T) domain.EventListener {
+				return mocks.NewEventListener(t)
+			},
+			wantErr: domain.ErrInvalidAddress,
+		},
+		{
+			name:    "should fail on invalid address checksum as an invalid address",
+			address: "0x35FA164735182de50811e8e2e824cfb9b6118ac2",
+			listener: func(t *testing.T) domain.EventListener {
+				return mocks.NewEventListener(t)
+			},
+			wantErr: domain.ErrInvalidAddress,
+		},
+		{
+			name:    "should fail on unknown chain as not found",
+			chain:   10,
+			address: address,
+			listener: func(t *testing.T) domain.EventListener {
+				return mocks.NewEventListener(t)
+			},
+			wantErr: domain.ErrNotFound,
+		},
+		{
+			name:    "should fail when already subscribed",
+			address: address,
+			listener: func(t *testing.T) domain.EventListener {
+				listener := mocks.NewEventListener(t)
+				listener.EXPECT().Listen(mock.Anything, domain.Address(address)).
+					Return(domain.ErrAlreadySubscribed).
+					Once()
+				return listener
+			},
+			wantErr: domain.ErrAlreadySubscribed,
+		},
+		{
+			name:    "should fail when the node is unavailable",
+			address: address,
+			listener: func(t *testing.T) domain.EventListener {
+				listener := mocks.NewEventListener(t)
+				listener.EXPECT().Listen(mock.Anything, domain.Address(address)).
+					Return(errors.Wrap(domain.ErrUpstreamUnavailable, "failed to create filter")).
+					Once()
+				return listener
+			},
+			wantErr: domain.ErrUpstreamUnavailable,
+		},
+		{
+			name:    "should subscribe on the default chain",
+			chain:   domain.DefaultChainID,
+			address: address,
+			listener: func(t *testing.T) domain.EventListener {
+				listener := mocks.NewEventListener(t)
+				listener.EXPECT().Listen(mock.Anything, domain.Address(address)).Return(nil).Once()
+				return listener
+			},
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := domain.NewParserV2(mocks.NewRepositoryReader(t), tt.listener(t))
+
+			if err := p.Subscribe(context.Background(), tt.chain, tt.address); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Subscribe() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_parserV2_GetCurrentBlock(t *testing.T) {
+	tests := []struct {
+		name    string
+		repo    func(*testing.T) domain.RepositoryReader
+		want    uint64
+		wantErr error
+	}{
+		{
+			name: "should fail as not found when there's no blocks",
+			repo: func(t *testing.T) domain.RepositoryReader {
+				repo := mocks.NewRepositoryReader(t)
+				repo.EXPECT().GetLatestBlock(mock.Anything).Return(uint64(0), domain.ErrBlockNotFound).Once()
+				return repo
+			},
+			wantErr: domain.ErrNotFound,
+		},
+		{
+			name: "should return the block number without int32 overflow",
+			repo: func(t *testing.T) domain.RepositoryReader {
+				repo := mocks.NewRepositoryReader(t)
+				repo.EXPECT().GetLatestBlock(mock.Anything).Return(uint64(1<<40), nil).Once()
+				return repo
+			},
+			want: 1 << 40,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := domain.NewParserV2(tt.repo(t), nil)
+
+			got, err := p.GetCurrentBlock(context.Background(), 0)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetCurrentBlock() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("GetCurrentBlock() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parserV2_GetTransactionsByHash(t *testing.T) {
+	const hash = "0x3585a53d05d27d622e3c5be8c34b5c4a6ad101929f3efbbac84dfd0129c67cc7"
+
+	tests := []struct {
+		name    string
+		hash    string
+		repo    func(*testing.T) domain.RepositoryReader
+		wantErr error
+	}{
+		{
+			name: "should fail on invalid hash",
+			hash: "0x123",
+			repo: func(t *testing.T) domain.RepositoryReader {
+				return mocks.NewRepositoryReader(t)
+			},
+			wantErr: domain.ErrInvalidHash,
+		},
+		{
+			name: "should fail as not found when the transaction is unknown",
+			hash: hash,
+			repo: func(t *testing.T) domain.RepositoryReader {
+				repo := mocks.NewRepositoryReader(t)
+				repo.EXPECT().GetTransactionsByHash(mock.Anything, hash).
+					Return([]domain.Transaction{}, domain.ErrTransactionNotFound).
+					Once()
+				return repo
+			},
+			wantErr: domain.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := domain.NewParserV2(tt.repo(t), nil)
+
+			if _, err := p.GetTransactionsByHash(context.Background(), 0, tt.hash); !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetTransactionsByHash() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_parserV2_GetSubscription(t *testing.T) {
+	const address = "0x35fa164735182de50811e8e2e824cfb9b6118ac2"
+
+	tests := []struct {
+		name      string
+		chain     domain.ChainID
+		address   string
+		listener  func(*testing.T) domain.EventListener
+		wantState domain.SubscriptionState
+		wantErr   error
+	}{
+		{
+			name:    "should fail on invalid address",
+			address: "0x123",
+			listener: func(t *testing.T) domain.EventListener {
+				return mocks.NewEventListener(t)
+			},
+			wantErr: domain.ErrInvalidAddress,
+		},
+		{
+			name:    "should fail as not found on unknown chain",
+			chain:   10,
+			address: address,
+			listener: func(t *testing.T) domain.EventListener {
+				return mocks.NewEventListener(t)
+			},
+			wantErr: domain.ErrNotFound,
+		},
+		{
+			name:    "should fail as not found when the address was never subscribed",
+			address: address,
+			listener: func(t *testing.T) domain.EventListener {
+				listener := mocks.NewEventListener(t)
+				listener.EXPECT().Subscription(domain.Address(address)).
+					Return(domain.Subscription{}, domain.ErrSubscriptionNotFound).
+					Once()
+				return listener
+			},
+			wantErr: domain.ErrNotFound,
+		},
+		{
+			name:    "should return the subscription state",
+			address: address,
+			listener: func(t *testing.T) domain.EventListener {
+				listener := mocks.NewEventListener(t)
+				listener.EXPECT().Subscription(domain.Address(address)).
+					Return(domain.Subscription{Address: address, State: domain.SubscriptionErrored}, nil).
+					Once()
+				return listener
+			},
+			wantState: domain.SubscriptionErrored,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := domain.NewParserV2(mocks.NewRepositoryReader(t), tt.listener(t))
+
+			got, err := p.GetSubscription(context.Background(), tt.chain, tt.address)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetSubscription() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got.State != tt.wantState {
+				t.Errorf("GetSubscription() state = %v, want %v", got.State, tt.wantState)
+			}
+		})
+	}
+}
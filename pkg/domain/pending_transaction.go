@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type PendingStatus string
+
+const (
+	PendingStatusPending  PendingStatus = "pending"
+	PendingStatusMined    PendingStatus = "mined"
+	PendingStatusDropped  PendingStatus = "dropped"
+	PendingStatusReplaced PendingStatus = "replaced"
+)
+
+// PendingTransaction is a transaction seen in the mempool, tracked until it is mined, dropped or replaced.
+type PendingTransaction struct {
+	ChainID            ChainID       `json:"chainId"`
+	Hash               string        `json:"transactionHash"`
+	Address            Address       `json:"address"`
+	From               Address       `json:"from"`
+	To                 Address       `json:"to,omitempty"`
+	Value              Quantity      `json:"value"`
+	Nonce              int64         `json:"nonce"`
+	Status             PendingStatus `json:"status"`
+	BlockHash          string        `json:"blockHash,omitempty"`
+	DecimalBlockNumber uint64        `json:"decimalBlockNumber,omitempty"`
+	FirstSeenAt        time.Time     `json:"firstSeenAt"`
+	UpdatedAt          time.Time     `json:"updatedAt"`
+}
+
+// NewPendingTransaction creates a transaction as returned by eth_getTransactionByHash,
+// blockNumber and blockHash are empty while the transaction is not mined.
+func NewPendingTransaction(hash, from, to, value, nonce, blockNumber, blockHash string) (PendingTransaction, error) {
+	decimalNonce, err := hexToDecimalString(nonce)
+	if err != nil {
+		return PendingTransaction{}, errors.Wrap(err, "invalid nonce")
+	}
+
+	decimalValue, err := ParseQuantity(value)
+	if err != nil {
+		return PendingTransaction{}, errors.Wrap(err, "invalid value")
+	}
+
+	tx := PendingTransaction{
+		Hash:   hash,
+		From:   HexToAddress(from),
+		To:     HexToAddress(to),
+		Value:  decimalValue,
+		Nonce:  decimalNonce,
+		Status: PendingStatusPending,
+	}
+
+	if blockNumber != "" {
+		tx.DecimalBlockNumber, err = hexToUint64(blockNumber)
+		if err != nil {
+			return PendingTransaction{}, errors.Wrap(err, "invalid block number")
+		}
+
+		tx.BlockHash = blockHash
+		tx.Status = PendingStatusMined
+	}
+
+	return tx, nil
+}
+
+// Touches reports whether the given address is the sender or the receiver of the transaction.
+func (p PendingTransaction) Touches(address Address) bool {
+	return p.From == address || p.To == address
+}
+
+func (p PendingTransaction) IsFinal() bool {
+	return p.Status != PendingStatusPending
+}
+
+// Mined transitions the transaction to mined, in the given block.
+func (p PendingTransaction) Mined(blockNumber uint64, blockHash string, at time.Time) PendingTransaction {
+	p.Status = PendingStatusMined
+	p.DecimalBlockNumber = blockNumber
+	p.BlockHash = blockHash
+	p.UpdatedAt = at
+
+	return p
+}
+
+// Dropped transitions the transaction to dropped, once it's no longer known by the node.
+func (p PendingTransaction) Dropped(at time.Time) PendingTransaction {
+	p.Status = PendingStatusDropped
+	p.UpdatedAt = at
+
+	return p
+}
+
+// Replaced transitions the transaction to replaced, once another transaction with the same nonce is mined.
+func (p PendingTransaction) Replaced(at time.Time) PendingTransaction {
+	p.Status = PendingStatusReplaced
+	p.UpdatedAt = at
+
+	return p
+}
@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewPendingTransaction(t *testing.T) {
+	type args struct {
+		hash        string
+		from        string
+		to          string
+		value       string
+		nonce       string
+		blockNumber string
+		blockHash   string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    PendingTransaction
+		wantErr bool
+	}{
+		{
+			name: "should return a pending transaction",
+			args: args{
+				hash:  "0x1",
+				from:  "0xfrom",
+				to:    "0xto",
+				value: "0x400",
+				nonce: "0x41",
+			},
+			want: PendingTransaction{
+				Hash:   "0x1",
+				From:   "0xfrom",
+				To:     "0xto",
+				Value:  quantity("0x400"),
+				Nonce:  65,
+				Status: PendingStatusPending,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should return a mined transaction",
+			args: args{
+				hash:        "0x1",
+				from:        "0xfrom",
+				to:          "0xto",
+				value:       "0x400",
+				nonce:       "0x41",
+				blockNumber: "0x12a30a0",
+				blockHash:   "0xb1",
+			},
+			want: PendingTransaction{
+				Hash:               "0x1",
+				From:               "0xfrom",
+				To:                 "0xto",
+				Value:              quantity("0x400"),
+				Nonce:              65,
+				Status:             PendingStatusMined,
+				BlockHash:          "0xb1",
+				DecimalBlockNumber: 19542176,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should error on invalid nonce",
+			args: args{
+				nonce: "0x",
+			},
+			want:    PendingTransaction{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewPendingTransaction(
+				tt.args.hash,
+				tt.args.from,
+				tt.args.to,
+				tt.args.value,
+				tt.args.nonce,
+				tt.args.blockNumber,
+				tt.args.blockHash,
+			)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewPendingTransaction() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewPendingTransaction() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPendingTransaction_Transitions(t *testing.T) {
+	var (
+		now     = time.Unix(1024, 0)
+		pending = PendingTransaction{Hash: "0x1", Status: PendingStatusPending}
+	)
+
+	if pending.IsFinal() {
+		t.Errorf("expected pending transaction not to be final")
+	}
+
+	mined := pending.Mined(65, "0xb1", now)
+	if mined.Status != PendingStatusMined || mined.DecimalBlockNumber != 65 || mined.BlockHash != "0xb1" {
+		t.Errorf("unexpected mined transaction: %v", mined)
+	}
+
+	if dropped := pending.Dropped(now); dropped.Status != PendingStatusDropped || !dropped.IsFinal() {
+		t.Errorf("unexpected dropped transaction: %v", dropped)
+	}
+
+	if replaced := pending.Replaced(now); replaced.Status != PendingStatusReplaced || !replaced.UpdatedAt.Equal(now) {
+		t.Errorf("unexpected replaced transaction: %v", replaced)
+	}
+}
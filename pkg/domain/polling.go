@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// SubscriptionPriority orders the polls of the subscriptions on a new head, and bounds how far their polling backs
+// off while no new head appears.
+type SubscriptionPriority string
+
+const (
+	// PriorityHigh subscriptions are polled first and never back off
+	PriorityHigh   SubscriptionPriority = "high"
+	PriorityNormal SubscriptionPriority = "normal"
+	// PriorityLow subscriptions are polled last and back off twice as far as the normal ones
+	PriorityLow SubscriptionPriority = "low"
+)
+
+// priorityRanks is the polling order of the priorities, the highest one coming first.
+var priorityRanks = map[SubscriptionPriority]int{
+	PriorityHigh:   0,
+	PriorityNormal: 1,
+	PriorityLow:    2,
+}
+
+// Rank returns the position of the priority in the polling order, unknown priorities ranking as normal ones.
+func (p SubscriptionPriority) Rank() int {
+	if rank, ok := priorityRanks[p]; ok {
+		return rank
+	}
+
+	return priorityRanks[PriorityNormal]
+}
+
+// SubscribeOptions configures how a subscription is polled.
+type SubscribeOptions struct {
+	// Interval is the minimum time between two polls, the chain pooling time being used when zero
+	Interval time.Duration
+	Priority SubscriptionPriority
+}
+
+type SubscribeOption func(*SubscribeOptions)
+
+// WithPollingInterval polls the address at most once per interval, e.g. to poll a low traffic address less often.
+func WithPollingInterval(interval time.Duration) SubscribeOption {
+	return func(o *SubscribeOptions) {
+		o.Interval = interval
+	}
+}
+
+func WithPriority(priority SubscriptionPriority) SubscribeOption {
+	return func(o *SubscribeOptions) {
+		o.Priority = priority
+	}
+}
+
+// NewSubscribeOptions applies the given options on top of the defaults, failing with ErrInvalidPolling on a negative
+// interval or an unknown priority.
+func NewSubscribeOptions(opts ...SubscribeOption) (SubscribeOptions, error) {
+	options := SubscribeOptions{Priority: PriorityNormal}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if options.Interval < 0 {
+		return SubscribeOptions{}, errors.Wrapf(ErrInvalidPolling, "negative interval %s", options.Interval)
+	}
+
+	if _, ok := priorityRanks[options.Priority]; !ok {
+		return SubscribeOptions{}, errors.Wrapf(ErrInvalidPolling, "unknown priority %q", options.Priority)
+	}
+
+	return options, nil
+}
+
+// SubscriptionPolling is how a subscription is polled: its requested interval and priority, along with the interval
+// measured between its last two polls. Intervals are rendered as duration strings on JSON, e.g. "12s".
+type SubscriptionPolling struct {
+	Interval time.Duration        `json:"interval,omitempty"`
+	Priority SubscriptionPriority `json:"priority"`
+	// EffectiveInterval follows the new heads, and grows while polling backs off
+	EffectiveInterval time.Duration `json:"effectiveInterval,omitempty"`
+	LastPolledAt      time.Time     `json:"lastPolledAt,omitempty"`
+}
+
+// subscriptionPollingJSON is the JSON form of SubscriptionPolling, its intervals being duration strings.
+type subscriptionPollingJSON struct {
+	Interval          string               `json:"interval,omitempty"`
+	Priority          SubscriptionPriority `json:"priority"`
+	EffectiveInterval string               `json:"effectiveInterval,omitempty"`
+	LastPolledAt      time.Time            `json:"lastPolledAt,omitempty"`
+}
+
+func (p SubscriptionPolling) MarshalJSON() ([]byte, error) {
+	return json.Marshal(subscriptionPollingJSON{
+		Interval:          durationString(p.Interval),
+		Priority:          p.Priority,
+		EffectiveInterval: durationString(p.EffectiveInterval),
+		LastPolledAt:      p.LastPolledAt,
+	})
+}
+
+func (p *SubscriptionPolling) UnmarshalJSON(data []byte) error {
+	var v subscriptionPollingJSON
+
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	interval, err := parseDuration(v.Interval)
+	if err != nil {
+		return errors.Wrap(err, "invalid interval")
+	}
+
+	effectiveInterval, err := parseDuration(v.EffectiveInterval)
+	if err != nil {
+		return errors.Wrap(err, "invalid effective interval")
+	}
+
+	*p = SubscriptionPolling{
+		Interval:          interval,
+		Priority:          v.Priority,
+		EffectiveInterval: effectiveInterval,
+		LastPolledAt:      v.LastPolledAt,
+	}
+
+	return nil
+}
+
+func durationString(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+
+	return d.String()
+}
+
+func parseDuration(v string) (time.Duration, error) {
+	if v == "" {
+		return 0, nil
+	}
+
+	return time.ParseDuration(v)
+}
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewSubscribeOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []SubscribeOption
+		want    SubscribeOptions
+		wantErr error
+	}{
+		{
+			name: "should default to the normal priority",
+			want: SubscribeOptions{Priority: PriorityNormal},
+		},
+		{
+			name: "should apply the options",
+			opts: []SubscribeOption{WithPollingInterval(time.Minute), WithPriority(PriorityLow)},
+			want: SubscribeOptions{Interval: time.Minute, Priority: PriorityLow},
+		},
+		{
+			name:    "should refuse a negative interval",
+			opts:    []SubscribeOption{WithPollingInterval(-time.Second)},
+			wantErr: ErrInvalidPolling,
+		},
+		{
+			name:    "should refuse an unknown priority",
+			opts:    []SubscribeOption{WithPriority("urgent")},
+			wantErr: ErrInvalidPolling,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewSubscribeOptions(tt.opts...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewSubscribeOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("NewSubscribeOptions() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubscriptionPolling_JSON(t *testing.T) {
+	polling := SubscriptionPolling{
+		Interval:          12 * time.Second,
+		Priority:          PriorityHigh,
+		EffectiveInterval: 1500 * time.Millisecond,
+		LastPolledAt:      time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	data, err := json.Marshal(polling)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	want := `{"interval":"12s","priority":"high","effectiveInterval":"1.5s","lastPolledAt":"2024-01-02T03:04:05Z"}`
+
+	if string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+
+	var got SubscriptionPolling
+
+	if err = json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	if got != polling {
+		t.Errorf("json.Unmarshal() = %+v, want %+v", got, polling)
+	}
+}
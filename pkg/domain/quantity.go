@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+// maxQuantityNibbles bounds quantities to 256 bits, the size of an EVM word.
+const maxQuantityNibbles = 64
+
+// Quantity is an arbitrary-precision Ethereum quantity, such as a wei amount. It's rendered as a hex string on JSON,
+// e.g. "0x1bc16d674ec80000", and its zero value is 0.
+type Quantity struct {
+	v *big.Int
+}
+
+func NewQuantity(v *big.Int) Quantity {
+	if v == nil {
+		return Quantity{}
+	}
+
+	return Quantity{v: new(big.Int).Set(v)}
+}
+
+func QuantityFromUint64(v uint64) Quantity {
+	return Quantity{v: new(big.Int).SetUint64(v)}
+}
+
+// ParseQuantity decodes a 0x prefixed hex quantity of up to 256 bits, without leading zero digits.
+func ParseQuantity(input string) (Quantity, error) {
+	raw, err := checkNumber(input)
+	if err != nil {
+		return Quantity{}, err
+	}
+
+	if len(raw) > maxQuantityNibbles {
+		return Quantity{}, errors.New("hex number > 256 bits")
+	}
+
+	for i := 0; i < len(raw); i++ {
+		if decodeNibble(raw[i]) == badNibble {
+			return Quantity{}, errors.New("invalid hex string")
+		}
+	}
+
+	v, _ := new(big.Int).SetString(raw, 16)
+
+	return Quantity{v: v}, nil
+}
+
+// Big returns a copy of the quantity.
+func (q Quantity) Big() *big.Int {
+	if q.v == nil {
+		return new(big.Int)
+	}
+
+	return new(big.Int).Set(q.v)
+}
+
+func (q Quantity) Sign() int {
+	if q.v == nil {
+		return 0
+	}
+
+	return q.v.Sign()
+}
+
+// Uint64 returns the quantity as an uint64, reporting whether it fits.
+func (q Quantity) Uint64() (uint64, bool) {
+	if q.v == nil {
+		return 0, true
+	}
+
+	return q.v.Uint64(), q.v.IsUint64()
+}
+
+func (q Quantity) Cmp(other Quantity) int {
+	return q.Big().Cmp(other.Big())
+}
+
+// String returns the hex form of the quantity.
+func (q Quantity) String() string {
+	if q.v == nil {
+		return "0x0"
+	}
+
+	return "0x" + q.v.Text(16)
+}
+
+func (q Quantity) MarshalJSON() ([]byte, error) {
+	return json.Marshal(q.String())
+}
+
+func (q *Quantity) UnmarshalJSON(data []byte) error {
+	var v string
+
+	if err := json.Unmarshal(data, &v); err != nil {
+		return errors.Wrap(err, "quantity must be a hex string")
+	}
+
+	parsed, err := ParseQuantity(v)
+	if err != nil {
+		return err
+	}
+
+	*q = parsed
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+)
+
+func quantity(v string) Quantity {
+	q, err := ParseQuantity(v)
+	if err != nil {
+		panic(err)
+	}
+
+	return q
+}
+
+func TestParseQuantity(t *testing.T) {
+	maxUint256, _ := new(big.Int).SetString("ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", 16)
+
+	tests := []struct {
+		name    string
+		input   string
+		want    *big.Int
+		wantErr bool
+	}{
+		{name: "should decode zero", input: "0x0", want: big.NewInt(0)},
+		{name: "should decode 1 ether in wei", input: "0xde0b6b3a7640000", want: big.NewInt(1e18)},
+		{
+			name:  "should decode a quantity over 64 bits",
+			input: "0x10000000000000000",
+			want:  new(big.Int).Lsh(big.NewInt(1), 64),
+		},
+		{name: "should decode up to 256 bits", input: "0x" + maxUint256.Text(16), want: maxUint256},
+		{name: "should refuse over 256 bits", input: "0x1" + maxUint256.Text(16), wantErr: true},
+		{name: "should refuse a missing prefix", input: "400", wantErr: true},
+		{name: "should refuse leading zero digits", input: "0x0400", wantErr: true},
+		{name: "should refuse a sign", input: "0x-1", wantErr: true},
+		{name: "should refuse an empty quantity", input: "0x", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseQuantity(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseQuantity() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err == nil && got.Big().Cmp(tt.want) != 0 {
+				t.Errorf("ParseQuantity() = %v, want %v", got.Big(), tt.want)
+			}
+		})
+	}
+}
+
+func TestQuantity_JSON(t *testing.T) {
+	var v struct {
+		Value Quantity `json:"value"`
+	}
+
+	if err := json.Unmarshal([]byte(`{"value":"0x10000000000000000"}`), &v); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	if string(data) != `{"value":"0x10000000000000000"}` {
+		t.Errorf("Marshal() = %s", data)
+	}
+
+	if err = json.Unmarshal([]byte(`{"value":1}`), &v); err == nil {
+		t.Errorf("expected error unmarshalling a number")
+	}
+
+	if data, _ = json.Marshal(Quantity{}); string(data) != `"0x0"` {
+		t.Errorf("expected the zero value to be 0x0, got: %s", data)
+	}
+}
+
+// FuzzParseQuantity checks the quantity decoding agrees with the hexutil one, which refuses the large values.
+func FuzzParseQuantity(f *testing.F) {
+	for _, seed := range []string{"0x0", "0x41", "0x400", "0x0400", "0x", "", "0xg", "0x-1", "0X1f", "0x8000000000000000", "0xffffffffffffffffff"} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		q, err := ParseQuantity(input)
+		legacy, legacyErr := hexToDecimalString(input)
+
+		// hexToDecimalString refuses the quantities overflowing an int64
+		if (err == nil && q.Big().IsInt64()) != (legacyErr == nil) {
+			t.Fatalf("ParseQuantity(%q) error = %v, hexToDecimalString() error = %v", input, err, legacyErr)
+		}
+
+		if err != nil {
+			return
+		}
+
+		if legacyErr == nil && q.Big().Int64() != legacy {
+			t.Fatalf("ParseQuantity(%q) = %v, hexToDecimalString() = %v", input, q.Big(), legacy)
+		}
+
+		roundTrip, err := ParseQuantity(q.String())
+		if err != nil || roundTrip.Cmp(q) != 0 {
+			t.Fatalf("ParseQuantity(%q) doesn't round trip: %v, %v", q.String(), roundTrip, err)
+		}
+
+		v, err := hexToUint64(input)
+		if fits := q.Big().IsUint64(); fits != (err == nil) {
+			t.Fatalf("hexToUint64(%q) error = %v, fits in 64 bits = %v", input, err, fits)
+		}
+
+		if err == nil && v != q.Big().Uint64() {
+			t.Fatalf("hexToUint64(%q) = %v, want %v", input, v, q.Big())
+		}
+	})
+}
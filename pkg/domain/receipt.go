@@ -0,0 +1,63 @@
+package domain
+
+import "github.com/pkg/errors"
+
+type ReceiptStatus string
+
+const (
+	ReceiptStatusSuccess  ReceiptStatus = "success"
+	ReceiptStatusReverted ReceiptStatus = "reverted"
+	ReceiptStatusUnknown  ReceiptStatus = "unknown"
+)
+
+type Receipt struct {
+	TransactionHash   string        `json:"transactionHash"`
+	Status            ReceiptStatus `json:"status"`
+	GasUsed           Quantity      `json:"gasUsed"`
+	CumulativeGasUsed Quantity      `json:"cumulativeGasUsed"`
+	EffectiveGasPrice Quantity      `json:"effectiveGasPrice"`
+	ContractAddress   string        `json:"contractAddress,omitempty"`
+}
+
+func NewReceipt(hash, status, gasUsed, cumulativeGasUsed, effectiveGasPrice, contractAddress string) (Receipt, error) {
+	decimalGasUsed, err := ParseQuantity(gasUsed)
+	if err != nil {
+		return Receipt{}, errors.Wrap(err, "invalid gas used")
+	}
+
+	decimalCumulativeGasUsed, err := ParseQuantity(cumulativeGasUsed)
+	if err != nil {
+		return Receipt{}, errors.Wrap(err, "invalid cumulative gas used")
+	}
+
+	var decimalEffectiveGasPrice Quantity
+
+	// effectiveGasPrice is only returned by post London nodes
+	if effectiveGasPrice != "" {
+		decimalEffectiveGasPrice, err = ParseQuantity(effectiveGasPrice)
+		if err != nil {
+			return Receipt{}, errors.Wrap(err, "invalid effective gas price")
+		}
+	}
+
+	return Receipt{
+		TransactionHash:   hash,
+		Status:            receiptStatusFromHex(status),
+		GasUsed:           decimalGasUsed,
+		CumulativeGasUsed: decimalCumulativeGasUsed,
+		EffectiveGasPrice: decimalEffectiveGasPrice,
+		ContractAddress:   contractAddress,
+	}, nil
+}
+
+// receiptStatusFromHex maps the EIP-658 status field, pre Byzantium receipts have no status at all.
+func receiptStatusFromHex(status string) ReceiptStatus {
+	switch status {
+	case "0x1":
+		return ReceiptStatusSuccess
+	case "0x0":
+		return ReceiptStatusReverted
+	default:
+		return ReceiptStatusUnknown
+	}
+}
@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestNewReceipt(t *testing.T) {
+	type args struct {
+		hash              string
+		status            string
+		gasUsed           string
+		cumulativeGasUsed string
+		effectiveGasPrice string
+		contractAddress   string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    Receipt
+		wantErr bool
+	}{
+		{
+			name: "should return a successful receipt",
+			args: args{
+				hash:              "0x3585a53d05d27d622e3c5be8c34b5c4a6ad101929f3efbbac84dfd0129c67cc7",
+				status:            "0x1",
+				gasUsed:           "0x5208",
+				cumulativeGasUsed: "0x1a3c7e",
+				effectiveGasPrice: "0x3b9aca00",
+			},
+			want: Receipt{
+				TransactionHash:   "0x3585a53d05d27d622e3c5be8c34b5c4a6ad101929f3efbbac84dfd0129c67cc7",
+				Status:            ReceiptStatusSuccess,
+				GasUsed:           QuantityFromUint64(21000),
+				CumulativeGasUsed: QuantityFromUint64(1719422),
+				EffectiveGasPrice: QuantityFromUint64(1000000000),
+			},
+			wantErr: false,
+		},
+		{
+			name: "should return a reverted receipt with the created contract address",
+			args: args{
+				hash:              "0x1",
+				status:            "0x0",
+				gasUsed:           "0x400",
+				cumulativeGasUsed: "0x400",
+				effectiveGasPrice: "0x41",
+				contractAddress:   "0x35fa164735182de50811e8e2e824cfb9b6118ac2",
+			},
+			want: Receipt{
+				TransactionHash:   "0x1",
+				Status:            ReceiptStatusReverted,
+				GasUsed:           QuantityFromUint64(1024),
+				CumulativeGasUsed: QuantityFromUint64(1024),
+				EffectiveGasPrice: QuantityFromUint64(65),
+				ContractAddress:   "0x35fa164735182de50811e8e2e824cfb9b6118ac2",
+			},
+			wantErr: false,
+		},
+		{
+			name: "should return unknown status when the node does not report it",
+			args: args{
+				hash:              "0x1",
+				gasUsed:           "0x400",
+				cumulativeGasUsed: "0x400",
+			},
+			want: Receipt{
+				TransactionHash:   "0x1",
+				Status:            ReceiptStatusUnknown,
+				GasUsed:           QuantityFromUint64(1024),
+				CumulativeGasUsed: QuantityFromUint64(1024),
+			},
+			wantErr: false,
+		},
+		{
+			name: "should keep an effective gas price overflowing 64 bits",
+			args: args{
+				hash:              "0x1",
+				status:            "0x1",
+				gasUsed:           "0x400",
+				cumulativeGasUsed: "0x400",
+				effectiveGasPrice: "0x10000000000000000",
+			},
+			want: Receipt{
+				TransactionHash:   "0x1",
+				Status:            ReceiptStatusSuccess,
+				GasUsed:           QuantityFromUint64(1024),
+				CumulativeGasUsed: QuantityFromUint64(1024),
+				EffectiveGasPrice: NewQuantity(new(big.Int).Lsh(big.NewInt(1), 64)),
+			},
+			wantErr: false,
+		},
+		{
+			name: "should error on invalid gas used",
+			args: args{
+				gasUsed:           "0x",
+				cumulativeGasUsed: "0x400",
+			},
+			want:    Receipt{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewReceipt(
+				tt.args.hash,
+				tt.args.status,
+				tt.args.gasUsed,
+				tt.args.cumulativeGasUsed,
+				tt.args.effectiveGasPrice,
+				tt.args.contractAddress,
+			)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewReceipt() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewReceipt() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type SubscriptionState string
+
+const (
+	// SubscriptionPending is the state of a subscription whose filter is being installed
+	SubscriptionPending SubscriptionState = "pending"
+	// SubscriptionBackfilling is the state of a subscription catching up with past blocks
+	SubscriptionBackfilling SubscriptionState = "backfilling"
+	SubscriptionActive      SubscriptionState = "active"
+	SubscriptionPaused      SubscriptionState = "paused"
+	// SubscriptionErrored is the state of a subscription whose last poll failed, it's retried on the next one
+	SubscriptionErrored SubscriptionState = "errored"
+	SubscriptionStopped SubscriptionState = "stopped"
+)
+
+// subscriptionTransitions lists the states each state can move to, a stopped subscription being final.
+var subscriptionTransitions = map[SubscriptionState][]SubscriptionState{
+	SubscriptionPending:     {SubscriptionBackfilling, SubscriptionActive, SubscriptionErrored, SubscriptionStopped},
+	SubscriptionBackfilling: {SubscriptionActive, SubscriptionPaused, SubscriptionErrored, SubscriptionStopped},
+	SubscriptionActive:      {SubscriptionBackfilling, SubscriptionPaused, SubscriptionErrored, SubscriptionStopped},
+	SubscriptionPaused:      {SubscriptionBackfilling, SubscriptionActive, SubscriptionStopped},
+	SubscriptionErrored:     {SubscriptionBackfilling, SubscriptionActive, SubscriptionPaused, SubscriptionStopped},
+	SubscriptionStopped:     {},
+}
+
+// CanTransition tells whether a subscription in the state can move to the given one.
+func (s SubscriptionState) CanTransition(to SubscriptionState) bool {
+	for _, v := range subscriptionTransitions[s] {
+		if v == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Subscription is the lifecycle of a subscribed address, along with when each state was last entered.
+type Subscription struct {
+	ChainID    ChainID                         `json:"chainId"`
+	Address    Address                         `json:"address"`
+	State      SubscriptionState               `json:"state"`
+	StateTimes map[SubscriptionState]time.Time `json:"stateTimes"`
+	// LastBlock is the last block whose records are indexed, a resumed subscription catching up from the next one
+	LastBlock   uint64    `json:"lastBlock,omitempty"`
+	LastError   string    `json:"lastError,omitempty"`
+	LastErrorAt time.Time `json:"lastErrorAt,omitempty"`
+	// Rescan is the progress of the last rescan of the address, if any
+	Rescan *BackfillProgress `json:"rescan,omitempty"`
+	// Polling is how the address is polled
+	Polling   SubscriptionPolling `json:"polling"`
+	CreatedAt time.Time           `json:"createdAt"`
+	UpdatedAt time.Time           `json:"updatedAt"`
+}
+
+func NewSubscription(chainID ChainID, address Address, now time.Time) Subscription {
+	return Subscription{
+		ChainID:    chainID,
+		Address:    address,
+		State:      SubscriptionPending,
+		StateTimes: map[SubscriptionState]time.Time{SubscriptionPending: now},
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
+
+// Transition moves the subscription to the given state, failing with ErrInvalidTransition when it's not allowed.
+// Moving to the current state is a no-op.
+func (s *Subscription) Transition(to SubscriptionState, now time.Time) error {
+	if s.State == to {
+		return nil
+	}
+
+	if !s.State.CanTransition(to) {
+		return errors.Wrapf(ErrInvalidTransition, "from %s to %s", s.State, to)
+	}
+
+	s.State = to
+	s.StateTimes[to] = now
+	s.UpdatedAt = now
+
+	return nil
+}
+
+// Fail moves the subscription to the errored state, recording the error.
+func (s *Subscription) Fail(err error, now time.Time) error {
+	if err := s.Transition(SubscriptionErrored, now); err != nil {
+		return err
+	}
+
+	s.LastError = err.Error()
+	s.LastErrorAt = now
+
+	return nil
+}
+
+// IsStopped tells whether the subscription reached its final state.
+func (s Subscription) IsStopped() bool {
+	return s.State == SubscriptionStopped
+}
+
+// Clone returns a copy of the subscription not sharing its state times nor its rescan progress.
+func (s Subscription) Clone() Subscription {
+	times := make(map[SubscriptionState]time.Time, len(s.StateTimes))
+
+	for state, at := range s.StateTimes {
+		times[state] = at
+	}
+
+	s.StateTimes = times
+
+	if s.Rescan != nil {
+		rescan := *s.Rescan
+		s.Rescan = &rescan
+	}
+
+	return s
+}
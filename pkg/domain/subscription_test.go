@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSubscription_Transition(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    []SubscriptionState
+		to      SubscriptionState
+		wantErr error
+	}{
+		{name: "should activate a pending subscription", to: SubscriptionActive},
+		{
+			name: "should backfill then activate a subscription",
+			path: []SubscriptionState{SubscriptionBackfilling},
+			to:   SubscriptionActive,
+		},
+		{
+			name: "should resume a paused subscription",
+			path: []SubscriptionState{SubscriptionActive, SubscriptionPaused},
+			to:   SubscriptionActive,
+		},
+		{
+			name: "should recover an errored subscription",
+			path: []SubscriptionState{SubscriptionActive, SubscriptionErrored},
+			to:   SubscriptionActive,
+		},
+		{
+			name: "should be a no-op to move to the current state",
+			path: []SubscriptionState{SubscriptionActive},
+			to:   SubscriptionActive,
+		},
+		{
+			name:    "should refuse to pause a pending subscription",
+			to:      SubscriptionPaused,
+			wantErr: ErrInvalidTransition,
+		},
+		{
+			name:    "should refuse to restart a stopped subscription",
+			path:    []SubscriptionState{SubscriptionActive, SubscriptionStopped},
+			to:      SubscriptionActive,
+			wantErr: ErrInvalidTransition,
+		},
+		{
+			name:    "should refuse to error a paused subscription",
+			path:    []SubscriptionState{SubscriptionActive, SubscriptionPaused},
+			to:      SubscriptionErrored,
+			wantErr: ErrInvalidTransition,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				createdAt = time.Unix(1000, 0)
+				now       = time.Unix(2000, 0)
+				s         = NewSubscription(DefaultChainID, "0x123", createdAt)
+			)
+
+			for _, state := range tt.path {
+				if err := s.Transition(state, createdAt); err != nil {
+					t.Fatalf("Transition(%s) error = %v", state, err)
+				}
+			}
+
+			from := s.State
+
+			err := s.Transition(tt.to, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Transition() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			switch {
+			case err != nil && s.State != from:
+				t.Errorf("State = %s, want %s unchanged", s.State, from)
+			case err == nil && from != tt.to && (s.State != tt.to || !s.StateTimes[tt.to].Equal(now) || !s.UpdatedAt.Equal(now)):
+				t.Errorf("Transition() = %+v, want %s at %v", s, tt.to, now)
+			}
+		})
+	}
+}
+
+func TestSubscription_Fail(t *testing.T) {
+	var (
+		now = time.Unix(2000, 0)
+		s   = NewSubscription(DefaultChainID, "0x123", time.Unix(1000, 0))
+	)
+
+	_ = s.Transition(SubscriptionActive, now)
+
+	if err := s.Fail(errors.New("node unavailable"), now); err != nil {
+		t.Fatalf("Fail() error = %v", err)
+	}
+
+	if s.State != SubscriptionErrored || s.LastError != "node unavailable" || !s.LastErrorAt.Equal(now) {
+		t.Errorf("Fail() = %+v", s)
+	}
+
+	// the clone doesn't share the state times
+	clone := s.Clone()
+	_ = s.Transition(SubscriptionStopped, now)
+
+	if _, ok := clone.StateTimes[SubscriptionStopped]; ok {
+		t.Errorf("Clone() shares the state times")
+	}
+
+	if err := s.Fail(errors.New("node unavailable"), now); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("Fail() error = %v, want %v once stopped", err, ErrInvalidTransition)
+	}
+}
@@ -1,26 +1,168 @@
 package domain
 
-import "github.com/pkg/errors"
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var hashRegex = regexp.MustCompile("^0x[0-9a-fA-F]{64}$")
+
+// topicAddressLength is the length of a 32 bytes topic holding an indexed address, e.g. on ERC-20 transfers.
+const topicAddressLength = 66
+
+type TransactionType string
+
+const (
+	TransactionTypeLog      TransactionType = "log"
+	TransactionTypeInternal TransactionType = "internal"
+)
 
 type Transaction struct {
-	Hash               string `json:"transactionHash"`
-	Address            string `json:"address"`
-	BlockNumber        string `json:"blockNumber"`
-	BlockHash          string `json:"blockHash"`
-	DecimalBlockNumber int64  `json:"decimalBlockNumber"`
+	ChainID            ChainID         `json:"chainId"`
+	Type               TransactionType `json:"type"`
+	Hash               string          `json:"transactionHash"`
+	Address            Address         `json:"address"`
+	BlockNumber        string          `json:"blockNumber"`
+	BlockHash          string          `json:"blockHash"`
+	DecimalBlockNumber uint64          `json:"decimalBlockNumber"`
+	LogIndex           int64           `json:"logIndex"`
+	Topics             []string        `json:"topics,omitempty"`
+	Timestamp          int64           `json:"timestamp,omitempty"`
+	BaseFeePerGas      *Quantity       `json:"baseFeePerGas,omitempty"`
+	Miner              Address         `json:"miner,omitempty"`
+	Receipt            *Receipt        `json:"receipt,omitempty"`
+	// Removed is set on logs removed by a chain reorganisation
+	Removed bool `json:"removed,omitempty"`
+
+	// fields only present on internal transactions
+	From         Address   `json:"from,omitempty"`
+	To           Address   `json:"to,omitempty"`
+	Value        *Quantity `json:"value,omitempty"`
+	TraceAddress string    `json:"traceAddress,omitempty"`
 }
 
 func NewTransaction(hash, address, blockNumber, blockHash string) (Transaction, error) {
-	decimalBlockNumber, err := hexToDecimalString(blockNumber)
+	decimalBlockNumber, err := hexToUint64(blockNumber)
 	if err != nil {
 		return Transaction{}, errors.Wrap(err, "invalid block number")
 	}
 
 	return Transaction{
+		Type:               TransactionTypeLog,
 		Hash:               hash,
-		Address:            address,
+		Address:            HexToAddress(address),
 		BlockNumber:        blockNumber,
 		BlockHash:          blockHash,
 		DecimalBlockNumber: decimalBlockNumber,
 	}, nil
 }
+
+// NewHash validates a transaction hash, returning its lowercase form.
+func NewHash(v string) (string, error) {
+	if !hashRegex.MatchString(v) {
+		return "", ErrInvalidHash
+	}
+
+	return strings.ToLower(v), nil
+}
+
+// NewInternalTransaction creates the record of a value transferring internal call involving the given address.
+func NewInternalTransaction(address Address, call InternalCall) Transaction {
+	return Transaction{
+		Type:               TransactionTypeInternal,
+		Hash:               call.TransactionHash,
+		Address:            address,
+		BlockNumber:        toHexQuantity(call.BlockNumber),
+		BlockHash:          call.BlockHash,
+		DecimalBlockNumber: call.BlockNumber,
+		From:               HexToAddress(call.From),
+		To:                 HexToAddress(call.To),
+		Value:              &call.Value,
+		TraceAddress:       call.traceAddressString(),
+	}
+}
+
+// Key identifies the record, a single transaction may produce many log records, told apart by their index within
+// the block, and many internal ones, told apart by their trace address. Trace addresses are marked as such, the
+// trace address "0" not to collide with the log index 0.
+func (t Transaction) Key() string {
+	if t.Type == TransactionTypeInternal {
+		return t.Hash + "/trace/" + t.TraceAddress
+	}
+
+	return t.Hash + "/" + strconv.FormatInt(t.LogIndex, 10)
+}
+
+// WithLog returns a copy of the log record holding its position within the block and its topics.
+func (t Transaction) WithLog(logIndex string, topics []string) (Transaction, error) {
+	index, err := hexToDecimalString(logIndex)
+	if err != nil {
+		return Transaction{}, errors.Wrap(err, "invalid log index")
+	}
+
+	t.LogIndex = index
+	t.Topics = topics
+
+	return t, nil
+}
+
+// Contract returns the contract emitting the log, or the one called by the internal transaction.
+func (t Transaction) Contract() Address {
+	if t.Type == TransactionTypeInternal {
+		return t.To
+	}
+
+	return t.Address
+}
+
+// Topic0 returns the event signature of the log, empty for internal transactions.
+func (t Transaction) Topic0() string {
+	if len(t.Topics) == 0 {
+		return ""
+	}
+
+	return strings.ToLower(t.Topics[0])
+}
+
+// Direction tells whether the record moves value into or out of the given address. Logs are read as transfers,
+// the first two indexed topics holding the sender and the recipient. It's empty when the address is neither of them.
+func (t Transaction) Direction(address Address) Direction {
+	from, to := t.From, t.To
+
+	if t.Type != TransactionTypeInternal {
+		if len(t.Topics) < 3 {
+			return ""
+		}
+
+		from, to = topicToAddress(t.Topics[1]), topicToAddress(t.Topics[2])
+	}
+
+	switch address {
+	case to:
+		return DirectionIn
+	case from:
+		return DirectionOut
+	default:
+		return ""
+	}
+}
+
+// WithBlockHeader returns a copy of the transaction holding the data of the block it was included in.
+func (t Transaction) WithBlockHeader(header BlockHeader) Transaction {
+	t.Timestamp = header.Timestamp
+	t.BaseFeePerGas = header.BaseFeePerGas
+	t.Miner = header.Miner
+
+	return t
+}
+
+func topicToAddress(topic string) Address {
+	if len(topic) != topicAddressLength {
+		return ""
+	}
+
+	return HexToAddress("0x" + topic[topicAddressLength-40:])
+}
@@ -0,0 +1,212 @@
+package domain
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	DefaultQueryLimit = 100
+	MaxQueryLimit     = 1000
+)
+
+type SortOrder string
+
+const (
+	SortAscending  SortOrder = "asc"
+	SortDescending SortOrder = "desc"
+)
+
+// Direction tells whether a record moves value into or out of an address.
+type Direction string
+
+const (
+	DirectionIn  Direction = "in"
+	DirectionOut Direction = "out"
+)
+
+// TransactionQuery selects a page of the records of an address, ordered by block number and log index.
+// Zero values mean the filter is not applied.
+type TransactionQuery struct {
+	Address   Address
+	FromBlock uint64
+	ToBlock   uint64
+	// FromTime and ToTime bound the time the blocks of the records were produced at, resolved into a block range
+	FromTime time.Time
+	ToTime   time.Time
+	// Contract matches the contract emitting a log, or the one called by an internal transaction
+	Contract  Address
+	Topic0    string
+	Direction Direction
+	Order     SortOrder
+	// Cursor is the NextCursor of the previous page, empty for the first one
+	Cursor string
+	Limit  int
+}
+
+// TransactionPage is a page of records, NextCursor being empty on the last one.
+type TransactionPage struct {
+	Transactions []Transaction `json:"transactions"`
+	NextCursor   string        `json:"nextCursor,omitempty"`
+}
+
+// Normalize validates the query, applying the default order and limit.
+func (q TransactionQuery) Normalize() (TransactionQuery, error) {
+	if q.ToBlock > 0 && q.FromBlock > q.ToBlock {
+		return q, errors.Wrap(ErrInvalidQuery, "invalid block range")
+	}
+
+	if !q.FromTime.IsZero() && !q.ToTime.IsZero() && q.FromTime.After(q.ToTime) {
+		return q, errors.Wrap(ErrInvalidQuery, "invalid time range")
+	}
+
+	switch q.Order {
+	case "":
+		q.Order = SortAscending
+	case SortAscending, SortDescending:
+	default:
+		return q, errors.Wrapf(ErrInvalidQuery, "invalid order %s", q.Order)
+	}
+
+	switch q.Direction {
+	case "", DirectionIn, DirectionOut:
+	default:
+		return q, errors.Wrapf(ErrInvalidQuery, "invalid direction %s", q.Direction)
+	}
+
+	switch {
+	case q.Limit < 0 || q.Limit > MaxQueryLimit:
+		return q, errors.Wrapf(ErrInvalidQuery, "limit must be within [1, %d]", MaxQueryLimit)
+	case q.Limit == 0:
+		q.Limit = DefaultQueryLimit
+	}
+
+	if q.Cursor != "" {
+		if _, err := DecodeCursor(q.Cursor); err != nil {
+			return q, err
+		}
+	}
+
+	q.Topic0 = strings.ToLower(q.Topic0)
+
+	return q, nil
+}
+
+// HasTimeRange tells whether the query is bounded in time.
+func (q TransactionQuery) HasTimeRange() bool {
+	return !q.FromTime.IsZero() || !q.ToTime.IsZero()
+}
+
+// WithinBlocks narrows the block range of the query to [fromBlock, toBlock], telling whether any block is left.
+func (q TransactionQuery) WithinBlocks(fromBlock, toBlock uint64) (TransactionQuery, bool) {
+	q.FromBlock = max(q.FromBlock, fromBlock)
+
+	if q.ToBlock == 0 || q.ToBlock > toBlock {
+		q.ToBlock = toBlock
+	}
+
+	return q, q.FromBlock <= q.ToBlock
+}
+
+// Matches tells whether the record passes the filters of the query, the cursor aside.
+func (q TransactionQuery) Matches(t Transaction) bool {
+	if t.DecimalBlockNumber < q.FromBlock || (q.ToBlock > 0 && t.DecimalBlockNumber > q.ToBlock) {
+		return false
+	}
+
+	if !q.Contract.IsZero() && t.Contract() != q.Contract {
+		return false
+	}
+
+	if q.Topic0 != "" && t.Topic0() != q.Topic0 {
+		return false
+	}
+
+	if q.Direction != "" && t.Direction(q.Address) != q.Direction {
+		return false
+	}
+
+	return true
+}
+
+// After tells whether the record comes after the cursor position, in the order of the query.
+func (q TransactionQuery) After(t Transaction, cursor Cursor) bool {
+	if q.Order == SortDescending {
+		return cursor.Compare(t) > 0
+	}
+
+	return cursor.Compare(t) < 0
+}
+
+// Cursor is the position of a record in the block number and log index order.
+type Cursor struct {
+	BlockNumber uint64
+	LogIndex    int64
+	Key         string
+}
+
+func CursorOf(t Transaction) Cursor {
+	return Cursor{BlockNumber: t.DecimalBlockNumber, LogIndex: t.LogIndex, Key: t.Key()}
+}
+
+// Compare returns -1, 0 or 1 whether the cursor is before, at or after the record.
+func (c Cursor) Compare(t Transaction) int {
+	switch {
+	case c.BlockNumber != t.DecimalBlockNumber:
+		return compare(c.BlockNumber, t.DecimalBlockNumber)
+	case c.LogIndex != t.LogIndex:
+		return compare(c.LogIndex, t.LogIndex)
+	default:
+		return strings.Compare(c.Key, t.Key())
+	}
+}
+
+// Encode returns the opaque form of the cursor, as handed to clients.
+func (c Cursor) Encode() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%d:%s", c.BlockNumber, c.LogIndex, c.Key)))
+}
+
+func DecodeCursor(v string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(v)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	parts := strings.SplitN(string(raw), ":", 3)
+	if len(parts) != 3 {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	blockNumber, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	logIndex, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	return Cursor{BlockNumber: blockNumber, LogIndex: logIndex, Key: parts[2]}, nil
+}
+
+// LessTransaction orders records by block number, log index and key.
+func LessTransaction(a, b Transaction) bool {
+	return CursorOf(a).Compare(b) < 0
+}
+
+func compare[T int64 | uint64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
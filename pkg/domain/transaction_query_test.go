@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTransactionQuery_Normalize(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   TransactionQuery
+		want    TransactionQuery
+		wantErr error
+	}{
+		{
+			name:  "should apply the default order and limit",
+			query: TransactionQuery{Topic0: "0xDDF2"},
+			want:  TransactionQuery{Topic0: "0xddf2", Order: SortAscending, Limit: DefaultQueryLimit},
+		},
+		{
+			name:    "should refuse an inverted block range",
+			query:   TransactionQuery{FromBlock: 10, ToBlock: 5},
+			wantErr: ErrInvalidQuery,
+		},
+		{
+			name:    "should refuse an inverted time range",
+			query:   TransactionQuery{FromTime: time.Unix(200, 0), ToTime: time.Unix(100, 0)},
+			wantErr: ErrInvalidQuery,
+		},
+		{
+			name:    "should refuse an unknown order",
+			query:   TransactionQuery{Order: "random"},
+			wantErr: ErrInvalidQuery,
+		},
+		{
+			name:    "should refuse an unknown direction",
+			query:   TransactionQuery{Direction: "sideways"},
+			wantErr: ErrInvalidQuery,
+		},
+		{
+			name:    "should refuse a limit above the maximum",
+			query:   TransactionQuery{Limit: MaxQueryLimit + 1},
+			wantErr: ErrInvalidQuery,
+		},
+		{
+			name:    "should refuse a malformed cursor",
+			query:   TransactionQuery{Cursor: "not a cursor"},
+			wantErr: ErrInvalidCursor,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.query.Normalize()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Normalize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err == nil && got != tt.want {
+				t.Errorf("Normalize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCursor_Encode(t *testing.T) {
+	var (
+		transaction = Transaction{Hash: "0xabc", DecimalBlockNumber: 10, LogIndex: 3}
+		cursor      = CursorOf(transaction)
+	)
+
+	got, err := DecodeCursor(cursor.Encode())
+	if err != nil {
+		t.Fatalf("DecodeCursor() error = %v", err)
+	}
+
+	if got != cursor {
+		t.Errorf("DecodeCursor() = %v, want %v", got, cursor)
+	}
+
+	if got.Compare(transaction) != 0 {
+		t.Errorf("Compare() = %v, want 0", got.Compare(transaction))
+	}
+
+	if got.Compare(Transaction{Hash: "0x0", DecimalBlockNumber: 10, LogIndex: 4}) != -1 {
+		t.Errorf("expected the cursor to be before a later log of the same block")
+	}
+}
+
+func TestTransaction_Direction(t *testing.T) {
+	const transferTopic = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+
+	var (
+		sender    = Address("0x35fa164735182de50811e8e2e824cfb9b6118ac2")
+		recipient = Address("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed")
+		topic     = func(a Address) string {
+			return "0x000000000000000000000000" + a.String()[2:]
+		}
+	)
+
+	tests := []struct {
+		name        string
+		transaction Transaction
+		address     Address
+		want        Direction
+	}{
+		{
+			name: "should read an outgoing transfer log",
+			transaction: Transaction{
+				Type:   TransactionTypeLog,
+				Topics: []string{transferTopic, topic(sender), topic(recipient)},
+			},
+			address: sender,
+			want:    DirectionOut,
+		},
+		{
+			name: "should read an incoming transfer log",
+			transaction: Transaction{
+				Type:   TransactionTypeLog,
+				Topics: []string{transferTopic, topic(sender), topic(recipient)},
+			},
+			address: recipient,
+			want:    DirectionIn,
+		},
+		{
+			name:        "should have no direction on logs without indexed parties",
+			transaction: Transaction{Type: TransactionTypeLog, Topics: []string{transferTopic}},
+			address:     sender,
+			want:        "",
+		},
+		{
+			name:        "should read an incoming internal transaction",
+			transaction: Transaction{Type: TransactionTypeInternal, From: sender, To: recipient},
+			address:     recipient,
+			want:        DirectionIn,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.transaction.Direction(tt.address); got != tt.want {
+				t.Errorf("Direction() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
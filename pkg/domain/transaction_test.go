@@ -27,6 +27,7 @@ func TestNewTransaction(t *testing.T) {
 				blockHash:   "0xacfdbd8d63fbb7cdea95b2d85be04ee5ecd8eef222a2861e246b8454bcf3952c",
 			},
 			want: Transaction{
+				Type:               TransactionTypeLog,
 				Hash:               "0x3585a53d05d27d622e3c5be8c34b5c4a6ad101929f3efbbac84dfd0129c67cc7",
 				Address:            "0x35fa164735182de50811e8e2e824cfb9b6118ac2",
 				BlockNumber:        "0x12a30a0",
@@ -59,3 +60,70 @@ func TestNewTransaction(t *testing.T) {
 		})
 	}
 }
+
+func TestNewInternalTransaction(t *testing.T) {
+	call := InternalCall{
+		TransactionHash: "0x3585a53d05d27d622e3c5be8c34b5c4a6ad101929f3efbbac84dfd0129c67cc7",
+		BlockNumber:     19542176,
+		BlockHash:       "0xacfdbd8d63fbb7cdea95b2d85be04ee5ecd8eef222a2861e246b8454bcf3952c",
+		CallType:        "call",
+		From:            "0x7a250d5630b4cf539739df2c5dacb4c659f2488d",
+		To:              "0x35fa164735182de50811e8e2e824cfb9b6118ac2",
+		Value:           quantity("0xde0b6b3a7640000"),
+		TraceAddress:    []int{0, 2},
+	}
+
+	value := quantity("0xde0b6b3a7640000")
+
+	want := Transaction{
+		Type:               TransactionTypeInternal,
+		Hash:               "0x3585a53d05d27d622e3c5be8c34b5c4a6ad101929f3efbbac84dfd0129c67cc7",
+		Address:            "0x35fa164735182de50811e8e2e824cfb9b6118ac2",
+		BlockNumber:        "0x12a30a0",
+		BlockHash:          "0xacfdbd8d63fbb7cdea95b2d85be04ee5ecd8eef222a2861e246b8454bcf3952c",
+		DecimalBlockNumber: 19542176,
+		From:               "0x7a250d5630b4cf539739df2c5dacb4c659f2488d",
+		To:                 "0x35fa164735182de50811e8e2e824cfb9b6118ac2",
+		Value:              &value,
+		TraceAddress:       "0_2",
+	}
+
+	got := NewInternalTransaction("0x35fa164735182de50811e8e2e824cfb9b6118ac2", call)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewInternalTransaction() got = %v, want %v", got, want)
+	}
+
+	if got.Key() == (Transaction{Hash: got.Hash, Type: TransactionTypeLog}).Key() {
+		t.Errorf("Key() of internal transactions must not collide with the log of the same transaction")
+	}
+
+	top := NewInternalTransaction(got.Address, InternalCall{TransactionHash: call.TransactionHash, TraceAddress: []int{0}})
+	if top.Key() == (Transaction{Hash: got.Hash, Type: TransactionTypeLog, LogIndex: 0}).Key() {
+		t.Errorf("Key() of the trace address 0 must not collide with the log index 0 of the same transaction")
+	}
+
+	first, second := Transaction{Hash: got.Hash, LogIndex: 3}, Transaction{Hash: got.Hash, LogIndex: 4}
+	if first.Key() == second.Key() {
+		t.Errorf("Key() of the logs of the same transaction must not collide")
+	}
+}
+
+func TestInternalCall_TransfersValue(t *testing.T) {
+	tests := []struct {
+		name string
+		call InternalCall
+		want bool
+	}{
+		{name: "call with value", call: InternalCall{CallType: "call", Value: quantity("0x1")}, want: true},
+		{name: "call without value", call: InternalCall{CallType: "call", Value: quantity("0x0")}, want: false},
+		{name: "delegate call", call: InternalCall{CallType: "delegatecall", Value: quantity("0x1")}, want: false},
+		{name: "static call", call: InternalCall{CallType: "STATICCALL", Value: quantity("0x1")}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.call.TransfersValue(); got != tt.want {
+				t.Errorf("TransfersValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
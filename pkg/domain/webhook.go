@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type WebhookEventType string
+
+const WebhookEventTransactionsAdded WebhookEventType = "transactions.added"
+
+type DeliveryStatus string
+
+const (
+	DeliveryStatusPending   DeliveryStatus = "pending"
+	DeliveryStatusDelivered DeliveryStatus = "delivered"
+	DeliveryStatusDead      DeliveryStatus = "dead"
+)
+
+// Webhook is the URL notified of the new activity of a subscribed address on a chain.
+// Its payloads are signed with HMAC-SHA256 using the secret, which is never exposed.
+type Webhook struct {
+	ChainID   ChainID   `json:"chainId"`
+	Address   Address   `json:"address"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"-"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func NewWebhook(chainID ChainID, address Address, rawURL, secret string) (Webhook, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return Webhook{}, errors.Wrap(ErrInvalidWebhookURL, err.Error())
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return Webhook{}, errors.Wrapf(ErrInvalidWebhookURL, "%s", rawURL)
+	}
+
+	if secret == "" {
+		return Webhook{}, ErrMissingWebhookSecret
+	}
+
+	return Webhook{
+		ChainID:   chainID,
+		Address:   address,
+		URL:       rawURL,
+		Secret:    secret,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// WebhookEvent is the payload POSTed to a webhook.
+type WebhookEvent struct {
+	ID           string           `json:"id"`
+	Type         WebhookEventType `json:"type"`
+	ChainID      ChainID          `json:"chainId"`
+	Address      Address          `json:"address"`
+	Transactions []Transaction    `json:"transactions"`
+	CreatedAt    time.Time        `json:"createdAt"`
+}
+
+// DeliveryAttempt records a single POST of an event to a webhook.
+type DeliveryAttempt struct {
+	At         time.Time     `json:"at"`
+	StatusCode int           `json:"statusCode,omitempty"`
+	Error      string        `json:"error,omitempty"`
+	Duration   time.Duration `json:"duration"`
+}
+
+func (a DeliveryAttempt) Succeeded() bool {
+	return a.Error == "" && a.StatusCode >= 200 && a.StatusCode < 300
+}
+
+// WebhookDelivery tracks the delivery of an event to a webhook, it goes dead once its attempts are exhausted.
+type WebhookDelivery struct {
+	ID        string            `json:"id"`
+	URL       string            `json:"url"`
+	Event     WebhookEvent      `json:"event"`
+	Status    DeliveryStatus    `json:"status"`
+	Attempts  []DeliveryAttempt `json:"attempts"`
+	CreatedAt time.Time         `json:"createdAt"`
+	UpdatedAt time.Time         `json:"updatedAt"`
+	// ReplayedAt is when the delivery was last replayed, earlier attempts don't count towards the limit
+	ReplayedAt time.Time `json:"replayedAt,omitempty"`
+}
+
+// Record appends the attempt, marking the delivery delivered on success or dead once maxAttempts is reached.
+func (d *WebhookDelivery) Record(attempt DeliveryAttempt, maxAttempts int) {
+	d.Attempts = append(d.Attempts, attempt)
+	d.UpdatedAt = attempt.At
+
+	switch {
+	case attempt.Succeeded():
+		d.Status = DeliveryStatusDelivered
+	case d.attemptsSinceReplay() >= maxAttempts:
+		d.Status = DeliveryStatusDead
+	default:
+		d.Status = DeliveryStatusPending
+	}
+}
+
+// Replay schedules the delivery again, with a fresh set of attempts.
+func (d *WebhookDelivery) Replay(at time.Time) {
+	d.Status = DeliveryStatusPending
+	d.ReplayedAt = at
+	d.UpdatedAt = at
+}
+
+func (d *WebhookDelivery) attemptsSinceReplay() int {
+	var count int
+
+	for _, v := range d.Attempts {
+		if !v.At.Before(d.ReplayedAt) {
+			count++
+		}
+	}
+
+	return count
+}
@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewWebhook(t *testing.T) {
+	type args struct {
+		url    string
+		secret string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "should create a webhook",
+			args: args{url: "https://example.com/hooks", secret: "s3cr3t"},
+		},
+		{
+			name:    "should error on a non http URL",
+			args:    args{url: "ftp://example.com/hooks", secret: "s3cr3t"},
+			wantErr: ErrInvalidWebhookURL,
+		},
+		{
+			name:    "should error on a relative URL",
+			args:    args{url: "/hooks", secret: "s3cr3t"},
+			wantErr: ErrInvalidWebhookURL,
+		},
+		{
+			name:    "should error without secret",
+			args:    args{url: "https://example.com/hooks"},
+			wantErr: ErrMissingWebhookSecret,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewWebhook(8453, "0x123", tt.args.url, tt.args.secret)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewWebhook() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (got.URL != tt.args.url || got.Secret != tt.args.secret) {
+				t.Errorf("NewWebhook() = %v", got)
+			}
+			if err == nil && (got.Address != "0x123" || got.ChainID != 8453) {
+				t.Errorf("NewWebhook() = %v", got)
+			}
+		})
+	}
+}
+
+func TestWebhookDelivery_Record(t *testing.T) {
+	var (
+		now     = time.Unix(1711724987, 0)
+		failed  = DeliveryAttempt{At: now, StatusCode: 500, Error: "unexpected status code 500"}
+		timeout = DeliveryAttempt{At: now, Error: "context deadline exceeded"}
+		success = DeliveryAttempt{At: now, StatusCode: 204}
+	)
+
+	tests := []struct {
+		name     string
+		attempts []DeliveryAttempt
+		want     DeliveryStatus
+	}{
+		{
+			name:     "should keep pending while attempts are left",
+			attempts: []DeliveryAttempt{failed, timeout},
+			want:     DeliveryStatusPending,
+		},
+		{
+			name:     "should be delivered on success",
+			attempts: []DeliveryAttempt{failed, success},
+			want:     DeliveryStatusDelivered,
+		},
+		{
+			name:     "should go dead once attempts are exhausted",
+			attempts: []DeliveryAttempt{failed, timeout, failed},
+			want:     DeliveryStatusDead,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &WebhookDelivery{Status: DeliveryStatusPending}
+
+			for _, attempt := range tt.attempts {
+				d.Record(attempt, 3)
+			}
+
+			if d.Status != tt.want {
+				t.Errorf("Record() status = %v, want %v", d.Status, tt.want)
+			}
+			if len(d.Attempts) != len(tt.attempts) {
+				t.Errorf("Record() attempts = %v, want %v", len(d.Attempts), len(tt.attempts))
+			}
+		})
+	}
+}
+
+func TestWebhookDelivery_Replay(t *testing.T) {
+	var (
+		now    = time.Unix(1711724987, 0)
+		failed = DeliveryAttempt{At: now, StatusCode: 500, Error: "unexpected status code 500"}
+	)
+
+	d := &WebhookDelivery{Status: DeliveryStatusPending}
+	d.Record(failed, 1)
+
+	if d.Status != DeliveryStatusDead {
+		t.Fatalf("Record() status = %v, want %v", d.Status, DeliveryStatusDead)
+	}
+
+	d.Replay(now.Add(time.Minute))
+
+	if d.Status != DeliveryStatusPending {
+		t.Fatalf("Replay() status = %v, want %v", d.Status, DeliveryStatusPending)
+	}
+
+	// attempts made before the replay don't count towards the limit
+	d.Record(DeliveryAttempt{At: now.Add(time.Minute), StatusCode: 200}, 1)
+
+	if d.Status != DeliveryStatusDelivered {
+		t.Errorf("Record() status = %v, want %v", d.Status, DeliveryStatusDelivered)
+	}
+	if len(d.Attempts) != 2 {
+		t.Errorf("Record() attempts = %v, want 2", len(d.Attempts))
+	}
+}
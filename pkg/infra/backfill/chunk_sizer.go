@@ -0,0 +1,59 @@
+package backfill
+
+import (
+	"sync"
+)
+
+// chunkSizer adapts the number of blocks of the chunks to the node: the size is shrunk on ErrTooManyResults, and
+// grown back once chunks are fetched in a row.
+type chunkSizer struct {
+	mu        sync.Mutex
+	current   uint64
+	maxSize   uint64
+	successes int
+}
+
+func newChunkSizer(size, maxSize uint64) *chunkSizer {
+	return &chunkSizer{current: size, maxSize: maxSize}
+}
+
+func (s *chunkSizer) size() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.current
+}
+
+// shrink halves the size of the chunk refused by the node, unless a smaller size is already used.
+func (s *chunkSizer) shrink(refused uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.successes = 0
+
+	if half := refused / 2; half < s.current {
+		s.current = half
+	}
+
+	if s.current == 0 {
+		s.current = 1
+	}
+}
+
+func (s *chunkSizer) grow() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.successes++
+
+	if s.successes < chunksBeforeGrowing {
+		return
+	}
+
+	s.successes = 0
+	s.current *= 2
+
+	if s.current > s.maxSize {
+		s.current = s.maxSize
+	}
+}
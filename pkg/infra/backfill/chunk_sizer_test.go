@@ -0,0 +1,31 @@
+package backfill
+
+import (
+	"testing"
+)
+
+func TestChunkSizer(t *testing.T) {
+	sizer := newChunkSizer(100, 400)
+
+	// shrinking below the size already used
+	sizer.shrink(100)
+	sizer.shrink(100)
+
+	if got := sizer.size(); got != 50 {
+		t.Fatalf("size() = %d, want 50", got)
+	}
+
+	for i := 0; i < chunksBeforeGrowing*4; i++ {
+		sizer.grow()
+	}
+
+	if got := sizer.size(); got != 400 {
+		t.Fatalf("size() = %d, want the max size", got)
+	}
+
+	sizer.shrink(1)
+
+	if got := sizer.size(); got != 1 {
+		t.Fatalf("size() = %d, want a single block", got)
+	}
+}
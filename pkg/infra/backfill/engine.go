@@ -0,0 +1,420 @@
+package backfill
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+const (
+	defaultWorkers      = 4
+	defaultChunkSize    = 1000
+	defaultMaxChunkSize = 10_000
+	// chunksBeforeGrowing is the number of chunks fetched in a row before the chunk size is doubled
+	chunksBeforeGrowing = 8
+)
+
+type LogsAPI interface {
+	GetLogs(ctx context.Context, address domain.Address, fromBlock, toBlock uint64) ([]domain.Transaction, error)
+}
+
+// RangeWriter stores the records fetched for a block range, superseding the stored ones of those blocks.
+type RangeWriter interface {
+	ReplaceBlockRange(
+		ctx context.Context,
+		address domain.Address,
+		fromBlock, toBlock uint64,
+		transactions []domain.Transaction,
+	) error
+}
+
+// CheckpointStore persists the last block written by each job, e.g. into a file for the jobs to resume after a restart.
+type CheckpointStore interface {
+	GetBackfillCheckpoint(ctx context.Context, job string) (domain.BackfillCheckpoint, error)
+	// GetBackfillCheckpoints returns the checkpoints of every unfinished job, keyed by job
+	GetBackfillCheckpoints(ctx context.Context) (map[string]domain.BackfillCheckpoint, error)
+	SaveBackfillCheckpoint(ctx context.Context, job string, checkpoint domain.BackfillCheckpoint) error
+	DeleteBackfillCheckpoint(ctx context.Context, job string) error
+}
+
+type Options func(*Engine)
+
+func WithConfig(cfg *Config) Options {
+	return func(e *Engine) {
+		e.cfg = cfg
+	}
+}
+
+func WithLogger(l *slog.Logger) Options {
+	return func(e *Engine) {
+		e.logger = l
+	}
+}
+
+// WithCheckpoints persists the progress of the jobs, so that they resume after a restart.
+func WithCheckpoints(store CheckpointStore) Options {
+	return func(e *Engine) {
+		e.checkpoints = store
+	}
+}
+
+type Config struct {
+	// Workers is the number of chunks fetched concurrently
+	Workers int
+	// ChunkSize is the initial number of blocks of a chunk, halved down to a single block while the node returns
+	// ErrTooManyResults, and doubled up to MaxChunkSize as chunks are fetched
+	ChunkSize    uint64
+	MaxChunkSize uint64
+}
+
+// Job backfills the blocks [FromBlock, ToBlock] of an address.
+type Job struct {
+	// ID keys the checkpoint of the job, so it must be stable across restarts
+	ID        string
+	Address   domain.Address
+	FromBlock uint64
+	ToBlock   uint64
+	// OnProgress, if set, is called after every chunk written and once the job is finished
+	OnProgress func(domain.BackfillProgress)
+}
+
+// Engine backfills block ranges: chunks are fetched concurrently by a bounded pool of workers, and written in block
+// order so that the checkpoint of a job is always the last block of a contiguous range written.
+type Engine struct {
+	logger      *slog.Logger
+	cfg         *Config
+	api         LogsAPI
+	checkpoints CheckpointStore
+}
+
+func NewEngine(api LogsAPI, opts ...Options) *Engine {
+	e := &Engine{
+		logger: slog.Default(),
+		cfg: &Config{
+			Workers:      defaultWorkers,
+			ChunkSize:    defaultChunkSize,
+			MaxChunkSize: defaultMaxChunkSize,
+		},
+		api: api,
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	if e.cfg.Workers <= 0 {
+		e.cfg.Workers = defaultWorkers
+	}
+
+	if e.cfg.ChunkSize == 0 {
+		e.cfg.ChunkSize = defaultChunkSize
+	}
+
+	if e.cfg.MaxChunkSize < e.cfg.ChunkSize {
+		e.cfg.MaxChunkSize = e.cfg.ChunkSize
+	}
+
+	return e
+}
+
+// chunk is a block range, seq being its position within the job.
+type chunk struct {
+	seq      int
+	from, to uint64
+}
+
+type chunkResult struct {
+	chunk
+	transactions []domain.Transaction
+	err          error
+}
+
+// Run backfills the job through the writer, resuming after its checkpoint. It stops on the first chunk failing,
+// the chunks before it being written and checkpointed, and deletes the checkpoint once the job is done.
+func (e *Engine) Run(ctx context.Context, job Job, writer RangeWriter) error {
+	progress := newProgressTracker(job, time.Now())
+
+	if job.FromBlock > job.ToBlock {
+		err := errors.Wrapf(domain.ErrInvalidBlockRange, "from %d to %d", job.FromBlock, job.ToBlock)
+		return e.finish(ctx, job, progress, err)
+	}
+
+	start, err := e.resumeFrom(ctx, job)
+	if err != nil {
+		return e.finish(ctx, job, progress, err)
+	}
+
+	if start > job.FromBlock {
+		e.logger.Info("Resuming backfill", "job", job.ID, "from_block", start, "to_block", job.ToBlock)
+		progress.resume(start-1, time.Now())
+	}
+
+	if start > job.ToBlock {
+		return e.finish(ctx, job, progress, nil)
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		sizer   = newChunkSizer(e.cfg.ChunkSize, e.cfg.MaxChunkSize)
+		chunks  = make(chan chunk)
+		results = make(chan chunkResult)
+		// slots bounds the chunks fetched but not written yet, which are buffered to be written in order
+		slots = make(chan struct{}, e.cfg.Workers*2)
+		wg    sync.WaitGroup
+	)
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+		e.dispatch(runCtx, start, job.ToBlock, sizer, slots, chunks)
+	}()
+
+	for i := 0; i < e.cfg.Workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for c := range chunks {
+				transactions, err := e.fetch(runCtx, job.Address, c.from, c.to, sizer)
+
+				select {
+				case results <- chunkResult{chunk: c, transactions: transactions, err: err}:
+				case <-runCtx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	err = e.write(runCtx, job, writer, results, slots, progress)
+
+	// stops the dispatcher and the workers still running after a failure
+	cancel()
+	wg.Wait()
+
+	return e.finish(ctx, job, progress, err)
+}
+
+// resumeFrom returns the first block to backfill, following the checkpoint of a previous run of the job.
+func (e *Engine) resumeFrom(ctx context.Context, job Job) (uint64, error) {
+	if e.checkpoints == nil || job.ID == "" {
+		return job.FromBlock, nil
+	}
+
+	checkpoint, err := e.checkpoints.GetBackfillCheckpoint(ctx, job.ID)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get checkpoint")
+	}
+
+	// a checkpoint of another range is stale
+	if checkpoint.FromBlock != job.FromBlock || checkpoint.NextBlock <= job.FromBlock {
+		return job.FromBlock, nil
+	}
+
+	return checkpoint.NextBlock, nil
+}
+
+// Unfinished returns the jobs whose ID starts with the prefix and which were interrupted before their end, e.g. by a
+// restart, to be run again from their checkpoint. Their OnProgress is left to the caller.
+func (e *Engine) Unfinished(ctx context.Context, prefix string) ([]Job, error) {
+	if e.checkpoints == nil {
+		return nil, nil
+	}
+
+	checkpoints, err := e.checkpoints.GetBackfillCheckpoints(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get checkpoints")
+	}
+
+	var jobs []Job
+
+	for id, checkpoint := range checkpoints {
+		if !strings.HasPrefix(id, prefix) || checkpoint.NextBlock > checkpoint.ToBlock {
+			continue
+		}
+
+		jobs = append(jobs, Job{
+			ID:        id,
+			Address:   checkpoint.Address,
+			FromBlock: checkpoint.FromBlock,
+			ToBlock:   checkpoint.ToBlock,
+		})
+	}
+
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].ID < jobs[j].ID
+	})
+
+	return jobs, nil
+}
+
+// dispatch splits [from, to] into chunks of the current size, as long as a slot is available.
+func (e *Engine) dispatch(
+	ctx context.Context,
+	from, to uint64,
+	sizer *chunkSizer,
+	slots chan struct{},
+	chunks chan<- chunk,
+) {
+	defer close(chunks)
+
+	for seq := 0; ; seq++ {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+
+		c := chunk{seq: seq, from: from, to: to}
+		if size := sizer.size(); to-from >= size {
+			c.to = from + size - 1
+		}
+
+		select {
+		case chunks <- c:
+		case <-ctx.Done():
+			return
+		}
+
+		if c.to == to {
+			return
+		}
+
+		from = c.to + 1
+	}
+}
+
+// fetch returns the logs of [from, to], splitting the range in halves while the node returns too many results.
+func (e *Engine) fetch(
+	ctx context.Context,
+	address domain.Address,
+	from, to uint64,
+	sizer *chunkSizer,
+) ([]domain.Transaction, error) {
+	transactions, err := e.api.GetLogs(ctx, address, from, to)
+
+	switch {
+	case err == nil:
+		sizer.grow()
+		return transactions, nil
+	case !errors.Is(err, domain.ErrTooManyResults) || from == to:
+		return nil, errors.Wrapf(err, "failed to fetch logs from %d to %d", from, to)
+	}
+
+	sizer.shrink(to - from + 1)
+
+	e.logger.Debug("Splitting chunk", "address", address, "from_block", from, "to_block", to, "error", err)
+
+	mid := from + (to-from)/2
+
+	left, err := e.fetch(ctx, address, from, mid, sizer)
+	if err != nil {
+		return nil, err
+	}
+
+	right, err := e.fetch(ctx, address, mid+1, to, sizer)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(left, right...), nil
+}
+
+// write writes the fetched chunks in order, buffering the ones fetched ahead, and checkpoints each of them.
+func (e *Engine) write(
+	ctx context.Context,
+	job Job,
+	writer RangeWriter,
+	results <-chan chunkResult,
+	slots <-chan struct{},
+	progress *progressTracker,
+) error {
+	var (
+		next    int
+		pending = make(map[int]chunkResult)
+	)
+
+	for result := range results {
+		pending[result.seq] = result
+
+		for {
+			c, ok := pending[next]
+			if !ok {
+				break
+			}
+
+			if c.err != nil {
+				return c.err
+			}
+
+			if err := writer.ReplaceBlockRange(ctx, job.Address, c.from, c.to, c.transactions); err != nil {
+				return errors.Wrapf(err, "failed to write blocks from %d to %d", c.from, c.to)
+			}
+
+			if err := e.checkpoint(ctx, job, c.to); err != nil {
+				return err
+			}
+
+			delete(pending, next)
+			next++
+			<-slots
+
+			if job.OnProgress != nil {
+				job.OnProgress(progress.advance(c.to, len(c.transactions), time.Now()))
+			}
+		}
+	}
+
+	// the results are closed early when the context is done
+	return ctx.Err()
+}
+
+func (e *Engine) checkpoint(ctx context.Context, job Job, lastBlock uint64) error {
+	if e.checkpoints == nil || job.ID == "" {
+		return nil
+	}
+
+	checkpoint := domain.BackfillCheckpoint{
+		Address:   job.Address,
+		FromBlock: job.FromBlock,
+		NextBlock: lastBlock + 1,
+		ToBlock:   job.ToBlock,
+	}
+
+	if err := e.checkpoints.SaveBackfillCheckpoint(ctx, job.ID, checkpoint); err != nil {
+		return errors.Wrap(err, "failed to save checkpoint")
+	}
+
+	return nil
+}
+
+// finish reports the end of the job, deleting its checkpoint once it's done.
+func (e *Engine) finish(ctx context.Context, job Job, progress *progressTracker, err error) error {
+	if err == nil && e.checkpoints != nil && job.ID != "" {
+		if deleteErr := e.checkpoints.DeleteBackfillCheckpoint(ctx, job.ID); deleteErr != nil {
+			e.logger.Warn("Failed to delete checkpoint", "error", deleteErr, "job", job.ID)
+		}
+	}
+
+	if job.OnProgress != nil {
+		job.OnProgress(progress.finish(err, time.Now()))
+	}
+
+	return err
+}
@@ -0,0 +1,256 @@
+package backfill
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/mocks"
+)
+
+const address = domain.Address("0x123")
+
+// writtenRange is a range written by the engine, along with its records.
+type writtenRange struct {
+	from, to uint64
+	records  int
+}
+
+// recordWrites returns a writer appending the ranges written, which are written by a single goroutine.
+func recordWrites(t *testing.T, written *[]writtenRange) *mocks.RangeWriter {
+	writer := mocks.NewRangeWriter(t)
+	writer.EXPECT().ReplaceBlockRange(mock.Anything, address, mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ domain.Address, from, to uint64, records []domain.Transaction) error {
+			*written = append(*written, writtenRange{from: from, to: to, records: len(records)})
+			return nil
+		}).
+		Maybe()
+
+	return writer
+}
+
+// logsOf returns a record per block of [from, to].
+func logsOf(from, to uint64) []domain.Transaction {
+	var transactions []domain.Transaction
+
+	for block := from; block <= to; block++ {
+		transactions = append(transactions, domain.Transaction{DecimalBlockNumber: block})
+	}
+
+	return transactions
+}
+
+func TestEngine_Run(t *testing.T) {
+	var (
+		logger   = slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))
+		written  []writtenRange
+		progress []domain.BackfillProgress
+	)
+
+	api := mocks.NewLogsAPI(t)
+	api.EXPECT().GetLogs(mock.Anything, address, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ domain.Address, from, to uint64) ([]domain.Transaction, error) {
+			// the first chunks are the slowest, so that they're fetched after the next ones
+			time.Sleep(time.Duration(150-from) * time.Millisecond / 10)
+			return logsOf(from, to), nil
+		})
+
+	checkpoints := mocks.NewCheckpointStore(t)
+	checkpoints.EXPECT().GetBackfillCheckpoint(mock.Anything, "job").Return(domain.BackfillCheckpoint{}, nil).Once()
+	checkpoints.EXPECT().SaveBackfillCheckpoint(mock.Anything, "job", mock.Anything).Return(nil).Times(5)
+	checkpoints.EXPECT().DeleteBackfillCheckpoint(mock.Anything, "job").Return(nil).Once()
+
+	e := NewEngine(
+		api,
+		WithLogger(logger),
+		WithCheckpoints(checkpoints),
+		WithConfig(&Config{Workers: 4, ChunkSize: 10}),
+	)
+
+	job := Job{
+		ID:        "job",
+		Address:   address,
+		FromBlock: 100,
+		ToBlock:   149,
+		OnProgress: func(p domain.BackfillProgress) {
+			progress = append(progress, p)
+		},
+	}
+
+	if err := e.Run(context.Background(), job, recordWrites(t, &written)); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	// the chunks are written in block order, whatever order they're fetched in
+	for i, w := range written {
+		if want := (writtenRange{from: 100 + uint64(i)*10, to: 109 + uint64(i)*10, records: 10}); w != want {
+			t.Fatalf("written[%d] = %+v, want %+v", i, w, want)
+		}
+	}
+
+	if len(written) != 5 || len(progress) != 6 {
+		t.Fatalf("written %d chunks and reported %d progress, want 5 and 6", len(written), len(progress))
+	}
+
+	if got := progress[2]; got.ScannedBlock != 129 || got.Records != 30 || got.BlocksPerSecond == 0 || !got.Running() {
+		t.Errorf("progress = %+v, want 30 records scanned up to block 129", got)
+	}
+
+	if got := progress[5]; got.ScannedBlock != 149 || got.Records != 50 || got.Running() || got.Error != "" {
+		t.Errorf("progress = %+v, want finished at block 149", got)
+	}
+}
+
+func TestEngine_RunAdaptsChunkSize(t *testing.T) {
+	var (
+		logger  = slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))
+		written []writtenRange
+	)
+
+	// the node refuses ranges of more than 4 blocks
+	api := mocks.NewLogsAPI(t)
+	api.EXPECT().GetLogs(mock.Anything, address, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ domain.Address, from, to uint64) ([]domain.Transaction, error) {
+			if to-from+1 > 4 {
+				return nil, errors.Wrap(domain.ErrTooManyResults, "query returned more than 10000 results")
+			}
+			return logsOf(from, to), nil
+		})
+
+	e := NewEngine(api, WithLogger(logger), WithConfig(&Config{Workers: 1, ChunkSize: 16}))
+
+	job := Job{Address: address, FromBlock: 0, ToBlock: 39}
+
+	if err := e.Run(context.Background(), job, recordWrites(t, &written)); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	var next uint64
+
+	for _, w := range written {
+		if w.from != next || w.records != int(w.to-w.from+1) {
+			t.Fatalf("written = %+v, want contiguous ranges from block 0", written)
+		}
+
+		next = w.to + 1
+	}
+
+	if next != 40 {
+		t.Fatalf("written = %+v, want every block up to 39", written)
+	}
+
+	// the chunks following the first split are dispatched with the shrunk size
+	if last := written[len(written)-1]; last.to-last.from+1 > 8 {
+		t.Errorf("written = %+v, want the chunk size shrunk", written)
+	}
+}
+
+func TestEngine_RunResumesFromCheckpoint(t *testing.T) {
+	var (
+		logger   = slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))
+		written  []writtenRange
+		progress domain.BackfillProgress
+	)
+
+	api := mocks.NewLogsAPI(t)
+	api.EXPECT().GetLogs(mock.Anything, address, uint64(126), uint64(135)).Return(logsOf(126, 135), nil).Once()
+	api.EXPECT().GetLogs(mock.Anything, address, uint64(136), uint64(139)).Return(logsOf(136, 139), nil).Once()
+
+	checkpoints := mocks.NewCheckpointStore(t)
+	checkpoints.EXPECT().GetBackfillCheckpoint(mock.Anything, "job").
+		Return(domain.BackfillCheckpoint{FromBlock: 100, NextBlock: 126}, nil).
+		Once()
+	for _, next := range []uint64{136, 140} {
+		checkpoint := domain.BackfillCheckpoint{Address: address, FromBlock: 100, NextBlock: next, ToBlock: 139}
+		checkpoints.EXPECT().SaveBackfillCheckpoint(mock.Anything, "job", checkpoint).Return(nil).Once()
+	}
+	checkpoints.EXPECT().DeleteBackfillCheckpoint(mock.Anything, "job").Return(nil).Once()
+
+	e := NewEngine(
+		api,
+		WithLogger(logger),
+		WithCheckpoints(checkpoints),
+		WithConfig(&Config{Workers: 2, ChunkSize: 10}),
+	)
+
+	job := Job{
+		ID:        "job",
+		Address:   address,
+		FromBlock: 100,
+		ToBlock:   139,
+		OnProgress: func(p domain.BackfillProgress) {
+			progress = p
+		},
+	}
+
+	if err := e.Run(context.Background(), job, recordWrites(t, &written)); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if len(written) != 2 || written[0].from != 126 {
+		t.Errorf("written = %+v, want the blocks after the checkpoint", written)
+	}
+
+	if progress.FromBlock != 100 || progress.ScannedBlock != 139 || progress.Records != 14 {
+		t.Errorf("progress = %+v, want the records written since resuming", progress)
+	}
+}
+
+func TestEngine_RunFailure(t *testing.T) {
+	var (
+		logger   = slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))
+		written  []writtenRange
+		progress domain.BackfillProgress
+	)
+
+	api := mocks.NewLogsAPI(t)
+	api.EXPECT().GetLogs(mock.Anything, address, uint64(0), uint64(9)).Return(logsOf(0, 9), nil).Once()
+	api.EXPECT().GetLogs(mock.Anything, address, uint64(10), uint64(19)).
+		Return(nil, errors.Wrap(domain.ErrUpstreamUnavailable, "unexpected status code 503")).
+		Once()
+	api.EXPECT().GetLogs(mock.Anything, address, uint64(20), uint64(29)).Return(logsOf(20, 29), nil).Maybe()
+
+	// the checkpoint is kept, for the job to resume after the last chunk written
+	checkpoints := mocks.NewCheckpointStore(t)
+	checkpoints.EXPECT().GetBackfillCheckpoint(mock.Anything, "job").Return(domain.BackfillCheckpoint{}, nil).Once()
+	checkpoint := domain.BackfillCheckpoint{Address: address, NextBlock: 10, ToBlock: 29}
+	checkpoints.EXPECT().SaveBackfillCheckpoint(mock.Anything, "job", checkpoint).
+		Return(nil).
+		Once()
+
+	e := NewEngine(
+		api,
+		WithLogger(logger),
+		WithCheckpoints(checkpoints),
+		WithConfig(&Config{Workers: 3, ChunkSize: 10}),
+	)
+
+	job := Job{
+		ID:        "job",
+		Address:   address,
+		FromBlock: 0,
+		ToBlock:   29,
+		OnProgress: func(p domain.BackfillProgress) {
+			progress = p
+		},
+	}
+
+	err := e.Run(context.Background(), job, recordWrites(t, &written))
+	if !errors.Is(err, domain.ErrUpstreamUnavailable) {
+		t.Fatalf("Run() error = %v, want %v", err, domain.ErrUpstreamUnavailable)
+	}
+
+	if len(written) != 1 || written[0].to != 9 {
+		t.Errorf("written = %+v, want only the chunk before the failing one", written)
+	}
+
+	if progress.Running() || progress.Error == "" || progress.ScannedBlock != 9 {
+		t.Errorf("progress = %+v, want failed after block 9", progress)
+	}
+}
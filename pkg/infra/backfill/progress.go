@@ -0,0 +1,61 @@
+package backfill
+
+import (
+	"time"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+// progressTracker measures the progress of a job, its throughput being computed since it started or resumed.
+type progressTracker struct {
+	progress domain.BackfillProgress
+	// since and sinceBlock are when the throughput is measured from, and the first block measured
+	since      time.Time
+	sinceBlock uint64
+}
+
+func newProgressTracker(job Job, now time.Time) *progressTracker {
+	return &progressTracker{
+		progress: domain.BackfillProgress{
+			FromBlock: job.FromBlock,
+			ToBlock:   job.ToBlock,
+			StartedAt: now,
+		},
+		since:      now,
+		sinceBlock: job.FromBlock,
+	}
+}
+
+// resume moves the progress to the checkpoint of a previous run.
+func (t *progressTracker) resume(lastBlock uint64, now time.Time) {
+	t.progress.ScannedBlock = lastBlock
+	t.since = now
+	t.sinceBlock = lastBlock + 1
+}
+
+func (t *progressTracker) advance(lastBlock uint64, records int, now time.Time) domain.BackfillProgress {
+	t.progress.ScannedBlock = lastBlock
+	t.progress.Records += records
+
+	if elapsed := now.Sub(t.since).Seconds(); elapsed > 0 {
+		t.progress.BlocksPerSecond = float64(lastBlock-t.sinceBlock+1) / elapsed
+	}
+
+	if t.progress.BlocksPerSecond > 0 {
+		remaining := float64(t.progress.ToBlock-lastBlock) / t.progress.BlocksPerSecond
+		t.progress.EstimatedFinishAt = now.Add(time.Duration(remaining * float64(time.Second)))
+	}
+
+	return t.progress
+}
+
+func (t *progressTracker) finish(err error, now time.Time) domain.BackfillProgress {
+	t.progress.FinishedAt = now
+	t.progress.EstimatedFinishAt = time.Time{}
+
+	if err != nil {
+		t.progress.Error = err.Error()
+	}
+
+	return t.progress
+}
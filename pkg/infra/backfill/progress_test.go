@@ -0,0 +1,33 @@
+package backfill
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestProgressTracker(t *testing.T) {
+	var (
+		start   = time.Unix(1000, 0)
+		tracker = newProgressTracker(Job{FromBlock: 1000, ToBlock: 1999}, start)
+	)
+
+	// resuming after block 1099, the throughput is measured from there
+	tracker.resume(1099, start.Add(time.Minute))
+
+	got := tracker.advance(1299, 10, start.Add(time.Minute+2*time.Second))
+
+	if got.ScannedBlock != 1299 || got.Records != 10 || got.BlocksPerSecond != 100 {
+		t.Fatalf("advance() = %+v, want 100 blocks per second", got)
+	}
+
+	if want := start.Add(time.Minute + 9*time.Second); !got.EstimatedFinishAt.Equal(want) {
+		t.Errorf("advance() estimated finish = %v, want %v", got.EstimatedFinishAt, want)
+	}
+
+	got = tracker.finish(errors.New("node unavailable"), start.Add(time.Hour))
+
+	if got.Running() || got.Error != "node unavailable" || !got.EstimatedFinishAt.IsZero() {
+		t.Errorf("finish() = %+v, want failed", got)
+	}
+}
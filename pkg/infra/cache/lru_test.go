@@ -0,0 +1,41 @@
+package cache
+
+import "testing"
+
+func TestLRU(t *testing.T) {
+	c := NewLRU[string, int](2)
+
+	c.Add("a", 1)
+	c.Add("b", 2)
+
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Fatalf("expected a to be 1, got: %v", v)
+	}
+
+	// b is now the least recently used item, so it must be evicted
+	c.Add("c", 3)
+
+	if _, ok := c.Get("b"); ok {
+		t.Fatalf("expected b to be evicted")
+	}
+
+	if v, ok := c.Get("c"); !ok || v != 3 {
+		t.Fatalf("expected c to be 3, got: %v", v)
+	}
+
+	c.Add("a", 10)
+
+	if v, ok := c.Get("a"); !ok || v != 10 {
+		t.Fatalf("expected a to be updated to 10, got: %v", v)
+	}
+}
+
+func TestLRU_ZeroCapacity(t *testing.T) {
+	c := NewLRU[string, int](0)
+
+	c.Add("a", 1)
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatalf("expected nothing to be cached")
+	}
+}
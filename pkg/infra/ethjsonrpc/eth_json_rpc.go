@@ -6,11 +6,17 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
 
 	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/infra/cache"
 )
 
 type Options func(*EthJSONRpc)
@@ -21,24 +27,56 @@ func WithHTTPClient(c *http.Client) Options {
 	}
 }
 
+const (
+	defaultMaxBatchSize     = 100
+	defaultReceiptCacheSize = 10_000
+)
+
 type Config struct {
-	APIURL         string
-	RequestTimeout time.Duration
+	APIURL           string
+	RequestTimeout   time.Duration
+	MaxBatchSize     int
+	ReceiptCacheSize int
+	// RateLimit is the number of requests per second sent to the node, unlimited when zero. Bursts of up to RateBurst
+	// requests are allowed.
+	RateLimit float64
+	RateBurst int
 }
 
 type EthJSONRpc struct {
 	cfg        *Config
 	httpClient *http.Client
 	currentID  int64
+	receipts   *cache.LRU[receiptKey, domain.Receipt]
+	tracer     atomic.Int32
+	limiter    *rate.Limiter
 }
 
 func NewEthJSONRpc(cfg *Config, opts ...Options) *EthJSONRpc {
+	if cfg.MaxBatchSize <= 0 {
+		cfg.MaxBatchSize = defaultMaxBatchSize
+	}
+
+	if cfg.ReceiptCacheSize <= 0 {
+		cfg.ReceiptCacheSize = defaultReceiptCacheSize
+	}
+
 	e := &EthJSONRpc{
 		currentID: 0,
 		cfg:       cfg,
 		httpClient: &http.Client{
 			Timeout: cfg.RequestTimeout,
 		},
+		receipts: cache.NewLRU[receiptKey, domain.Receipt](cfg.ReceiptCacheSize),
+	}
+
+	if cfg.RateLimit > 0 {
+		burst := cfg.RateBurst
+		if burst <= 0 {
+			burst = 1
+		}
+
+		e.limiter = rate.NewLimiter(rate.Limit(cfg.RateLimit), burst)
 	}
 
 	for _, opt := range opts {
@@ -48,13 +86,11 @@ func NewEthJSONRpc(cfg *Config, opts ...Options) *EthJSONRpc {
 	return e
 }
 
-func (e *EthJSONRpc) NewFilter(ctx context.Context, address string) (string, error) {
-	e.currentID++
-
-	payload := newRequestPayload(e.currentID, ethNewFilterMethod, []struct {
+func (e *EthJSONRpc) NewFilter(ctx context.Context, address domain.Address) (string, error) {
+	payload := newRequestPayload(e.nextID(), ethNewFilterMethod, []struct {
 		Address string `json:"address"`
 	}{
-		{Address: address},
+		{Address: address.String()},
 	})
 
 	resPayload, err := e.doPost(ctx, payload)
@@ -76,22 +112,47 @@ func (e *EthJSONRpc) NewFilter(ctx context.Context, address string) (string, err
 }
 
 func (e *EthJSONRpc) FetchTransactions(ctx context.Context, filter string) ([]domain.Transaction, error) {
-	e.currentID++
+	return e.fetchLogs(ctx, newRequestPayload(e.nextID(), ethGetFilterChangesMethod, []string{filter}))
+}
+
+// GetLogs returns the transactions of the logs emitted by the address within [fromBlock, toBlock].
+func (e *EthJSONRpc) GetLogs(
+	ctx context.Context,
+	address domain.Address,
+	fromBlock, toBlock uint64,
+) ([]domain.Transaction, error) {
+	payload := newRequestPayload(e.nextID(), ethGetLogsMethod, []struct {
+		Address   string `json:"address"`
+		FromBlock string `json:"fromBlock"`
+		ToBlock   string `json:"toBlock"`
+	}{
+		{Address: address.String(), FromBlock: toHexQuantity(fromBlock), ToBlock: toHexQuantity(toBlock)},
+	})
 
-	payload := newRequestPayload(e.currentID, ethGetFilterChangesMethod, []string{filter})
+	return e.fetchLogs(ctx, payload)
+}
 
+func (e *EthJSONRpc) fetchLogs(ctx context.Context, payload requestPayload) ([]domain.Transaction, error) {
 	resPayload, err := e.doPost(ctx, payload)
 	if err != nil {
 		return nil, errors.Wrap(err, "error reading response body")
 	}
 
-	var response getFilterChangesResponse
+	var response logsResponse
 
 	if err = json.Unmarshal(resPayload, &response); err != nil {
 		return nil, errors.Wrap(err, "error unmarshalling response")
 	}
 
 	if response.Error != nil {
+		if isTooManyResults(response.Error) {
+			return nil, errors.Wrap(domain.ErrTooManyResults, response.Error.Message)
+		}
+
+		if isFilterNotFound(response.Error.Message) {
+			return nil, errors.Wrap(domain.ErrFilterNotFound, response.Error.Message)
+		}
+
 		return nil, errors.Errorf("error response: %s", response.Error.Message)
 	}
 
@@ -108,16 +169,70 @@ func (e *EthJSONRpc) FetchTransactions(ctx context.Context, filter string) ([]do
 			return nil, errors.Wrap(err, "error creating new transaction")
 		}
 
+		if t, err = t.WithLog(v.LogIndex, v.Topics); err != nil {
+			return nil, errors.Wrap(err, "error creating new transaction")
+		}
+
+		t.Removed = v.Removed
 		transactions[i] = t
 	}
 
 	return transactions, nil
 }
 
-func (e *EthJSONRpc) RemoveFilter(ctx context.Context, address string) error {
-	e.currentID++
+// JSON-RPC error codes of the nodes refusing to return the logs of a range too large
+const (
+	serverErrorCode   = -32000
+	invalidParamsCode = -32602
+	// limitExceededCode is also returned by some nodes on rate limiting, the message telling both apart
+	limitExceededCode = -32005
+)
+
+// tooManyResultsErrors are the errors of the nodes refusing to return the logs of a range too large, matched on their
+// message and, when given, their code, e.g. "query returned more than 10000 results" on Geth and Infura, "Log response
+// size exceeded" on Alchemy or "exceed maximum block range: 5000" on BSC.
+var tooManyResultsErrors = []struct {
+	codes   []int
+	message string
+}{
+	{codes: []int{limitExceededCode, serverErrorCode}, message: "query returned more than"},
+	{codes: []int{invalidParamsCode, serverErrorCode}, message: "response size exceeded"},
+	{message: "exceed maximum block range"},
+}
+
+func isTooManyResults(res *errorResponse) bool {
+	message := strings.ToLower(res.Message)
+
+	for _, v := range tooManyResultsErrors {
+		if strings.Contains(message, v.message) && (len(v.codes) == 0 || slices.Contains(v.codes, res.Code)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// filterNotFoundMessages are the messages of the nodes no longer holding a filter, e.g. "filter not found" on Geth
+// or "Filter with id: 1 does not exist" on Nethermind.
+var filterNotFoundMessages = []string{
+	"filter not found",
+	"does not exist",
+}
 
-	payload := newRequestPayload(e.currentID, ethUninstallFilterMethod, []string{address})
+func isFilterNotFound(message string) bool {
+	message = strings.ToLower(message)
+
+	for _, v := range filterNotFoundMessages {
+		if strings.Contains(message, v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (e *EthJSONRpc) RemoveFilter(ctx context.Context, address string) error {
+	payload := newRequestPayload(e.nextID(), ethUninstallFilterMethod, []string{address})
 
 	resPayload, err := e.doPost(ctx, payload)
 	if err != nil {
@@ -137,18 +252,205 @@ func (e *EthJSONRpc) RemoveFilter(ctx context.Context, address string) error {
 	return nil
 }
 
-func (e *EthJSONRpc) doPost(ctx context.Context, payload requestPayload) ([]byte, error) {
-	data, err := json.Marshal(payload)
+// receiptKey identifies the receipt of a transaction in a block, the receipt of a transaction moved to another block by
+// a reorg being another one.
+type receiptKey struct {
+	blockHash string
+	hash      string
+}
+
+// GetTransactionReceipts returns the receipts of the given transactions, keyed by transaction hash.
+// Receipts already seen in the block of the transaction are served from cache, the remaining ones are fetched through
+// batch requests. Transactions whose receipt is not available yet, or no longer in their block, are not present in
+// the returned map.
+func (e *EthJSONRpc) GetTransactionReceipts(
+	ctx context.Context,
+	transactions []domain.Transaction,
+) (map[string]domain.Receipt, error) {
+	var (
+		receipts = make(map[string]domain.Receipt, len(transactions))
+		seen     = make(map[string]struct{}, len(transactions))
+		missing  = make([]receiptKey, 0, len(transactions))
+	)
+
+	for _, v := range transactions {
+		if _, ok := seen[v.Hash]; ok {
+			continue
+		}
+		seen[v.Hash] = struct{}{}
+
+		key := receiptKey{blockHash: v.BlockHash, hash: v.Hash}
+
+		if receipt, ok := e.receipts.Get(key); ok {
+			receipts[v.Hash] = receipt
+			continue
+		}
+
+		missing = append(missing, key)
+	}
+
+	for start := 0; start < len(missing); start += e.cfg.MaxBatchSize {
+		end := start + e.cfg.MaxBatchSize
+		if end > len(missing) {
+			end = len(missing)
+		}
+
+		if err := e.fetchReceiptsBatch(ctx, missing[start:end], receipts); err != nil {
+			return nil, err
+		}
+	}
+
+	return receipts, nil
+}
+
+func (e *EthJSONRpc) fetchReceiptsBatch(
+	ctx context.Context,
+	keys []receiptKey,
+	receipts map[string]domain.Receipt,
+) error {
+	var (
+		payload     = make([]requestPayload, len(keys))
+		blockHashes = make(map[string]string, len(keys))
+	)
+
+	for i, key := range keys {
+		payload[i] = newRequestPayload(e.nextID(), ethGetTransactionReceiptMethod, []string{key.hash})
+		blockHashes[key.hash] = key.blockHash
+	}
+
+	resPayload, err := e.doPost(ctx, payload)
 	if err != nil {
-		return nil, errors.Wrap(err, "error marshalling payload")
+		return errors.Wrap(err, "error reading response body")
+	}
+
+	var responses []getTransactionReceiptResponse
+
+	if err = json.Unmarshal(resPayload, &responses); err != nil {
+		return errors.Wrap(err, "error unmarshalling response")
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.cfg.APIURL, bytes.NewReader(data))
+	// the receipts returned are kept even when some requests of the batch failed, only the failed ones being fetched
+	// again on the next call
+	var failed error
+
+	for _, response := range responses {
+		if response.Error != nil {
+			if failed == nil {
+				failed = errors.Errorf("error response: %s", response.Error.Message)
+			}
+
+			continue
+		}
+
+		if response.Result == nil {
+			continue // receipt not available yet
+		}
+
+		var contractAddress string
+		if response.Result.ContractAddress != nil {
+			contractAddress = *response.Result.ContractAddress
+		}
+
+		receipt, err := domain.NewReceipt(
+			response.Result.TransactionHash,
+			response.Result.Status,
+			response.Result.GasUsed,
+			response.Result.CumulativeGasUsed,
+			response.Result.EffectiveGasPrice,
+			contractAddress,
+		)
+		if err != nil {
+			return errors.Wrap(err, "error creating new receipt")
+		}
+
+		e.receipts.Add(receiptKey{blockHash: response.Result.BlockHash, hash: receipt.TransactionHash}, receipt)
+
+		// the transaction was moved to another block by a reorg, its receipt isn't the one of the given transaction
+		if want := blockHashes[receipt.TransactionHash]; want != "" && want != response.Result.BlockHash {
+			continue
+		}
+
+		receipts[receipt.TransactionHash] = receipt
+	}
+
+	return failed
+}
+
+// GetBlockByNumber returns the header of the given block, without its transactions.
+func (e *EthJSONRpc) GetBlockByNumber(ctx context.Context, number uint64) (domain.BlockHeader, error) {
+	payload := newRequestPayload(e.nextID(), ethGetBlockByNumberMethod, []interface{}{toHexQuantity(number), false})
+
+	resPayload, err := e.doPost(ctx, payload)
+	if err != nil {
+		return domain.BlockHeader{}, errors.Wrap(err, "error reading response body")
+	}
+
+	var response getBlockByNumberResponse
+
+	if err = json.Unmarshal(resPayload, &response); err != nil {
+		return domain.BlockHeader{}, errors.Wrap(err, "error unmarshalling response")
+	}
+
+	if response.Error != nil {
+		return domain.BlockHeader{}, errors.Errorf("error response: %s", response.Error.Message)
+	}
+
+	if response.Result == nil {
+		return domain.BlockHeader{}, domain.ErrBlockNotFound
+	}
+
+	header, err := domain.NewBlockHeader(
+		response.Result.Number,
+		response.Result.Hash,
+		response.Result.Timestamp,
+		response.Result.BaseFeePerGas,
+		response.Result.Miner,
+	)
+	if err != nil {
+		return domain.BlockHeader{}, errors.Wrap(err, "error creating new block header")
+	}
+
+	header.Transactions = response.Result.Transactions
+
+	return header, nil
+}
+
+// BlockNumber returns the number of the most recent block.
+func (e *EthJSONRpc) BlockNumber(ctx context.Context) (uint64, error) {
+	payload := newRequestPayload(e.nextID(), ethBlockNumberMethod, []string{})
+
+	resPayload, err := e.doPost(ctx, payload)
+	if err != nil {
+		return 0, errors.Wrap(err, "error reading response body")
+	}
+
+	var response blockNumberResponse
+
+	if err = json.Unmarshal(resPayload, &response); err != nil {
+		return 0, errors.Wrap(err, "error unmarshalling response")
+	}
+
+	if response.Error != nil {
+		return 0, errors.Errorf("error response: %s", response.Error.Message)
+	}
+
+	number, err := domain.HexToUint64(response.Result)
 	if err != nil {
-		return nil, errors.Wrap(err, "error creating new request")
+		return 0, errors.Wrap(err, "invalid block number")
 	}
 
-	req.Header.Set("Content-Type", "application/json")
+	return number, nil
+}
+
+func (e *EthJSONRpc) nextID() int64 {
+	return atomic.AddInt64(&e.currentID, 1)
+}
+
+func (e *EthJSONRpc) doPost(ctx context.Context, payload interface{}) ([]byte, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshalling payload")
+	}
 
 	const maxRetries = 10
 
@@ -157,23 +459,62 @@ func (e *EthJSONRpc) doPost(ctx context.Context, payload requestPayload) ([]byte
 	// TODO Add maxRetries and sleep time on configuration
 
 	for i := 1; i <= maxRetries; i++ {
+		if e.limiter != nil {
+			if err = e.limiter.Wait(ctx); err != nil {
+				return nil, errors.Wrap(err, "error waiting for rate limiter")
+			}
+		}
+
+		// the body is consumed by every attempt, the request is built again
+		req, reqErr := http.NewRequestWithContext(ctx, http.MethodPost, e.cfg.APIURL, bytes.NewReader(data))
+		if reqErr != nil {
+			return nil, errors.Wrap(reqErr, "error creating new request")
+		}
+
+		req.Header.Set("Content-Type", "application/json")
+
 		res, err = e.httpClient.Do(req)
-		if err != nil {
-			continue
+		if err == nil && !isRetryable(res.StatusCode) {
+			break
 		}
-		if res.StatusCode == http.StatusOK {
+
+		if i == maxRetries || ctx.Err() != nil {
 			break
 		}
-		time.Sleep(time.Millisecond * 100 * time.Duration(i))
+
+		backoff := time.Millisecond * 100 * time.Duration(i)
+
+		if err == nil {
+			// a node limiting the rate tells how long to wait
+			if wait := retryAfter(res); wait > backoff {
+				backoff = wait
+			}
+
+			_ = res.Body.Close()
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, errors.Wrap(ctx.Err(), "error executing request")
+		case <-time.After(backoff):
+		}
 	}
 
 	if err != nil {
-		return nil, errors.Wrap(err, "error executing request")
+		if ctx.Err() != nil {
+			return nil, errors.Wrap(err, "error executing request")
+		}
+
+		return nil, errors.Wrapf(domain.ErrUpstreamUnavailable, "error executing request: %v", err)
 	}
 	defer func() {
 		_ = res.Body.Close()
 	}()
 
+	if isRetryable(res.StatusCode) {
+		return nil, errors.Wrapf(domain.ErrUpstreamUnavailable, "unexpected status code %d", res.StatusCode)
+	}
+
 	resPayload, err := io.ReadAll(res.Body)
 	if err != nil {
 		return nil, errors.Wrap(err, "error reading response body")
@@ -181,3 +522,21 @@ func (e *EthJSONRpc) doPost(ctx context.Context, payload requestPayload) ([]byte
 
 	return resPayload, nil
 }
+
+// isRetryable reports whether the request may succeed when sent again, the node being overloaded or rate limiting.
+func isRetryable(statusCode int) bool {
+	return statusCode >= http.StatusInternalServerError || statusCode == http.StatusTooManyRequests
+}
+
+// maxRetryAfter bounds the time waited on the Retry-After header of a response.
+const maxRetryAfter = 30 * time.Second
+
+// retryAfter returns the delay asked through the Retry-After header of the response, in seconds, zero when none.
+func retryAfter(res *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(res.Header.Get("Retry-After"))
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+
+	return min(time.Duration(seconds)*time.Second, maxRetryAfter)
+}
@@ -0,0 +1,249 @@
+package ethjsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+func TestEthJSONRpc_GetTransactionReceipts(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		batches [][]string
+		blocks  = map[string]string{"0x1": "0xb1", "0x2": "0xb1", "0x3": "0xb1"}
+	)
+
+	// the receipt of 0xbad can't be served, the other ones are, in the block set in blocks
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var requests []struct {
+			ID     int64    `json:"id"`
+			Params []string `json:"params"`
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&requests); err != nil {
+			http.Error(w, "batch expected", http.StatusBadRequest)
+			return
+		}
+
+		var (
+			hashes    []string
+			responses []string
+		)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		for _, req := range requests {
+			hash := req.Params[0]
+			hashes = append(hashes, hash)
+
+			if hash == "0xbad" {
+				responses = append(responses, fmt.Sprintf(`{"id":%d,"error":{"code":-32000,"message":"boom"}}`, req.ID))
+				continue
+			}
+
+			responses = append(responses, fmt.Sprintf(
+				`{"id":%d,"result":{"transactionHash":"%s","blockHash":"%s","status":"0x1","gasUsed":"0x5208",`+
+					`"cumulativeGasUsed":"0xa410","effectiveGasPrice":"0x3b9aca00"}}`,
+				req.ID, hash, blocks[hash],
+			))
+		}
+
+		batches = append(batches, hashes)
+
+		_, _ = fmt.Fprintf(w, "[%s]", strings.Join(responses, ","))
+	}))
+	defer server.Close()
+
+	api := NewEthJSONRpc(&Config{APIURL: server.URL, MaxBatchSize: 2})
+
+	transactions := func(blockHash string, hashes ...string) []domain.Transaction {
+		var txs = make([]domain.Transaction, len(hashes))
+		for i, hash := range hashes {
+			txs[i] = domain.Transaction{Hash: hash, BlockHash: blockHash}
+		}
+		return txs
+	}
+
+	// the failed request of the batch fails the call, the receipt fetched along being kept
+	_, err := api.GetTransactionReceipts(context.Background(), transactions("0xb1", "0x1", "0x1", "0xbad", "0x2"))
+	if err == nil {
+		t.Fatalf("GetTransactionReceipts() error = nil, want the failure of the batch")
+	}
+
+	got, err := api.GetTransactionReceipts(context.Background(), transactions("0xb1", "0x1", "0x2", "0x3"))
+	if err != nil {
+		t.Fatalf("GetTransactionReceipts() error = %v", err)
+	}
+
+	want := domain.Receipt{
+		TransactionHash:   "0x2",
+		Status:            domain.ReceiptStatusSuccess,
+		GasUsed:           domain.QuantityFromUint64(21000),
+		CumulativeGasUsed: domain.QuantityFromUint64(42000),
+		EffectiveGasPrice: domain.QuantityFromUint64(1_000_000_000),
+	}
+
+	if len(got) != 3 || !reflect.DeepEqual(got["0x2"], want) {
+		t.Errorf("GetTransactionReceipts() = %+v, want the 3 receipts, 0x2 being %+v", got, want)
+	}
+
+	// duplicates are requested once, and cached receipts never again
+	wantBatches := [][]string{{"0x1", "0xbad"}, {"0x2", "0x3"}}
+
+	if !reflect.DeepEqual(batches, wantBatches) {
+		t.Errorf("batches = %v, want %v", batches, wantBatches)
+	}
+
+	// a reorg moves 0x1 to block 0xb2, its receipt cached for block 0xb1 not being served for the new block
+	mu.Lock()
+	blocks["0x1"] = "0xb2"
+	batches = nil
+	mu.Unlock()
+
+	got, err = api.GetTransactionReceipts(context.Background(), transactions("0xb2", "0x1"))
+	if err != nil {
+		t.Fatalf("GetTransactionReceipts() error = %v", err)
+	}
+
+	if _, ok := got["0x1"]; !ok || !reflect.DeepEqual(batches, [][]string{{"0x1"}}) {
+		t.Errorf("GetTransactionReceipts() = %+v with batches %v, want 0x1 fetched again", got, batches)
+	}
+
+	// a transaction no longer in the block it was seen in gets no receipt
+	got, err = api.GetTransactionReceipts(context.Background(), transactions("0xb3", "0x2"))
+	if err != nil {
+		t.Fatalf("GetTransactionReceipts() error = %v", err)
+	}
+
+	if _, ok := got["0x2"]; ok {
+		t.Errorf("GetTransactionReceipts() = %+v, want no receipt for 0x2 outside of block 0xb1", got)
+	}
+}
+
+func TestIsTooManyResults(t *testing.T) {
+	tests := []struct {
+		name string
+		err  errorResponse
+		want bool
+	}{
+		{
+			name: "should tell too many results on Infura",
+			err:  errorResponse{Code: -32005, Message: "query returned more than 10000 results"},
+			want: true,
+		},
+		{
+			name: "should tell a response too large on Alchemy",
+			err:  errorResponse{Code: -32602, Message: "Log response size exceeded. Try a smaller range"},
+			want: true,
+		},
+		{
+			name: "should tell a block range too large",
+			err:  errorResponse{Code: -32000, Message: "exceed maximum block range: 5000"},
+			want: true,
+		},
+		{
+			name: "should not take rate limiting for too many results",
+			err:  errorResponse{Code: -32005, Message: "Too Many Requests"},
+		},
+		{
+			name: "should not take an exceeded request rate for too many results",
+			err:  errorResponse{Code: -32005, Message: "project ID request rate exceeded"},
+		},
+		{
+			name: "should not take an invalid block range for too many results",
+			err:  errorResponse{Code: -32602, Message: "invalid block range params"},
+		},
+		{
+			name: "should not match a message under another code",
+			err:  errorResponse{Code: -32601, Message: "query returned more than 10000 results"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isTooManyResults(&tt.err); got != tt.want {
+				t.Errorf("isTooManyResults() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEthJSONRpc_GetLogs(t *testing.T) {
+	const address = domain.Address("0x35fa164735182de50811e8e2e824cfb9b6118ac2")
+
+	tests := []struct {
+		name     string
+		response string
+		want     []domain.Transaction
+		wantErr  error
+	}{
+		{
+			name: "should return the logs of the range",
+			response: `{"jsonrpc":"2.0","id":1,"result":[{"address":"` + address.String() + `",` +
+				`"topics":["0xddf2"],"blockNumber":"0x10","transactionHash":"0x1","blockHash":"0xb10",` +
+				`"logIndex":"0x2","removed":false}]}`,
+			want: []domain.Transaction{{
+				Type:               domain.TransactionTypeLog,
+				Hash:               "0x1",
+				Address:            address,
+				BlockNumber:        "0x10",
+				BlockHash:          "0xb10",
+				DecimalBlockNumber: 16,
+				LogIndex:           2,
+				Topics:             []string{"0xddf2"},
+			}},
+		},
+		{
+			name:     "should tell a range holding too many logs",
+			response: `{"jsonrpc":"2.0","id":1,"error":{"code":-32005,"message":"query returned more than 10000 results"}}`,
+			wantErr:  domain.ErrTooManyResults,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var params []map[string]string
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var request struct {
+					Method string              `json:"method"`
+					Params []map[string]string `json:"params"`
+				}
+
+				if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.Method != ethGetLogsMethod {
+					http.Error(w, "eth_getLogs expected", http.StatusBadRequest)
+					return
+				}
+
+				params = request.Params
+				_, _ = io.WriteString(w, tt.response)
+			}))
+			defer server.Close()
+
+			got, err := NewEthJSONRpc(&Config{APIURL: server.URL}).GetLogs(context.Background(), address, 16, 32)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetLogs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetLogs() = %+v, want %+v", got, tt.want)
+			}
+
+			wantParams := []map[string]string{{"address": address.String(), "fromBlock": "0x10", "toBlock": "0x20"}}
+
+			if !reflect.DeepEqual(params, wantParams) {
+				t.Errorf("GetLogs() params = %v, want %v", params, wantParams)
+			}
+		})
+	}
+}
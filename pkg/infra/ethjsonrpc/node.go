@@ -0,0 +1,139 @@
+package ethjsonrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+// ChainID returns the EIP-155 chain ID served by the node.
+func (e *EthJSONRpc) ChainID(ctx context.Context) (domain.ChainID, error) {
+	result, err := e.callString(ctx, ethChainIDMethod)
+	if err != nil {
+		return 0, err
+	}
+
+	chainID, err := strconv.ParseUint(result, 0, 64)
+	if err != nil {
+		return 0, errors.Wrap(err, "invalid chain ID")
+	}
+
+	return domain.ChainID(chainID), nil
+}
+
+// NetVersion returns the network ID served by the node.
+func (e *EthJSONRpc) NetVersion(ctx context.Context) (string, error) {
+	return e.callString(ctx, netVersionMethod)
+}
+
+// ClientVersion returns the name and version of the node client.
+func (e *EthJSONRpc) ClientVersion(ctx context.Context) (string, error) {
+	return e.callString(ctx, web3ClientVersionMethod)
+}
+
+// Syncing reports whether the node is still syncing, with its current and highest known blocks when it is.
+func (e *EthJSONRpc) Syncing(ctx context.Context) (bool, uint64, uint64, error) {
+	payload := newRequestPayload(e.nextID(), ethSyncingMethod, []string{})
+
+	resPayload, err := e.doPost(ctx, payload)
+	if err != nil {
+		return false, 0, 0, errors.Wrap(err, "error reading response body")
+	}
+
+	var response syncingResponse
+
+	if err = json.Unmarshal(resPayload, &response); err != nil {
+		return false, 0, 0, errors.Wrap(err, "error unmarshalling response")
+	}
+
+	if response.Error != nil {
+		return false, 0, 0, errors.Errorf("error response: %s", response.Error.Message)
+	}
+
+	// a synced node answers false, a syncing one its progress
+	if len(response.Result) == 0 || bytes.Equal(response.Result, []byte("false")) {
+		return false, 0, 0, nil
+	}
+
+	var progress struct {
+		CurrentBlock string `json:"currentBlock"`
+		HighestBlock string `json:"highestBlock"`
+	}
+
+	if err = json.Unmarshal(response.Result, &progress); err != nil {
+		return false, 0, 0, errors.Wrap(err, "error unmarshalling sync progress")
+	}
+
+	current, err := domain.HexToUint64(progress.CurrentBlock)
+	if err != nil {
+		return false, 0, 0, errors.Wrap(err, "invalid current block")
+	}
+
+	highest, err := domain.HexToUint64(progress.HighestBlock)
+	if err != nil {
+		return false, 0, 0, errors.Wrap(err, "invalid highest block")
+	}
+
+	return true, current, highest, nil
+}
+
+// NodeStatus returns the chain, network, client and sync state of the node.
+func (e *EthJSONRpc) NodeStatus(ctx context.Context) (domain.NodeStatus, error) {
+	chainID, err := e.ChainID(ctx)
+	if err != nil {
+		return domain.NodeStatus{}, errors.Wrap(err, "error getting chain ID")
+	}
+
+	networkID, err := e.NetVersion(ctx)
+	if err != nil {
+		return domain.NodeStatus{}, errors.Wrap(err, "error getting network version")
+	}
+
+	clientVersion, err := e.ClientVersion(ctx)
+	if err != nil {
+		return domain.NodeStatus{}, errors.Wrap(err, "error getting client version")
+	}
+
+	syncing, current, highest, err := e.Syncing(ctx)
+	if err != nil {
+		return domain.NodeStatus{}, errors.Wrap(err, "error getting sync state")
+	}
+
+	return domain.NodeStatus{
+		ChainID:       chainID,
+		NetworkID:     networkID,
+		ClientVersion: clientVersion,
+		Syncing:       syncing,
+		CurrentBlock:  current,
+		HighestBlock:  highest,
+		CheckedAt:     time.Now(),
+	}, nil
+}
+
+// callString calls a method without params returning a string.
+func (e *EthJSONRpc) callString(ctx context.Context, method string) (string, error) {
+	payload := newRequestPayload(e.nextID(), method, []string{})
+
+	resPayload, err := e.doPost(ctx, payload)
+	if err != nil {
+		return "", errors.Wrap(err, "error reading response body")
+	}
+
+	var response stringResponse
+
+	if err = json.Unmarshal(resPayload, &response); err != nil {
+		return "", errors.Wrap(err, "error unmarshalling response")
+	}
+
+	if response.Error != nil {
+		return "", errors.Errorf("error response: %s", response.Error.Message)
+	}
+
+	return response.Result, nil
+}
@@ -0,0 +1,75 @@
+package ethjsonrpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+func TestEthJSONRpc_NodeStatus(t *testing.T) {
+	var base = map[string]string{
+		ethChainIDMethod:        `{"jsonrpc":"2.0","id":1,"result":"0x89"}`,
+		netVersionMethod:        `{"jsonrpc":"2.0","id":2,"result":"137"}`,
+		web3ClientVersionMethod: `{"jsonrpc":"2.0","id":3,"result":"Geth/v1.13.5"}`,
+	}
+
+	tests := []struct {
+		name    string
+		syncing string
+		want    domain.NodeStatus
+		wantErr bool
+	}{
+		{
+			name:    "should report a synced node",
+			syncing: `{"jsonrpc":"2.0","id":4,"result":false}`,
+			want:    domain.NodeStatus{ChainID: 137, NetworkID: "137", ClientVersion: "Geth/v1.13.5"},
+		},
+		{
+			name:    "should report the progress of a syncing node",
+			syncing: `{"jsonrpc":"2.0","id":4,"result":{"currentBlock":"0x10","highestBlock":"0x20"}}`,
+			want: domain.NodeStatus{
+				ChainID:       137,
+				NetworkID:     "137",
+				ClientVersion: "Geth/v1.13.5",
+				Syncing:       true,
+				CurrentBlock:  16,
+				HighestBlock:  32,
+			},
+		},
+		{
+			name:    "should return the error of the node",
+			syncing: `{"jsonrpc":"2.0","id":4,"error":{"code":-32000,"message":"boom"}}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var responses = map[string]string{ethSyncingMethod: tt.syncing}
+			for method, response := range base {
+				responses[method] = response
+			}
+
+			_, api := newTestNode(t, responses)
+
+			got, err := api.NodeStatus(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NodeStatus() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got.CheckedAt.IsZero() {
+				t.Errorf("NodeStatus() checked at = zero, want the time of the check")
+			}
+
+			got.CheckedAt = tt.want.CheckedAt
+
+			if got != tt.want {
+				t.Errorf("NodeStatus() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package ethjsonrpc
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+// NewPendingTransactionFilter creates a filter notifying the hashes of new pending transactions.
+func (e *EthJSONRpc) NewPendingTransactionFilter(ctx context.Context) (string, error) {
+	payload := newRequestPayload(e.nextID(), ethNewPendingTransactionFilterMethod, []string{})
+
+	resPayload, err := e.doPost(ctx, payload)
+	if err != nil {
+		return "", errors.Wrap(err, "error reading response body")
+	}
+
+	var response newFilterResponse
+
+	if err = json.Unmarshal(resPayload, &response); err != nil {
+		return "", errors.Wrap(err, "error unmarshalling response")
+	}
+
+	if response.Error != nil {
+		return "", errors.Errorf("error response: %s", response.Error.Message)
+	}
+
+	return response.Result, nil
+}
+
+// FetchPendingTransactionHashes returns the hashes of the pending transactions seen since the last call.
+func (e *EthJSONRpc) FetchPendingTransactionHashes(ctx context.Context, filter string) ([]string, error) {
+	payload := newRequestPayload(e.nextID(), ethGetFilterChangesMethod, []string{filter})
+
+	resPayload, err := e.doPost(ctx, payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading response body")
+	}
+
+	var response getPendingFilterChangesResponse
+
+	if err = json.Unmarshal(resPayload, &response); err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling response")
+	}
+
+	if response.Error != nil {
+		return nil, errors.Errorf("error response: %s", response.Error.Message)
+	}
+
+	return response.Result, nil
+}
+
+// GetTransactionsByHash returns the given transactions through batch requests, keyed by hash.
+// Transactions unknown by the node are not present in the returned map.
+func (e *EthJSONRpc) GetTransactionsByHash(ctx context.Context, hashes []string) (map[string]domain.PendingTransaction, error) {
+	var transactions = make(map[string]domain.PendingTransaction, len(hashes))
+
+	for start := 0; start < len(hashes); start += e.cfg.MaxBatchSize {
+		end := start + e.cfg.MaxBatchSize
+		if end > len(hashes) {
+			end = len(hashes)
+		}
+
+		if err := e.fetchTransactionsBatch(ctx, hashes[start:end], transactions); err != nil {
+			return nil, err
+		}
+	}
+
+	return transactions, nil
+}
+
+func (e *EthJSONRpc) fetchTransactionsBatch(
+	ctx context.Context,
+	hashes []string,
+	transactions map[string]domain.PendingTransaction,
+) error {
+	var payload = make([]requestPayload, len(hashes))
+
+	for i, hash := range hashes {
+		payload[i] = newRequestPayload(e.nextID(), ethGetTransactionByHashMethod, []string{hash})
+	}
+
+	resPayload, err := e.doPost(ctx, payload)
+	if err != nil {
+		return errors.Wrap(err, "error reading response body")
+	}
+
+	var responses []getTransactionByHashResponse
+
+	if err = json.Unmarshal(resPayload, &responses); err != nil {
+		return errors.Wrap(err, "error unmarshalling response")
+	}
+
+	for _, response := range responses {
+		if response.Error != nil {
+			return errors.Errorf("error response: %s", response.Error.Message)
+		}
+
+		if response.Result == nil {
+			continue // unknown transaction
+		}
+
+		var to, blockNumber, blockHash string
+
+		if response.Result.To != nil {
+			to = *response.Result.To
+		}
+
+		if response.Result.BlockNumber != nil {
+			blockNumber = *response.Result.BlockNumber
+		}
+
+		if response.Result.BlockHash != nil {
+			blockHash = *response.Result.BlockHash
+		}
+
+		tx, err := domain.NewPendingTransaction(
+			response.Result.Hash,
+			response.Result.From,
+			to,
+			response.Result.Value,
+			response.Result.Nonce,
+			blockNumber,
+			blockHash,
+		)
+		if err != nil {
+			return errors.Wrap(err, "error creating new pending transaction")
+		}
+
+		transactions[tx.Hash] = tx
+	}
+
+	return nil
+}
+
+// GetTransactionCount returns the nonce of the given address at the latest block.
+func (e *EthJSONRpc) GetTransactionCount(ctx context.Context, address domain.Address) (int64, error) {
+	payload := newRequestPayload(e.nextID(), ethGetTransactionCountMethod, []string{address.String(), "latest"})
+
+	resPayload, err := e.doPost(ctx, payload)
+	if err != nil {
+		return 0, errors.Wrap(err, "error reading response body")
+	}
+
+	var response getTransactionCountResponse
+
+	if err = json.Unmarshal(resPayload, &response); err != nil {
+		return 0, errors.Wrap(err, "error unmarshalling response")
+	}
+
+	if response.Error != nil {
+		return 0, errors.Errorf("error response: %s", response.Error.Message)
+	}
+
+	count, err := domain.HexToInt64(response.Result)
+	if err != nil {
+		return 0, errors.Wrap(err, "invalid transaction count")
+	}
+
+	return count, nil
+}
@@ -0,0 +1,80 @@
+package ethjsonrpc
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+func TestEthJSONRpc_PendingTransactions(t *testing.T) {
+	const (
+		sender   = "0x35fa164735182de50811e8e2e824cfb9b6118ac2"
+		receiver = "0x00000000219ab540356cbb839cbe05303d7705fa"
+	)
+
+	_, api := newTestNode(t, map[string]string{
+		ethNewPendingTransactionFilterMethod: `{"jsonrpc":"2.0","id":1,"result":"0xf1"}`,
+		ethGetFilterChangesMethod:            `{"jsonrpc":"2.0","id":2,"result":["0x1","0x2","0x3"]}`,
+		ethGetTransactionByHashMethod: `[
+			{"jsonrpc":"2.0","id":3,"result":{"hash":"0x1","from":"` + sender + `","to":"` + receiver + `",
+				"value":"0xde0b6b3a7640000","nonce":"0x7","blockNumber":null,"blockHash":null}},
+			{"jsonrpc":"2.0","id":4,"result":null},
+			{"jsonrpc":"2.0","id":5,"result":{"hash":"0x3","from":"` + sender + `","to":null,
+				"value":"0x0","nonce":"0x8","blockNumber":"0x10","blockHash":"0xb10"}}
+		]`,
+		ethGetTransactionCountMethod: `{"jsonrpc":"2.0","id":6,"result":"0x9"}`,
+	})
+
+	ctx := context.Background()
+
+	filter, err := api.NewPendingTransactionFilter(ctx)
+	if err != nil || filter != "0xf1" {
+		t.Fatalf("NewPendingTransactionFilter() = %s, %v, want 0xf1", filter, err)
+	}
+
+	hashes, err := api.FetchPendingTransactionHashes(ctx, filter)
+	if err != nil {
+		t.Fatalf("FetchPendingTransactionHashes() error = %v", err)
+	}
+
+	got, err := api.GetTransactionsByHash(ctx, hashes)
+	if err != nil {
+		t.Fatalf("GetTransactionsByHash() error = %v", err)
+	}
+
+	oneEther, _ := domain.ParseQuantity("0xde0b6b3a7640000")
+	zero, _ := domain.ParseQuantity("0x0")
+
+	// the unknown transaction is left out
+	want := map[string]domain.PendingTransaction{
+		"0x1": {
+			Hash:   "0x1",
+			From:   domain.HexToAddress(sender),
+			To:     domain.HexToAddress(receiver),
+			Value:  oneEther,
+			Nonce:  7,
+			Status: domain.PendingStatusPending,
+		},
+		"0x3": {
+			Hash:               "0x3",
+			From:               domain.HexToAddress(sender),
+			To:                 domain.HexToAddress(""),
+			Value:              zero,
+			Nonce:              8,
+			Status:             domain.PendingStatusMined,
+			BlockHash:          "0xb10",
+			DecimalBlockNumber: 16,
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetTransactionsByHash() = %+v, want %+v", got, want)
+	}
+
+	count, err := api.GetTransactionCount(ctx, domain.HexToAddress(sender))
+	if err != nil || count != 9 {
+		t.Errorf("GetTransactionCount() = %d, %v, want 9", count, err)
+	}
+}
@@ -0,0 +1,84 @@
+package ethjsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+func TestEthJSONRpc_RateLimit(t *testing.T) {
+	server := httptest.NewServer(&testNode{
+		responses: map[string]string{ethBlockNumberMethod: `{"jsonrpc":"2.0","id":1,"result":"0x10"}`},
+	})
+	defer server.Close()
+
+	api := NewEthJSONRpc(&Config{APIURL: server.URL, RateLimit: 20, RateBurst: 1})
+	start := time.Now()
+
+	for i := 0; i < 3; i++ {
+		if _, err := api.BlockNumber(context.Background()); err != nil {
+			t.Fatalf("BlockNumber() error = %v", err)
+		}
+	}
+
+	// a request every 50ms past the burst
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("3 requests took %s, want them spaced by the rate limit", elapsed)
+	}
+}
+
+func TestEthJSONRpc_RateLimitedBackoff(t *testing.T) {
+	var (
+		attempts atomic.Int32
+		limiting atomic.Bool
+	)
+
+	// the node rate limits the first attempt, telling to wait a second
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request requestPayload
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, "invalid request", http.StatusBadRequest)
+			return
+		}
+
+		if attempts.Add(1) == 1 || limiting.Load() {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		_, _ = io.WriteString(w, `{"jsonrpc":"2.0","id":1,"result":"0x10"}`)
+	}))
+	defer server.Close()
+
+	api := NewEthJSONRpc(&Config{APIURL: server.URL})
+	start := time.Now()
+
+	got, err := api.BlockNumber(context.Background())
+	if err != nil || got != 16 {
+		t.Fatalf("BlockNumber() = %d, %v, want the block once retried", got, err)
+	}
+
+	if elapsed := time.Since(start); elapsed < time.Second || attempts.Load() != 2 {
+		t.Errorf("BlockNumber() took %s in %d attempts, want a retry after a second", elapsed, attempts.Load())
+	}
+
+	// a node limiting the rate until the context is done fails the call straight away
+	limiting.Store(true)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	if _, err = api.BlockNumber(ctx); errors.Is(err, domain.ErrUpstreamUnavailable) || err == nil {
+		t.Errorf("BlockNumber() error = %v, want the context error", err)
+	}
+}
@@ -1,11 +1,30 @@
 package ethjsonrpc
 
+import "fmt"
+
 const (
 	defaultJSONRPCVersion = "2.0"
 
 	ethNewFilterMethod        = "eth_newFilter"
 	ethUninstallFilterMethod  = "eth_uninstallFilter"
 	ethGetFilterChangesMethod = "eth_getFilterChanges"
+	ethGetLogsMethod          = "eth_getLogs"
+
+	ethGetTransactionReceiptMethod = "eth_getTransactionReceipt"
+	ethGetBlockByNumberMethod      = "eth_getBlockByNumber"
+	ethBlockNumberMethod           = "eth_blockNumber"
+
+	ethNewPendingTransactionFilterMethod = "eth_newPendingTransactionFilter"
+	ethGetTransactionByHashMethod        = "eth_getTransactionByHash"
+	ethGetTransactionCountMethod         = "eth_getTransactionCount"
+
+	ethChainIDMethod        = "eth_chainId"
+	ethSyncingMethod        = "eth_syncing"
+	netVersionMethod        = "net_version"
+	web3ClientVersionMethod = "web3_clientVersion"
+
+	debugTraceBlockByNumberMethod = "debug_traceBlockByNumber"
+	traceBlockMethod              = "trace_block"
 )
 
 type requestPayload struct {
@@ -23,3 +42,7 @@ func newRequestPayload(id int64, method string, params interface{}) requestPaylo
 		Params:  params,
 	}
 }
+
+func toHexQuantity(v uint64) string {
+	return fmt.Sprintf("0x%x", v)
+}
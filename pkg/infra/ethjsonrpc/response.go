@@ -1,5 +1,11 @@
 package ethjsonrpc
 
+import (
+	"encoding/json"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
 type errorResponse struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
@@ -11,7 +17,8 @@ type newFilterResponse struct {
 	Error  *errorResponse `json:"error"`
 }
 
-type getFilterChangesResponse struct {
+// logsResponse is the response of eth_getFilterChanges and eth_getLogs.
+type logsResponse struct {
 	ID     int `json:"id"`
 	Result []struct {
 		Address          string   `json:"address"`
@@ -31,3 +38,122 @@ type uninstallFilterResponse struct {
 	ID    int            `json:"id"`
 	Error *errorResponse `json:"error"`
 }
+
+type getTransactionReceiptResponse struct {
+	ID     int64 `json:"id"`
+	Result *struct {
+		TransactionHash   string  `json:"transactionHash"`
+		BlockHash         string  `json:"blockHash"`
+		Status            string  `json:"status"`
+		GasUsed           string  `json:"gasUsed"`
+		CumulativeGasUsed string  `json:"cumulativeGasUsed"`
+		EffectiveGasPrice string  `json:"effectiveGasPrice"`
+		ContractAddress   *string `json:"contractAddress"`
+	} `json:"result"`
+	Error *errorResponse `json:"error"`
+}
+
+type getBlockByNumberResponse struct {
+	ID     int64 `json:"id"`
+	Result *struct {
+		Number        string   `json:"number"`
+		Hash          string   `json:"hash"`
+		Timestamp     string   `json:"timestamp"`
+		BaseFeePerGas string   `json:"baseFeePerGas"`
+		Miner         string   `json:"miner"`
+		Transactions  []string `json:"transactions"`
+	} `json:"result"`
+	Error *errorResponse `json:"error"`
+}
+
+type blockNumberResponse struct {
+	ID     int64          `json:"id"`
+	Result string         `json:"result"`
+	Error  *errorResponse `json:"error"`
+}
+
+// callFrame is the output of geth's callTracer.
+type callFrame struct {
+	Type  string          `json:"type"`
+	From  string          `json:"from"`
+	To    string          `json:"to"`
+	Value domain.Quantity `json:"value"`
+	Error string          `json:"error"`
+	Calls []callFrame     `json:"calls"`
+}
+
+type debugTraceBlockResponse struct {
+	ID     int64 `json:"id"`
+	Result []struct {
+		TxHash string    `json:"txHash"`
+		Result callFrame `json:"result"`
+	} `json:"result"`
+	Error *errorResponse `json:"error"`
+}
+
+// traceBlockResponse is the output of the parity style trace_block, as served by Erigon and Nethermind.
+type traceBlockResponse struct {
+	ID     int64 `json:"id"`
+	Result []struct {
+		Action struct {
+			CallType string          `json:"callType"`
+			From     string          `json:"from"`
+			To       string          `json:"to"`
+			Value    domain.Quantity `json:"value"`
+			// Address, RefundAddress and Balance are set on suicide traces
+			Address       string          `json:"address"`
+			RefundAddress string          `json:"refundAddress"`
+			Balance       domain.Quantity `json:"balance"`
+		} `json:"action"`
+		// Result holds the address of the contract deployed by create traces
+		Result *struct {
+			Address string `json:"address"`
+		} `json:"result"`
+		BlockHash       string `json:"blockHash"`
+		BlockNumber     uint64 `json:"blockNumber"`
+		Error           string `json:"error"`
+		TraceAddress    []int  `json:"traceAddress"`
+		TransactionHash string `json:"transactionHash"`
+		Type            string `json:"type"`
+	} `json:"result"`
+	Error *errorResponse `json:"error"`
+}
+
+type getPendingFilterChangesResponse struct {
+	ID     int64          `json:"id"`
+	Result []string       `json:"result"`
+	Error  *errorResponse `json:"error"`
+}
+
+type getTransactionByHashResponse struct {
+	ID     int64 `json:"id"`
+	Result *struct {
+		Hash        string  `json:"hash"`
+		From        string  `json:"from"`
+		To          *string `json:"to"`
+		Value       string  `json:"value"`
+		Nonce       string  `json:"nonce"`
+		BlockNumber *string `json:"blockNumber"`
+		BlockHash   *string `json:"blockHash"`
+	} `json:"result"`
+	Error *errorResponse `json:"error"`
+}
+
+type getTransactionCountResponse struct {
+	ID     int64          `json:"id"`
+	Result string         `json:"result"`
+	Error  *errorResponse `json:"error"`
+}
+
+type stringResponse struct {
+	ID     int64          `json:"id"`
+	Result string         `json:"result"`
+	Error  *errorResponse `json:"error"`
+}
+
+// syncingResponse holds either false or the sync progress of the node.
+type syncingResponse struct {
+	ID     int64           `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *errorResponse  `json:"error"`
+}
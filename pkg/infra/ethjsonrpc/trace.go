@@ -0,0 +1,241 @@
+package ethjsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+// tracing capabilities of the node, detected on the first TraceBlock call.
+const (
+	tracerUnknown int32 = iota
+	tracerDebug
+	tracerParity
+	tracerUnsupported
+)
+
+const methodNotFoundCode = -32601
+
+// errMethodNotSupported is returned when the node does not serve the requested tracing method.
+var errMethodNotSupported = errors.New("method not supported")
+
+// TraceBlock returns the internal calls executed in the given block.
+// It uses debug_traceBlockByNumber with the callTracer, falling back to trace_block on Erigon/Nethermind style nodes,
+// and returns domain.ErrTracingNotSupported when the node supports none of them.
+func (e *EthJSONRpc) TraceBlock(ctx context.Context, number uint64) ([]domain.InternalCall, error) {
+	switch e.tracer.Load() {
+	case tracerDebug:
+		return e.debugTraceBlock(ctx, number)
+	case tracerParity:
+		return e.parityTraceBlock(ctx, number)
+	case tracerUnsupported:
+		return nil, domain.ErrTracingNotSupported
+	}
+
+	calls, err := e.debugTraceBlock(ctx, number)
+	if err == nil {
+		e.tracer.Store(tracerDebug)
+		return calls, nil
+	}
+	if !errors.Is(err, errMethodNotSupported) {
+		return nil, err
+	}
+
+	calls, err = e.parityTraceBlock(ctx, number)
+	if err == nil {
+		e.tracer.Store(tracerParity)
+		return calls, nil
+	}
+	if !errors.Is(err, errMethodNotSupported) {
+		return nil, err
+	}
+
+	e.tracer.Store(tracerUnsupported)
+
+	return nil, domain.ErrTracingNotSupported
+}
+
+func (e *EthJSONRpc) debugTraceBlock(ctx context.Context, number uint64) ([]domain.InternalCall, error) {
+	payload := newRequestPayload(e.nextID(), debugTraceBlockByNumberMethod, []interface{}{
+		toHexQuantity(number),
+		map[string]string{"tracer": "callTracer"},
+	})
+
+	resPayload, err := e.doPost(ctx, payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading response body")
+	}
+
+	var response debugTraceBlockResponse
+
+	if err = json.Unmarshal(resPayload, &response); err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling response")
+	}
+
+	if response.Error != nil {
+		return nil, traceError(response.Error)
+	}
+
+	var calls []domain.InternalCall
+
+	for _, tx := range response.Result {
+		if tx.TxHash == "" {
+			return nil, errors.New("trace result without transaction hash")
+		}
+
+		// the top level frame is the transaction itself, only nested frames are internal calls
+		if tx.Result.Error != "" {
+			continue
+		}
+
+		for i, frame := range tx.Result.Calls {
+			calls = appendCallFrames(calls, tx.TxHash, number, frame, []int{i})
+		}
+	}
+
+	return calls, nil
+}
+
+func appendCallFrames(
+	calls []domain.InternalCall,
+	txHash string,
+	number uint64,
+	frame callFrame,
+	traceAddress []int,
+) []domain.InternalCall {
+	// reverted frames, and everything below them, did not move any value
+	if frame.Error != "" {
+		return calls
+	}
+
+	calls = append(calls, domain.InternalCall{
+		TransactionHash: txHash,
+		BlockNumber:     number,
+		CallType:        strings.ToLower(frame.Type),
+		From:            frame.From,
+		To:              frame.To,
+		Value:           frame.Value,
+		TraceAddress:    traceAddress,
+	})
+
+	for i, child := range frame.Calls {
+		childAddress := make([]int, len(traceAddress), len(traceAddress)+1)
+		copy(childAddress, traceAddress)
+
+		calls = appendCallFrames(calls, txHash, number, child, append(childAddress, i))
+	}
+
+	return calls
+}
+
+func (e *EthJSONRpc) parityTraceBlock(ctx context.Context, number uint64) ([]domain.InternalCall, error) {
+	payload := newRequestPayload(e.nextID(), traceBlockMethod, []string{toHexQuantity(number)})
+
+	resPayload, err := e.doPost(ctx, payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading response body")
+	}
+
+	var response traceBlockResponse
+
+	if err = json.Unmarshal(resPayload, &response); err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling response")
+	}
+
+	if response.Error != nil {
+		return nil, traceError(response.Error)
+	}
+
+	// trace addresses of the reverted calls, by transaction
+	var reverted = make(map[string][][]int)
+
+	for _, trace := range response.Result {
+		if trace.Error != "" {
+			reverted[trace.TransactionHash] = append(reverted[trace.TransactionHash], trace.TraceAddress)
+		}
+	}
+
+	var calls []domain.InternalCall
+
+	for _, trace := range response.Result {
+		// rewards and top level calls are not internal calls
+		if len(trace.TraceAddress) == 0 {
+			continue
+		}
+
+		if isReverted(reverted[trace.TransactionHash], trace.TraceAddress) {
+			continue
+		}
+
+		call := domain.InternalCall{
+			TransactionHash: trace.TransactionHash,
+			BlockNumber:     trace.BlockNumber,
+			BlockHash:       trace.BlockHash,
+			TraceAddress:    trace.TraceAddress,
+		}
+
+		// the same call types as geth's callTracer frames
+		switch trace.Type {
+		case "call":
+			call.CallType = strings.ToLower(trace.Action.CallType)
+			call.From, call.To, call.Value = trace.Action.From, trace.Action.To, trace.Action.Value
+		case "create":
+			if trace.Result == nil {
+				continue
+			}
+
+			call.CallType = "create"
+			call.From, call.To, call.Value = trace.Action.From, trace.Result.Address, trace.Action.Value
+		case "suicide":
+			call.CallType = "selfdestruct"
+			call.From, call.To, call.Value = trace.Action.Address, trace.Action.RefundAddress, trace.Action.Balance
+		default:
+			continue
+		}
+
+		calls = append(calls, call)
+	}
+
+	return calls, nil
+}
+
+// isReverted reports whether the call, or any of its parents, has been reverted.
+func isReverted(reverted [][]int, traceAddress []int) bool {
+	for _, r := range reverted {
+		if len(r) > len(traceAddress) {
+			continue
+		}
+
+		isParent := true
+
+		for i := range r {
+			if r[i] != traceAddress[i] {
+				isParent = false
+				break
+			}
+		}
+
+		if isParent {
+			return true
+		}
+	}
+
+	return false
+}
+
+func traceError(res *errorResponse) error {
+	message := strings.ToLower(res.Message)
+
+	if res.Code == methodNotFoundCode ||
+		strings.Contains(message, "not supported") ||
+		strings.Contains(message, "does not exist") ||
+		strings.Contains(message, "not available") {
+		return errors.Wrap(errMethodNotSupported, res.Message)
+	}
+
+	return errors.Errorf("error response: %s", res.Message)
+}
@@ -0,0 +1,197 @@
+package ethjsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+// testNode is a JSON-RPC node answering every method with the canned response given, recording the methods called.
+type testNode struct {
+	mu        sync.Mutex
+	responses map[string]string
+	methods   []string
+}
+
+func newTestNode(t *testing.T, responses map[string]string) (*testNode, *EthJSONRpc) {
+	t.Helper()
+
+	node := &testNode{responses: responses}
+
+	server := httptest.NewServer(node)
+	t.Cleanup(server.Close)
+
+	return node, NewEthJSONRpc(&Config{APIURL: server.URL})
+}
+
+func (n *testNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	var request requestPayload
+	if err := json.Unmarshal(body, &request); err != nil {
+		// batches are answered through the method of their first request
+		var batch []requestPayload
+		if err = json.Unmarshal(body, &batch); err != nil || len(batch) == 0 {
+			http.Error(w, "invalid request", http.StatusBadRequest)
+			return
+		}
+
+		request = batch[0]
+	}
+
+	n.mu.Lock()
+	n.methods = append(n.methods, request.Method)
+	n.mu.Unlock()
+
+	response, ok := n.responses[request.Method]
+	if !ok {
+		response = `{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"the method does not exist"}}`
+	}
+
+	_, _ = io.WriteString(w, response)
+}
+
+func (n *testNode) called() []string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return append([]string(nil), n.methods...)
+}
+
+func TestEthJSONRpc_TraceBlock(t *testing.T) {
+	const (
+		debugResponse = `{"jsonrpc":"2.0","id":1,"result":[{"txHash":"0x1","result":{
+			"type":"CALL","from":"0xa","to":"0xb","value":"0x0","calls":[
+				{"type":"CALL","from":"0xb","to":"0xc","value":"0x10","calls":[
+					{"type":"SELFDESTRUCT","from":"0xc","to":"0xe","value":"0x30"}
+				]},
+				{"type":"CALL","from":"0xb","to":"0xf","value":"0x40","error":"execution reverted","calls":[
+					{"type":"CALL","from":"0xf","to":"0xa","value":"0x50"}
+				]}
+			]}}]}`
+
+		parityResponse = `{"jsonrpc":"2.0","id":1,"result":[
+			{"action":{"callType":"call","from":"0xa","to":"0xb","value":"0x0"},"blockHash":"0xb10",
+				"blockNumber":16,"traceAddress":[],"transactionHash":"0x1","type":"call"},
+			{"action":{"callType":"call","from":"0xb","to":"0xc","value":"0x10"},"blockHash":"0xb10",
+				"blockNumber":16,"traceAddress":[0],"transactionHash":"0x1","type":"call"},
+			{"action":{"from":"0xb","value":"0x20","init":"0x60"},"result":{"address":"0xd","code":"0x"},
+				"blockHash":"0xb10","blockNumber":16,"traceAddress":[1],"transactionHash":"0x1","type":"create"},
+			{"action":{"address":"0xd","refundAddress":"0xe","balance":"0x30"},"result":null,"blockHash":"0xb10",
+				"blockNumber":16,"traceAddress":[1,0],"transactionHash":"0x1","type":"suicide"},
+			{"action":{"callType":"call","from":"0xb","to":"0xf","value":"0x40"},"error":"Reverted",
+				"blockHash":"0xb10","blockNumber":16,"traceAddress":[2],"transactionHash":"0x1","type":"call"},
+			{"action":{"callType":"call","from":"0xf","to":"0xa","value":"0x50"},"blockHash":"0xb10",
+				"blockNumber":16,"traceAddress":[2,0],"transactionHash":"0x1","type":"call"},
+			{"action":{"author":"0xminer","rewardType":"block","value":"0x1"},"result":null,"blockHash":"0xb10",
+				"blockNumber":16,"traceAddress":[],"type":"reward"}
+		]}`
+	)
+
+	tests := []struct {
+		name        string
+		responses   map[string]string
+		want        []domain.InternalCall
+		wantErr     error
+		wantMethods []string
+	}{
+		{
+			name:      "should trace through the callTracer, skipping the reverted frames",
+			responses: map[string]string{debugTraceBlockByNumberMethod: debugResponse},
+			want: []domain.InternalCall{
+				{
+					TransactionHash: "0x1",
+					BlockNumber:     16,
+					CallType:        "call",
+					From:            "0xb",
+					To:              "0xc",
+					Value:           domain.QuantityFromUint64(0x10),
+					TraceAddress:    []int{0},
+				},
+				{
+					TransactionHash: "0x1",
+					BlockNumber:     16,
+					CallType:        "selfdestruct",
+					From:            "0xc",
+					To:              "0xe",
+					Value:           domain.QuantityFromUint64(0x30),
+					TraceAddress:    []int{0, 0},
+				},
+			},
+			wantMethods: []string{debugTraceBlockByNumberMethod, debugTraceBlockByNumberMethod},
+		},
+		{
+			name:      "should fall back to trace_block, with the creations and self-destructs",
+			responses: map[string]string{traceBlockMethod: parityResponse},
+			want: []domain.InternalCall{
+				{
+					TransactionHash: "0x1",
+					BlockNumber:     16,
+					BlockHash:       "0xb10",
+					CallType:        "call",
+					From:            "0xb",
+					To:              "0xc",
+					Value:           domain.QuantityFromUint64(0x10),
+					TraceAddress:    []int{0},
+				},
+				{
+					TransactionHash: "0x1",
+					BlockNumber:     16,
+					BlockHash:       "0xb10",
+					CallType:        "create",
+					From:            "0xb",
+					To:              "0xd",
+					Value:           domain.QuantityFromUint64(0x20),
+					TraceAddress:    []int{1},
+				},
+				{
+					TransactionHash: "0x1",
+					BlockNumber:     16,
+					BlockHash:       "0xb10",
+					CallType:        "selfdestruct",
+					From:            "0xd",
+					To:              "0xe",
+					Value:           domain.QuantityFromUint64(0x30),
+					TraceAddress:    []int{1, 0},
+				},
+			},
+			// the format is detected once, the next block being traced straight away
+			wantMethods: []string{debugTraceBlockByNumberMethod, traceBlockMethod, traceBlockMethod},
+		},
+		{
+			name:        "should detect a node without tracing",
+			responses:   map[string]string{},
+			wantErr:     domain.ErrTracingNotSupported,
+			wantMethods: []string{debugTraceBlockByNumberMethod, traceBlockMethod},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, api := newTestNode(t, tt.responses)
+
+			for i := 0; i < 2; i++ {
+				got, err := api.TraceBlock(context.Background(), 16)
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("TraceBlock() #%d error = %v, wantErr %v", i, err, tt.wantErr)
+				}
+
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Fatalf("TraceBlock() #%d = %+v, want %+v", i, got, tt.want)
+				}
+			}
+
+			if got := node.called(); !reflect.DeepEqual(got, tt.wantMethods) {
+				t.Errorf("methods called = %v, want %v", got, tt.wantMethods)
+			}
+		})
+	}
+}
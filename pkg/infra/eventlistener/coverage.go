@@ -0,0 +1,107 @@
+package eventlistener
+
+import (
+	"context"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+// CoverageStore persists the block ranges indexed for each address.
+type CoverageStore interface {
+	AddCoveredRange(ctx context.Context, address domain.Address, r domain.BlockRange) error
+	GetCoveredRanges(ctx context.Context, address domain.Address) ([]domain.BlockRange, error)
+}
+
+// coverPoll records the blocks covered by the filter of the address after a successful poll, from the first one up to
+// the last confirmed block before the pending records. The first poll of a filter only sets where its coverage starts,
+// the blocks mined before it being left to the gap repair.
+func (e *PoolingEventListener) coverPoll(ctx context.Context, address domain.Address, pending []domain.Transaction) {
+	if e.coverage == nil {
+		return
+	}
+
+	head, err := e.api.BlockNumber(ctx)
+	if err != nil {
+		e.logger.Debug("Failed to fetch block number to record coverage", "error", err, "address", address)
+		return
+	}
+
+	e.mu.Lock()
+
+	sub, ok := e.subscriptions[address]
+	if !ok {
+		e.mu.Unlock()
+		return
+	}
+
+	from := sub.coverFrom
+	if from == 0 {
+		sub.coverFrom = head + 1
+	}
+
+	if sub.startBlock == 0 {
+		sub.startBlock = head + 1
+	}
+
+	e.mu.Unlock()
+
+	if from == 0 || head < e.cfg.Confirmations {
+		return
+	}
+
+	to := head - e.cfg.Confirmations
+
+	for _, v := range pending {
+		if v.DecimalBlockNumber <= from {
+			return
+		}
+
+		if v.DecimalBlockNumber <= to {
+			to = v.DecimalBlockNumber - 1
+		}
+	}
+
+	if to < from {
+		return
+	}
+
+	e.cover(ctx, address, domain.BlockRange{FromBlock: from, ToBlock: to})
+}
+
+// cover records the range as indexed for the address, a failure only delaying it to the next poll or gap repair.
+func (e *PoolingEventListener) cover(ctx context.Context, address domain.Address, r domain.BlockRange) {
+	if e.coverage == nil {
+		return
+	}
+
+	if err := e.coverage.AddCoveredRange(ctx, address, r); err != nil {
+		e.logger.Warn("Failed to record covered blocks", "error", err, "address", address)
+	}
+}
+
+// liveFrom returns the first block covered by the filter of the address while it's polled successfully, zero
+// otherwise.
+func (e *PoolingEventListener) liveFrom(address domain.Address) uint64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	sub, ok := e.subscriptions[address]
+	if !ok || sub.handle == nil || sub.State != domain.SubscriptionActive {
+		return 0
+	}
+
+	return sub.coverFrom
+}
+
+// startFrom returns the block the subscription of the address started at, zero when unknown.
+func (e *PoolingEventListener) startFrom(address domain.Address) uint64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	sub, ok := e.subscriptions[address]
+	if !ok {
+		return 0
+	}
+
+	return sub.startBlock
+}
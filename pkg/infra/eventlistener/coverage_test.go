@@ -0,0 +1,118 @@
+package eventlistener
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/mocks"
+)
+
+func TestPoolingEventListener_Coverage(t *testing.T) {
+	var (
+		logger  = slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))
+		address = domain.Address("0x123")
+
+		mu      sync.Mutex
+		head    uint64 = 100
+		covered []domain.BlockRange
+	)
+
+	// the head moves a block forward on every call
+	api := mocks.NewEthJSONAPI(t)
+	api.EXPECT().NewFilter(mock.Anything, address).Return("0x1", nil).Once()
+	api.EXPECT().NewFilter(mock.Anything, address).Return("0x2", nil).Once()
+	api.EXPECT().FetchTransactions(mock.Anything, mock.Anything).Return([]domain.Transaction{}, nil)
+	api.EXPECT().BlockNumber(mock.Anything).RunAndReturn(func(context.Context) (uint64, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		head++
+
+		return head - 1, nil
+	})
+	api.EXPECT().GetLogs(mock.Anything, address, mock.Anything, mock.Anything).Return([]domain.Transaction{}, nil).Twice()
+	api.EXPECT().RemoveFilter(mock.Anything, mock.Anything).Return(nil).Twice()
+
+	coverage := mocks.NewCoverageStore(t)
+	coverage.EXPECT().AddCoveredRange(mock.Anything, address, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ domain.Address, r domain.BlockRange) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			covered = append(covered, r)
+
+			return nil
+		})
+
+	repo := mocks.NewRepositoryWriter(t)
+	repo.EXPECT().ReplaceBlockRange(mock.Anything, address, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, nil).
+		Once()
+	repo.EXPECT().UpdateLastBlock(mock.Anything, address, mock.Anything).Return(nil).Once()
+
+	e := NewPoolingEventListener(
+		context.Background(),
+		api,
+		repo,
+		WithLogger(logger),
+		WithCoverage(coverage),
+		WithConfig(&Config{PoolingTime: time.Millisecond * 5, Confirmations: 2}),
+	)
+
+	// waitCovered returns the last range covered once it's ending at or after the given block
+	waitCovered := func(toBlock uint64) domain.BlockRange {
+		t.Helper()
+
+		for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+			mu.Lock()
+			if n := len(covered); n > 0 && covered[n-1].ToBlock >= toBlock {
+				mu.Unlock()
+				return covered[n-1]
+			}
+			mu.Unlock()
+		}
+
+		t.Fatalf("no range covered up to block %d, covered: %v", toBlock, covered)
+
+		return domain.BlockRange{}
+	}
+
+	if err := e.Listen(context.Background(), address); err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+
+	// the coverage starts after the head of the first poll, up to the last confirmed block
+	if got := waitCovered(102); got.FromBlock != 101 {
+		t.Fatalf("covered = %v, want from block 101", got)
+	}
+
+	if err := e.Pause(context.Background(), address); err != nil {
+		t.Fatalf("Pause() error = %v", err)
+	}
+
+	sub, _ := e.Subscription(address)
+
+	if got := waitCovered(sub.LastBlock); got != (domain.BlockRange{FromBlock: 101, ToBlock: sub.LastBlock}) {
+		t.Fatalf("covered = %v, want up to the cursor of the paused subscription %d", got, sub.LastBlock)
+	}
+
+	if err := e.Resume(context.Background(), address); err != nil {
+		t.Fatalf("Resume() error = %v", err)
+	}
+
+	// the confirmed blocks caught up on resume are covered, the next ones by the next polls
+	if got := waitCovered(sub.LastBlock + 1); got.FromBlock != sub.LastBlock+1 {
+		t.Fatalf("covered = %v, want from block %d", got, sub.LastBlock+1)
+	}
+
+	if err := e.Close(context.Background()); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+}
@@ -0,0 +1,113 @@
+package eventlistener
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/infra/cache"
+)
+
+// Enricher decorates fetched transactions with additional data before they are stored.
+type Enricher interface {
+	Enrich(ctx context.Context, transactions []domain.Transaction) ([]domain.Transaction, error)
+}
+
+const defaultBlockHeaderCacheSize = 1_000
+
+type ReceiptFetcher interface {
+	GetTransactionReceipts(ctx context.Context, transactions []domain.Transaction) (map[string]domain.Receipt, error)
+}
+
+// ReceiptEnricher attaches the transaction receipt (gas used, status, etc.) to every transaction.
+type ReceiptEnricher struct {
+	api ReceiptFetcher
+}
+
+func NewReceiptEnricher(api ReceiptFetcher) *ReceiptEnricher {
+	return &ReceiptEnricher{api: api}
+}
+
+func (r *ReceiptEnricher) Enrich(ctx context.Context, transactions []domain.Transaction) ([]domain.Transaction, error) {
+	receipts, err := r.api.GetTransactionReceipts(ctx, transactions)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch receipts")
+	}
+
+	var enriched = make([]domain.Transaction, len(transactions))
+
+	for i, v := range transactions {
+		receipt, ok := receipts[v.Hash]
+		if !ok {
+			return nil, errors.Errorf("receipt not available for transaction %s", v.Hash)
+		}
+
+		v.Receipt = &receipt
+		enriched[i] = v
+	}
+
+	return enriched, nil
+}
+
+type BlockHeaderFetcher interface {
+	GetBlockByNumber(ctx context.Context, number uint64) (domain.BlockHeader, error)
+}
+
+// BlockHeaderEnricher attaches the block timestamp, base fee and miner to every transaction.
+// Headers are cached by block hash, so several transactions from the same block cost a single request.
+type BlockHeaderEnricher struct {
+	api     BlockHeaderFetcher
+	headers *cache.LRU[string, domain.BlockHeader]
+}
+
+func NewBlockHeaderEnricher(api BlockHeaderFetcher, cacheSize int) *BlockHeaderEnricher {
+	if cacheSize <= 0 {
+		cacheSize = defaultBlockHeaderCacheSize
+	}
+
+	return &BlockHeaderEnricher{
+		api:     api,
+		headers: cache.NewLRU[string, domain.BlockHeader](cacheSize),
+	}
+}
+
+func (b *BlockHeaderEnricher) Enrich(ctx context.Context, transactions []domain.Transaction) ([]domain.Transaction, error) {
+	var enriched = make([]domain.Transaction, len(transactions))
+
+	for i, v := range transactions {
+		header, err := b.header(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+
+		enriched[i] = v.WithBlockHeader(header)
+	}
+
+	return enriched, nil
+}
+
+func (b *BlockHeaderEnricher) header(ctx context.Context, transaction domain.Transaction) (domain.BlockHeader, error) {
+	if header, ok := b.headers.Get(transaction.BlockHash); ok {
+		return header, nil
+	}
+
+	header, err := b.api.GetBlockByNumber(ctx, transaction.DecimalBlockNumber)
+	if err != nil {
+		return domain.BlockHeader{}, errors.Wrapf(err, "failed to fetch block %d", transaction.DecimalBlockNumber)
+	}
+
+	// the block at this height was replaced by a reorg, the transaction will be retried later
+	if header.Hash != transaction.BlockHash {
+		return domain.BlockHeader{}, errors.Errorf(
+			"block %d hash mismatch, expected %s got %s",
+			transaction.DecimalBlockNumber,
+			transaction.BlockHash,
+			header.Hash,
+		)
+	}
+
+	b.headers.Add(header.Hash, header)
+
+	return header, nil
+}
@@ -0,0 +1,194 @@
+package eventlistener
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/mocks"
+)
+
+func TestReceiptEnricher_Enrich(t *testing.T) {
+	var (
+		receipt1 = domain.Receipt{
+			TransactionHash: "0x1",
+			Status:          domain.ReceiptStatusSuccess,
+			GasUsed:         domain.QuantityFromUint64(21000),
+		}
+		receipt2 = domain.Receipt{
+			TransactionHash: "0x2",
+			Status:          domain.ReceiptStatusReverted,
+			GasUsed:         domain.QuantityFromUint64(1024),
+		}
+
+		transactions = []domain.Transaction{
+			{Hash: "0x1", Address: "0x123", DecimalBlockNumber: 1},
+			{Hash: "0x2", Address: "0x123", DecimalBlockNumber: 2},
+		}
+	)
+
+	type args struct {
+		transactions []domain.Transaction
+	}
+	tests := []struct {
+		name    string
+		api     func(*testing.T) ReceiptFetcher
+		args    args
+		want    []domain.Transaction
+		wantErr bool
+	}{
+		{
+			name: "should attach the receipt to every transaction",
+			api: func(t *testing.T) ReceiptFetcher {
+				api := mocks.NewReceiptFetcher(t)
+				api.EXPECT().GetTransactionReceipts(mock.Anything, transactions).
+					Return(map[string]domain.Receipt{"0x1": receipt1, "0x2": receipt2}, nil).
+					Once()
+				return api
+			},
+			args: args{transactions: transactions},
+			want: []domain.Transaction{
+				{Hash: "0x1", Address: "0x123", DecimalBlockNumber: 1, Receipt: &receipt1},
+				{Hash: "0x2", Address: "0x123", DecimalBlockNumber: 2, Receipt: &receipt2},
+			},
+			wantErr: false,
+		},
+		{
+			name: "should error when a receipt is not available yet",
+			api: func(t *testing.T) ReceiptFetcher {
+				api := mocks.NewReceiptFetcher(t)
+				api.EXPECT().GetTransactionReceipts(mock.Anything, transactions).
+					Return(map[string]domain.Receipt{"0x1": receipt1}, nil).
+					Once()
+				return api
+			},
+			args:    args{transactions: transactions},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "should error when receipts can not be fetched",
+			api: func(t *testing.T) ReceiptFetcher {
+				api := mocks.NewReceiptFetcher(t)
+				api.EXPECT().GetTransactionReceipts(mock.Anything, transactions).
+					Return(nil, errors.New("network error")).
+					Once()
+				return api
+			},
+			args:    args{transactions: transactions},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewReceiptEnricher(tt.api(t))
+
+			got, err := r.Enrich(context.Background(), tt.args.transactions)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Enrich() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Enrich() got = %v, want %v", got, tt.want)
+			}
+			if transactions[0].Receipt != nil {
+				t.Errorf("Enrich() must not change the given transactions")
+			}
+		})
+	}
+}
+
+func TestBlockHeaderEnricher_Enrich(t *testing.T) {
+	var (
+		baseFee = domain.QuantityFromUint64(65)
+		header  = domain.BlockHeader{Number: 1, Hash: "0xb1", Timestamp: 1711724987, BaseFeePerGas: &baseFee, Miner: "0xm"}
+
+		transactions = []domain.Transaction{
+			{Hash: "0x1", BlockHash: "0xb1", DecimalBlockNumber: 1},
+			{Hash: "0x2", BlockHash: "0xb1", DecimalBlockNumber: 1},
+		}
+	)
+
+	type args struct {
+		transactions []domain.Transaction
+	}
+	tests := []struct {
+		name    string
+		api     func(*testing.T) BlockHeaderFetcher
+		args    args
+		want    []domain.Transaction
+		wantErr bool
+	}{
+		{
+			name: "should attach the block header fetching each block only once",
+			api: func(t *testing.T) BlockHeaderFetcher {
+				api := mocks.NewBlockHeaderFetcher(t)
+				api.EXPECT().GetBlockByNumber(mock.Anything, uint64(1)).Return(header, nil).Once()
+				return api
+			},
+			args: args{transactions: transactions},
+			want: []domain.Transaction{
+				{
+					Hash:               "0x1",
+					BlockHash:          "0xb1",
+					DecimalBlockNumber: 1,
+					Timestamp:          1711724987,
+					BaseFeePerGas:      &baseFee,
+					Miner:              "0xm",
+				},
+				{
+					Hash:               "0x2",
+					BlockHash:          "0xb1",
+					DecimalBlockNumber: 1,
+					Timestamp:          1711724987,
+					BaseFeePerGas:      &baseFee,
+					Miner:              "0xm",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "should error when the block was reorganised",
+			api: func(t *testing.T) BlockHeaderFetcher {
+				api := mocks.NewBlockHeaderFetcher(t)
+				api.EXPECT().GetBlockByNumber(mock.Anything, uint64(1)).
+					Return(domain.BlockHeader{Number: 1, Hash: "0xb2"}, nil).
+					Once()
+				return api
+			},
+			args:    args{transactions: transactions},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "should error when the block can not be fetched",
+			api: func(t *testing.T) BlockHeaderFetcher {
+				api := mocks.NewBlockHeaderFetcher(t)
+				api.EXPECT().GetBlockByNumber(mock.Anything, uint64(1)).Return(domain.BlockHeader{}, domain.ErrBlockNotFound).Once()
+				return api
+			},
+			args:    args{transactions: transactions},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBlockHeaderEnricher(tt.api(t), 10)
+
+			got, err := b.Enrich(context.Background(), tt.args.transactions)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Enrich() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Enrich() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,249 @@
+package eventlistener
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/infra/backfill"
+)
+
+const defaultGapRepairInterval = 1 * time.Minute
+
+// GapStore persists the block ranges indexed for each address along with the gaps found between them.
+type GapStore interface {
+	CoverageStore
+	SaveGap(ctx context.Context, gap domain.Gap) error
+	GetGaps(ctx context.Context, address domain.Address) ([]domain.Gap, error)
+}
+
+type GapRepairerOptions func(*GapRepairer)
+
+func WithGapRepairerConfig(cfg *GapRepairerConfig) GapRepairerOptions {
+	return func(r *GapRepairer) {
+		r.cfg = cfg
+	}
+}
+
+func WithGapRepairerLogger(l *slog.Logger) GapRepairerOptions {
+	return func(r *GapRepairer) {
+		r.logger = l
+	}
+}
+
+type GapRepairerConfig struct {
+	// Interval is the time between two runs looking for gaps
+	Interval time.Duration
+}
+
+// GapRepairer periodically looks for the blocks missing between the ranges indexed for each subscribed address, from
+// the block its subscription started at, or its first covered block when unknown, up to the last confirmed one, e.g.
+// while the service was down or a filter expired, and fills them through eth_getLogs with the backfill engine of the
+// listener.
+type GapRepairer struct {
+	logger   *slog.Logger
+	cfg      *GapRepairerConfig
+	listener *PoolingEventListener
+	store    GapStore
+}
+
+func NewGapRepairer(listener *PoolingEventListener, store GapStore, opts ...GapRepairerOptions) *GapRepairer {
+	r := &GapRepairer{
+		logger:   slog.Default(),
+		cfg:      &GapRepairerConfig{Interval: defaultGapRepairInterval},
+		listener: listener,
+		store:    store,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if r.cfg.Interval <= 0 {
+		r.cfg.Interval = defaultGapRepairInterval
+	}
+
+	return r
+}
+
+// Run repairs the gaps on every interval until the context is done.
+func (r *GapRepairer) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case <-ticker.C:
+			for _, address := range r.listener.Addresses() {
+				if err := r.Repair(ctx, address); err != nil && ctx.Err() == nil {
+					r.logger.Error("Failed to repair gaps", "error", err, "address", address)
+				}
+			}
+		}
+	}
+}
+
+// Repair detects the gaps of the address and fills them one after the other, stopping on the first one failing,
+// which is retried on the next run.
+func (r *GapRepairer) Repair(ctx context.Context, address domain.Address) error {
+	covered, err := r.store.GetCoveredRanges(ctx, address)
+	if err != nil {
+		return errors.Wrap(err, "failed to get covered ranges")
+	}
+
+	var (
+		start = r.listener.startFrom(address)
+		gaps  = domain.BlockRangeGaps(covered)
+	)
+
+	if lead := leadingGap(covered, start); lead != nil {
+		gaps = append([]domain.BlockRange{*lead}, gaps...)
+	}
+
+	tail, err := r.tailGap(ctx, address, covered, start)
+	if err != nil {
+		return err
+	}
+
+	if tail != nil {
+		gaps = append(gaps, *tail)
+	}
+
+	if len(gaps) == 0 {
+		return nil
+	}
+
+	known, err := r.store.GetGaps(ctx, address)
+	if err != nil {
+		return errors.Wrap(err, "failed to get gaps")
+	}
+
+	var detectedAt = make(map[domain.BlockRange]time.Time, len(known))
+
+	for _, gap := range known {
+		detectedAt[gap.Range()] = gap.DetectedAt
+	}
+
+	for _, missing := range gaps {
+		gap := domain.Gap{
+			Address:    address,
+			FromBlock:  missing.FromBlock,
+			ToBlock:    missing.ToBlock,
+			State:      domain.GapDetected,
+			DetectedAt: time.Now(),
+		}
+
+		if at, ok := detectedAt[missing]; ok {
+			gap.DetectedAt = at
+		} else {
+			r.logger.Warn("Gap detected", "address", address, "from_block", gap.FromBlock, "to_block", gap.ToBlock)
+		}
+
+		if err = r.repair(ctx, gap); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// leadingGap returns the blocks from the start of the subscription up to the first covered one, nil when there are
+// none or the start is unknown.
+func leadingGap(covered []domain.BlockRange, start uint64) *domain.BlockRange {
+	merged := domain.MergeBlockRanges(covered)
+	if start == 0 || len(merged) == 0 || merged[0].FromBlock <= start {
+		return nil
+	}
+
+	return &domain.BlockRange{FromBlock: start, ToBlock: merged[0].FromBlock - 1}
+}
+
+// tailGap returns the confirmed blocks after the last covered one, or from the start of the subscription when none is
+// covered yet, nil when there are none. The blocks the filter of the address covers while it's polled successfully
+// are left to it.
+func (r *GapRepairer) tailGap(
+	ctx context.Context,
+	address domain.Address,
+	covered []domain.BlockRange,
+	start uint64,
+) (*domain.BlockRange, error) {
+	merged := domain.MergeBlockRanges(covered)
+	if len(merged) == 0 && start == 0 {
+		return nil, nil
+	}
+
+	head, err := r.listener.api.BlockNumber(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch block number")
+	}
+
+	if head < r.listener.cfg.Confirmations {
+		return nil, nil
+	}
+
+	to := head - r.listener.cfg.Confirmations
+
+	if from := r.listener.liveFrom(address); from > 0 && from <= to {
+		to = from - 1
+	}
+
+	// nothing covered yet, the blocks are missing from the start of the subscription
+	last := start - 1
+	if len(merged) > 0 {
+		last = merged[len(merged)-1].ToBlock
+	}
+
+	if last >= to {
+		return nil, nil
+	}
+
+	return &domain.BlockRange{FromBlock: last + 1, ToBlock: to}, nil
+}
+
+// repair fills the gap through the backfill engine, recording its state before and after.
+func (r *GapRepairer) repair(ctx context.Context, gap domain.Gap) error {
+	gap.State = domain.GapRepairing
+
+	if err := r.store.SaveGap(ctx, gap); err != nil {
+		return errors.Wrap(err, "failed to save gap")
+	}
+
+	job := backfill.Job{
+		ID:        fmt.Sprintf("%s%s/%d", gapJobPrefix(r.listener.cfg.ChainID), gap.Address, gap.FromBlock),
+		Address:   gap.Address,
+		FromBlock: gap.FromBlock,
+		ToBlock:   gap.ToBlock,
+		OnProgress: func(progress domain.BackfillProgress) {
+			gap.Records = progress.Records
+		},
+	}
+
+	err := r.listener.backfill.Run(ctx, job, rangeWriter{r.listener})
+	if err != nil {
+		err = errors.Wrapf(err, "failed to repair gap from %d to %d", gap.FromBlock, gap.ToBlock)
+		gap.State = domain.GapFailed
+		gap.Error = err.Error()
+	} else {
+		if coverErr := r.store.AddCoveredRange(ctx, gap.Address, gap.Range()); coverErr != nil {
+			r.logger.Warn("Failed to record covered blocks", "error", coverErr, "address", gap.Address)
+		}
+
+		gap.State = domain.GapRepaired
+		gap.RepairedAt = time.Now()
+
+		r.logger.Info("Gap repaired", "address", gap.Address, "from_block", gap.FromBlock, "to_block", gap.ToBlock)
+	}
+
+	if saveErr := r.store.SaveGap(ctx, gap); saveErr != nil {
+		r.logger.Error("Failed to save gap", "error", saveErr, "address", gap.Address)
+	}
+
+	return err
+}
@@ -0,0 +1,241 @@
+package eventlistener
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/infra/backfill"
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/mocks"
+)
+
+func TestGapRepairer_Repair(t *testing.T) {
+	var (
+		logger     = slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))
+		address    = domain.Address("0x123")
+		detectedAt = time.Now().Add(-time.Hour)
+		missed     = []domain.Transaction{{Hash: "0x1", DecimalBlockNumber: 115}}
+		saved      []domain.Gap
+	)
+
+	// the last covered block is the head, there's no tail gap
+	api := mocks.NewEthJSONAPI(t)
+	api.EXPECT().BlockNumber(mock.Anything).Return(149, nil).Once()
+	api.EXPECT().GetLogs(mock.Anything, address, uint64(110), uint64(119)).Return(missed, nil).Once()
+	api.EXPECT().GetLogs(mock.Anything, address, uint64(130), uint64(139)).
+		Return(nil, errors.New("node unavailable")).
+		Once()
+
+	repo := mocks.NewRepositoryWriter(t)
+	repo.EXPECT().ReplaceBlockRange(mock.Anything, address, uint64(110), uint64(119), mock.Anything).
+		RunAndReturn(func(
+			_ context.Context, _ domain.Address, _, _ uint64, got []domain.Transaction,
+		) ([]domain.Transaction, error) {
+			if len(got) != 1 || got[0].ChainID != 10 {
+				t.Errorf("ReplaceBlockRange() = %v, want the missed records stamped with the chain ID", got)
+			}
+			return nil, nil
+		}).
+		Once()
+
+	// the first gap was already detected by a previous run which failed to repair it
+	var (
+		covered = []domain.BlockRange{
+			{FromBlock: 100, ToBlock: 109},
+			{FromBlock: 120, ToBlock: 129},
+			{FromBlock: 140, ToBlock: 149},
+		}
+		known = []domain.Gap{
+			{Address: address, FromBlock: 110, ToBlock: 119, State: domain.GapFailed, DetectedAt: detectedAt},
+		}
+	)
+
+	store := mocks.NewGapStore(t)
+	store.EXPECT().GetCoveredRanges(mock.Anything, address).Return(covered, nil).Once()
+	store.EXPECT().GetGaps(mock.Anything, address).Return(known, nil).Once()
+	store.EXPECT().SaveGap(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, gap domain.Gap) error {
+			saved = append(saved, gap)
+			return nil
+		}).
+		Times(4)
+	store.EXPECT().AddCoveredRange(mock.Anything, address, domain.BlockRange{FromBlock: 110, ToBlock: 119}).
+		Return(nil).
+		Once()
+
+	listener := NewPoolingEventListener(
+		context.Background(),
+		api,
+		repo,
+		WithLogger(logger),
+		WithConfig(&Config{ChainID: 10}),
+		WithBackfill(backfill.NewEngine(api, backfill.WithConfig(&backfill.Config{Workers: 1, ChunkSize: 10}))),
+	)
+
+	r := NewGapRepairer(listener, store, WithGapRepairerLogger(logger))
+
+	if err := r.Repair(context.Background(), address); err == nil {
+		t.Fatalf("Repair() error = nil, want the failure of the second gap")
+	}
+
+	states := []domain.GapState{domain.GapRepairing, domain.GapRepaired, domain.GapRepairing, domain.GapFailed}
+
+	for i, gap := range saved {
+		if gap.State != states[i] {
+			t.Fatalf("SaveGap() = %+v, want the states %v", saved, states)
+		}
+	}
+
+	repaired := saved[1]
+	if !repaired.DetectedAt.Equal(detectedAt) || repaired.Records != 1 || repaired.RepairedAt.IsZero() {
+		t.Errorf("repaired gap = %+v, want 1 record repaired, detected by the previous run", repaired)
+	}
+
+	if failed := saved[3]; failed.FromBlock != 130 || failed.ToBlock != 139 || failed.Error == "" {
+		t.Errorf("failed gap = %+v, want the blocks 130 to 139 failed", failed)
+	}
+}
+
+func TestGapRepairer_RepairTail(t *testing.T) {
+	var (
+		logger  = slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))
+		address = domain.Address("0x123")
+		polled  = make(chan struct{}, 1)
+	)
+
+	// the filter expires on its first poll, and can't be reinstalled: the blocks up to the confirmed head are a gap
+	api := mocks.NewEthJSONAPI(t)
+	api.EXPECT().NewFilter(mock.Anything, address).Return("0x1", nil).Once()
+	api.EXPECT().NewFilter(mock.Anything, address).Return("", errors.New("node unavailable")).Once()
+	api.EXPECT().FetchTransactions(mock.Anything, "0x1").
+		RunAndReturn(func(context.Context, string) ([]domain.Transaction, error) {
+			polled <- struct{}{}
+			return nil, errors.Wrap(domain.ErrFilterNotFound, "filter not found")
+		}).
+		Once()
+	api.EXPECT().BlockNumber(mock.Anything).Return(130, nil).Once()
+	api.EXPECT().GetLogs(mock.Anything, address, uint64(110), uint64(125)).Return([]domain.Transaction{}, nil).Once()
+	api.EXPECT().RemoveFilter(mock.Anything, "0x1").Return(nil).Once()
+
+	repo := mocks.NewRepositoryWriter(t)
+	repo.EXPECT().ReplaceBlockRange(mock.Anything, address, uint64(110), uint64(125), mock.Anything).
+		Return(nil, nil).
+		Once()
+
+	store := mocks.NewGapStore(t)
+	store.EXPECT().GetCoveredRanges(mock.Anything, address).
+		Return([]domain.BlockRange{{FromBlock: 100, ToBlock: 109}}, nil).
+		Once()
+	store.EXPECT().GetGaps(mock.Anything, address).Return(nil, nil).Once()
+	store.EXPECT().SaveGap(mock.Anything, mock.Anything).Return(nil).Twice()
+	store.EXPECT().AddCoveredRange(mock.Anything, address, domain.BlockRange{FromBlock: 110, ToBlock: 125}).
+		Return(nil).
+		Once()
+
+	listener := NewPoolingEventListener(
+		context.Background(),
+		api,
+		repo,
+		WithLogger(logger),
+		WithConfig(&Config{PoolingTime: time.Hour, Confirmations: 5}),
+		WithBackfill(backfill.NewEngine(api, backfill.WithConfig(&backfill.Config{Workers: 1, ChunkSize: 100}))),
+	)
+
+	if err := listener.Listen(context.Background(), address); err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+
+	listener.NewHead(context.Background(), 130)
+	<-polled
+
+	// the filter is reinstalled once its poll is over
+	for deadline := time.Now().Add(time.Second); listener.liveFrom(address) != 0 || !isErrored(listener, address); {
+		if time.Now().After(deadline) {
+			t.Fatalf("the subscription isn't errored after its filter expired")
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	r := NewGapRepairer(listener, store, WithGapRepairerLogger(logger))
+
+	if err := r.Repair(context.Background(), address); err != nil {
+		t.Fatalf("Repair() error = %v", err)
+	}
+
+	if err := listener.Unsubscribe(context.Background(), address); err != nil {
+		t.Fatalf("Unsubscribe() error = %v", err)
+	}
+}
+
+func TestGapRepairer_RepairFromStart(t *testing.T) {
+	var (
+		logger  = slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))
+		address = domain.Address("0x123")
+	)
+
+	// the subscription starts at block 100 while the coverage begins at 110: the blocks in between are a gap
+	api := mocks.NewEthJSONAPI(t)
+	api.EXPECT().NewFilter(mock.Anything, address).Return("0x1", nil).Once()
+	api.EXPECT().BlockNumber(mock.Anything).Return(125, nil).Once()
+	api.EXPECT().GetLogs(mock.Anything, address, uint64(100), uint64(109)).Return([]domain.Transaction{}, nil).Once()
+	api.EXPECT().GetLogs(mock.Anything, address, uint64(120), uint64(125)).Return([]domain.Transaction{}, nil).Once()
+	api.EXPECT().RemoveFilter(mock.Anything, "0x1").Return(nil).Once()
+
+	repo := mocks.NewRepositoryWriter(t)
+	repo.EXPECT().ReplaceBlockRange(mock.Anything, address, uint64(100), uint64(109), mock.Anything).
+		Return(nil, nil).
+		Once()
+	repo.EXPECT().ReplaceBlockRange(mock.Anything, address, uint64(120), uint64(125), mock.Anything).
+		Return(nil, nil).
+		Once()
+
+	store := mocks.NewGapStore(t)
+	store.EXPECT().GetCoveredRanges(mock.Anything, address).
+		Return([]domain.BlockRange{{FromBlock: 110, ToBlock: 119}}, nil).
+		Once()
+	store.EXPECT().GetGaps(mock.Anything, address).Return(nil, nil).Once()
+	store.EXPECT().SaveGap(mock.Anything, mock.Anything).Return(nil).Times(4)
+	store.EXPECT().AddCoveredRange(mock.Anything, address, domain.BlockRange{FromBlock: 100, ToBlock: 109}).
+		Return(nil).
+		Once()
+	store.EXPECT().AddCoveredRange(mock.Anything, address, domain.BlockRange{FromBlock: 120, ToBlock: 125}).
+		Return(nil).
+		Once()
+
+	listener := NewPoolingEventListener(
+		context.Background(),
+		api,
+		repo,
+		WithLogger(logger),
+		WithConfig(&Config{PoolingTime: time.Hour}),
+		WithBackfill(backfill.NewEngine(api, backfill.WithConfig(&backfill.Config{Workers: 1, ChunkSize: 100}))),
+	)
+
+	listener.NewHead(context.Background(), 100)
+
+	if err := listener.Listen(context.Background(), address); err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+
+	r := NewGapRepairer(listener, store, WithGapRepairerLogger(logger))
+
+	if err := r.Repair(context.Background(), address); err != nil {
+		t.Fatalf("Repair() error = %v", err)
+	}
+
+	if err := listener.Unsubscribe(context.Background(), address); err != nil {
+		t.Fatalf("Unsubscribe() error = %v", err)
+	}
+}
+
+func isErrored(listener *PoolingEventListener, address domain.Address) bool {
+	sub, err := listener.Subscription(address)
+	return err == nil && sub.State == domain.SubscriptionErrored
+}
@@ -0,0 +1,117 @@
+package eventlistener
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+type HeadAPI interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+}
+
+// HeadListener is notified of every new head, e.g. to poll the subscriptions as soon as a block is produced.
+type HeadListener interface {
+	// NewHead handles the new head, returning at the latest once the context is done.
+	NewHead(ctx context.Context, head uint64)
+}
+
+type HeadTrackerOptions func(*HeadTracker)
+
+func WithHeadTrackerConfig(cfg *Config) HeadTrackerOptions {
+	return func(h *HeadTracker) {
+		h.cfg = cfg
+	}
+}
+
+func WithHeadTrackerLogger(l *slog.Logger) HeadTrackerOptions {
+	return func(h *HeadTracker) {
+		h.logger = l
+	}
+}
+
+// WithHeadListeners registers listeners to notify of the new heads, once published.
+func WithHeadListeners(listeners ...HeadListener) HeadTrackerOptions {
+	return func(h *HeadTracker) {
+		h.listeners = append(h.listeners, listeners...)
+	}
+}
+
+// HeadTracker follows the chain head, publishing an event for every new block.
+type HeadTracker struct {
+	logger     *slog.Logger
+	cfg        *Config
+	api        HeadAPI
+	publishers []EventPublisher
+	listeners  []HeadListener
+	lastBlock  uint64
+}
+
+func NewHeadTracker(api HeadAPI, publishers []EventPublisher, opts ...HeadTrackerOptions) *HeadTracker {
+	h := &HeadTracker{
+		logger:     slog.Default(),
+		cfg:        &Config{PoolingTime: defaultPoolingTime},
+		api:        api,
+		publishers: publishers,
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// Run publishes the new heads until the context is done.
+func (h *HeadTracker) Run(ctx context.Context) error {
+	ticker := time.NewTicker(h.cfg.PoolingTime)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case <-ticker.C:
+			if err := h.publishNewHead(ctx); err != nil {
+				h.logger.Error("Failed to track head", "error", err)
+			}
+		}
+	}
+}
+
+func (h *HeadTracker) publishNewHead(ctx context.Context) error {
+	head, err := h.api.BlockNumber(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch block number")
+	}
+
+	// skipped blocks aren't published one by one, consumers only care about the latest head
+	if head <= h.lastBlock {
+		return nil
+	}
+
+	var events = []domain.Event{domain.NewHeadEvent(h.cfg.ChainID, head, time.Now())}
+
+	for _, publisher := range h.publishers {
+		if err = publisher.Publish(ctx, events); err != nil {
+			return errors.Wrap(err, "failed to publish head")
+		}
+	}
+
+	h.lastBlock = head
+
+	// the listeners are given until the next head is due, not to hold back the tracker
+	ctx, cancel := context.WithTimeout(ctx, h.cfg.PoolingTime)
+	defer cancel()
+
+	for _, listener := range h.listeners {
+		listener.NewHead(ctx, head)
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package eventlistener
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/mocks"
+)
+
+func TestHeadTracker_Run(t *testing.T) {
+	var (
+		logger  = slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))
+		chainID = domain.ChainID(8453)
+	)
+
+	api := mocks.NewHeadAPI(t)
+	api.EXPECT().BlockNumber(mock.Anything).Return(uint64(10), nil).Once()
+	api.EXPECT().BlockNumber(mock.Anything).Return(uint64(10), nil).Once()
+	api.EXPECT().BlockNumber(mock.Anything).Return(0, errors.New("connection refused")).Once()
+	api.EXPECT().BlockNumber(mock.Anything).Return(uint64(12), nil)
+
+	var heads []uint64
+
+	// the same head is published once only
+	publisher := mocks.NewEventPublisher(t)
+	publisher.EXPECT().Publish(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, events []domain.Event) error {
+			for _, v := range events {
+				if v.Type != domain.EventNewHead || v.ChainID != chainID {
+					t.Errorf("unexpected event %v", v)
+				}
+				heads = append(heads, v.BlockNumber)
+			}
+			return nil
+		}).
+		Twice()
+
+	listener := mocks.NewHeadListener(t)
+	listener.EXPECT().NewHead(mock.Anything, uint64(10)).Once()
+	listener.EXPECT().NewHead(mock.Anything, uint64(12)).Once()
+
+	h := NewHeadTracker(
+		api,
+		[]EventPublisher{publisher},
+		WithHeadTrackerLogger(logger),
+		WithHeadListeners(listener),
+		WithHeadTrackerConfig(&Config{PoolingTime: time.Millisecond * 10, ChainID: chainID}),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*55)
+	defer cancel()
+
+	if err := h.Run(ctx); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if len(heads) != 2 || heads[0] != 10 || heads[1] != 12 {
+		t.Errorf("published heads = %v, want [10 12]", heads)
+	}
+}
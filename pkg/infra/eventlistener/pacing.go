@@ -0,0 +1,192 @@
+package eventlistener
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+const (
+	// defaultBackoffFactor bounds the backoff of the polls to this many pooling times, when no maximum is configured
+	defaultBackoffFactor = 8
+	backoffMultiplier    = 2
+)
+
+// pacer paces the polls of a subscription: while the head is tracked, polls follow the new heads and back off, up to a
+// ceiling, while no new block appears. Without any head tracked, polls happen on a fixed interval.
+type pacer struct {
+	base    time.Duration
+	ceiling time.Duration
+	// throttle is the minimum time between two polls requested by the subscription, zero when none
+	throttle time.Duration
+	interval time.Duration
+	lastPoll time.Time
+	lastHead uint64
+	// effective is the time measured between the last two polls, the base interval until then
+	effective time.Duration
+}
+
+func newPacer(cfg *Config, polling domain.SubscriptionPolling) *pacer {
+	p := &pacer{base: cfg.PoolingTime, throttle: polling.Interval}
+
+	if p.base <= 0 {
+		p.base = defaultPoolingTime
+	}
+
+	if polling.Interval > 0 {
+		p.base = polling.Interval
+	}
+
+	maxInterval := cfg.MaxPoolingTime
+	if maxInterval <= 0 {
+		maxInterval = defaultBackoffFactor * p.base
+	}
+
+	switch polling.Priority {
+	case domain.PriorityHigh:
+		p.ceiling = p.base
+	case domain.PriorityLow:
+		p.ceiling = backoffMultiplier * maxInterval
+	default:
+		p.ceiling = maxInterval
+	}
+
+	if p.ceiling < p.base {
+		p.ceiling = p.base
+	}
+
+	p.interval = p.base
+	p.effective = p.base
+
+	return p
+}
+
+// polled records a poll at the given time, the head being the latest block known, zero when it isn't tracked.
+// It returns the time to wait before the next poll, unless a new head comes first.
+func (p *pacer) polled(now time.Time, head uint64) time.Duration {
+	if !p.lastPoll.IsZero() {
+		p.effective = now.Sub(p.lastPoll)
+	}
+
+	p.lastPoll = now
+
+	switch {
+	case head == 0:
+		p.interval = p.base
+	case head > p.lastHead:
+		p.lastHead = head
+		p.interval = p.base
+	default:
+		p.interval = min(backoffMultiplier*p.interval, p.ceiling)
+	}
+
+	return p.interval
+}
+
+// throttled tells whether a poll triggered by a new head must be skipped, the subscription interval not being elapsed
+// since the last poll.
+func (p *pacer) throttled(now time.Time) bool {
+	return p.throttle > 0 && now.Sub(p.lastPoll) < p.throttle
+}
+
+// NewHead polls every active subscription on a new head, one after the other, the higher priorities first.
+// It returns once they're all polled, or once the context is done: past its deadline the subscriptions left are polled
+// without being waited for. Heads not higher than the last one are ignored.
+func (e *PoolingEventListener) NewHead(ctx context.Context, head uint64) {
+	e.mu.Lock()
+
+	if head <= e.head {
+		e.mu.Unlock()
+		return
+	}
+
+	e.head = head
+
+	var handles = make([]*poolingHandle, 0, len(e.subscriptions))
+
+	for _, sub := range e.subscriptionsByPriority() {
+		if sub.handle != nil {
+			handles = append(handles, sub.handle)
+		}
+	}
+
+	e.mu.Unlock()
+
+	for i, handle := range handles {
+		polled := make(chan struct{})
+
+		// a poll already due covers the new head as well
+		select {
+		case handle.heads <- polled:
+		default:
+			continue
+		}
+
+		select {
+		case <-polled:
+		case <-handle.done:
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				triggerPolls(handles[i+1:])
+			}
+
+			return
+		}
+	}
+}
+
+// triggerPolls asks the given subscriptions to poll, without waiting for them.
+func triggerPolls(handles []*poolingHandle) {
+	for _, handle := range handles {
+		select {
+		case handle.heads <- make(chan struct{}):
+		default:
+		}
+	}
+}
+
+// subscriptionsByPriority returns the subscriptions in polling order, the lock being held.
+func (e *PoolingEventListener) subscriptionsByPriority() []*subscription {
+	var subscriptions = make([]*subscription, 0, len(e.subscriptions))
+
+	for _, sub := range e.subscriptions {
+		subscriptions = append(subscriptions, sub)
+	}
+
+	sort.SliceStable(subscriptions, func(i, j int) bool {
+		return subscriptions[i].Polling.Priority.Rank() < subscriptions[j].Polling.Priority.Rank()
+	})
+
+	return subscriptions
+}
+
+// paced records the poll of the address in its subscription, returning the time to wait before the next poll.
+func (e *PoolingEventListener) paced(address domain.Address, pace *pacer, now time.Time) time.Duration {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	next := pace.polled(now, e.head)
+
+	if sub, ok := e.subscriptions[address]; ok {
+		sub.Polling.EffectiveInterval = pace.effective
+		sub.Polling.LastPolledAt = now
+	}
+
+	return next
+}
+
+// resetTimer waits the given duration from now on, whether the timer fired or not.
+func resetTimer(timer *time.Timer, d time.Duration) {
+	if !timer.Stop() {
+		select {
+		case <-timer.C:
+		default:
+		}
+	}
+
+	timer.Reset(d)
+}
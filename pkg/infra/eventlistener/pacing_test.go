@@ -0,0 +1,292 @@
+package eventlistener
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/mocks"
+)
+
+func TestPacer_Polled(t *testing.T) {
+	var (
+		cfg = &Config{PoolingTime: time.Second, MaxPoolingTime: 4 * time.Second}
+		now = time.Now()
+	)
+
+	tests := []struct {
+		name    string
+		polling domain.SubscriptionPolling
+		heads   []uint64
+		want    []time.Duration
+	}{
+		{
+			name:    "should poll on a fixed interval while the head isn't tracked",
+			polling: domain.SubscriptionPolling{Priority: domain.PriorityNormal},
+			heads:   []uint64{0, 0, 0},
+			want:    []time.Duration{time.Second, time.Second, time.Second},
+		},
+		{
+			name:    "should back off while no new head appears, up to the maximum",
+			polling: domain.SubscriptionPolling{Priority: domain.PriorityNormal},
+			heads:   []uint64{10, 10, 10, 10, 11},
+			want:    []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 4 * time.Second, time.Second},
+		},
+		{
+			name:    "should never back off a high priority subscription",
+			polling: domain.SubscriptionPolling{Priority: domain.PriorityHigh},
+			heads:   []uint64{10, 10, 10},
+			want:    []time.Duration{time.Second, time.Second, time.Second},
+		},
+		{
+			name:    "should back off a low priority subscription twice as far",
+			polling: domain.SubscriptionPolling{Priority: domain.PriorityLow},
+			heads:   []uint64{10, 10, 10, 10, 10},
+			want:    []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 8 * time.Second},
+		},
+		{
+			name:    "should start backing off from the interval of the subscription",
+			polling: domain.SubscriptionPolling{Interval: 3 * time.Second, Priority: domain.PriorityNormal},
+			heads:   []uint64{10, 10, 10},
+			want:    []time.Duration{3 * time.Second, 4 * time.Second, 4 * time.Second},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newPacer(cfg, tt.polling)
+
+			for i, head := range tt.heads {
+				if got := p.polled(now.Add(time.Duration(i)*time.Second), head); got != tt.want[i] {
+					t.Fatalf("polled() #%d = %s, want %s", i, got, tt.want[i])
+				}
+			}
+
+			if p.effective != time.Second {
+				t.Errorf("effective interval = %s, want the time between the last two polls", p.effective)
+			}
+		})
+	}
+}
+
+func TestPacer_Throttled(t *testing.T) {
+	var (
+		now = time.Now()
+		p   = newPacer(&Config{PoolingTime: time.Second}, domain.SubscriptionPolling{Interval: time.Minute})
+	)
+
+	p.polled(now, 10)
+
+	if !p.throttled(now.Add(time.Second)) {
+		t.Errorf("throttled() = false, want the new head skipped within the interval of the subscription")
+	}
+
+	if p.throttled(now.Add(time.Minute)) {
+		t.Errorf("throttled() = true, want the new head polled once the interval elapsed")
+	}
+}
+
+func TestPoolingEventListener_NewHead(t *testing.T) {
+	var (
+		logger  = slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))
+		address = domain.Address("0x123")
+		polled  = make(chan struct{}, 1)
+	)
+
+	api := mocks.NewEthJSONAPI(t)
+	api.EXPECT().NewFilter(mock.Anything, address).Return("0x1", nil).Once()
+	api.EXPECT().FetchTransactions(mock.Anything, "0x1").
+		RunAndReturn(func(context.Context, string) ([]domain.Transaction, error) {
+			polled <- struct{}{}
+			return []domain.Transaction{}, nil
+		}).
+		Once()
+	api.EXPECT().RemoveFilter(mock.Anything, "0x1").Return(nil).Once()
+
+	e := NewPoolingEventListener(
+		context.Background(),
+		api,
+		mocks.NewRepositoryWriter(t),
+		WithLogger(logger),
+		WithConfig(&Config{PoolingTime: time.Hour}),
+	)
+
+	err := e.Listen(context.Background(), address, domain.WithPriority("urgent"))
+	if !errors.Is(err, domain.ErrInvalidPolling) {
+		t.Fatalf("Listen() error = %v, want ErrInvalidPolling", err)
+	}
+
+	if err = e.Listen(context.Background(), address, domain.WithPriority(domain.PriorityHigh)); err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+
+	// the interval is an hour, the new head only triggers the poll
+	e.NewHead(context.Background(), 10)
+
+	select {
+	case <-polled:
+	case <-time.After(time.Second):
+		t.Fatalf("the new head didn't trigger a poll")
+	}
+
+	// the head being known already, no poll is triggered
+	e.NewHead(context.Background(), 10)
+
+	if err = e.Unsubscribe(context.Background(), address); err != nil {
+		t.Fatalf("Unsubscribe() error = %v", err)
+	}
+
+	sub, err := e.Subscription(address)
+	if err != nil {
+		t.Fatalf("Subscription() error = %v", err)
+	}
+
+	if sub.Polling.Priority != domain.PriorityHigh || sub.Polling.LastPolledAt.IsZero() {
+		t.Errorf("Subscription() polling = %+v, want a high priority polled once", sub.Polling)
+	}
+
+	if sub.Polling.EffectiveInterval != time.Hour {
+		t.Errorf("Subscription() effective interval = %s, want the pooling time", sub.Polling.EffectiveInterval)
+	}
+}
+
+func TestPoolingEventListener_NewHeadPollsByPriority(t *testing.T) {
+	var (
+		logger      = slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))
+		mu          sync.Mutex
+		polled      []string
+		priorities  = []domain.SubscriptionPriority{domain.PriorityLow, domain.PriorityNormal, domain.PriorityHigh}
+		subscribers = make(map[domain.Address]domain.SubscriptionPriority)
+	)
+
+	api := mocks.NewEthJSONAPI(t)
+
+	for i, priority := range priorities {
+		address := domain.Address(fmt.Sprintf("0x%d", i+1))
+		subscribers[address] = priority
+
+		api.EXPECT().NewFilter(mock.Anything, address).Return(string(priority), nil).Once()
+		api.EXPECT().FetchTransactions(mock.Anything, string(priority)).
+			RunAndReturn(func(_ context.Context, filter string) ([]domain.Transaction, error) {
+				// a slower poll of a higher priority still comes first
+				time.Sleep(time.Millisecond * 10 * time.Duration(i+1))
+
+				mu.Lock()
+				polled = append(polled, filter)
+				mu.Unlock()
+
+				return []domain.Transaction{}, nil
+			}).
+			Once()
+		api.EXPECT().RemoveFilter(mock.Anything, string(priority)).Return(nil).Once()
+	}
+
+	e := NewPoolingEventListener(
+		context.Background(),
+		api,
+		mocks.NewRepositoryWriter(t),
+		WithLogger(logger),
+		WithConfig(&Config{PoolingTime: time.Hour}),
+	)
+
+	for address, priority := range subscribers {
+		if err := e.Listen(context.Background(), address, domain.WithPriority(priority)); err != nil {
+			t.Fatalf("Listen() error = %v", err)
+		}
+	}
+
+	// the polls are over once the new head is handled
+	e.NewHead(context.Background(), 10)
+
+	mu.Lock()
+	got := append([]string(nil), polled...)
+	mu.Unlock()
+
+	if want := []string{"high", "normal", "low"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("polls = %v, want %v", got, want)
+	}
+
+	for address := range subscribers {
+		if err := e.Unsubscribe(context.Background(), address); err != nil {
+			t.Fatalf("Unsubscribe() error = %v", err)
+		}
+	}
+}
+
+func TestPoolingEventListener_NewHeadDeadline(t *testing.T) {
+	var (
+		logger  = slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))
+		stuck   = domain.Address("0x1")
+		other   = domain.Address("0x2")
+		release = make(chan struct{})
+		polled  = make(chan struct{}, 1)
+	)
+
+	// the poll of the high priority subscription hangs until released
+	api := mocks.NewEthJSONAPI(t)
+	api.EXPECT().NewFilter(mock.Anything, stuck).Return("0x1", nil).Once()
+	api.EXPECT().NewFilter(mock.Anything, other).Return("0x2", nil).Once()
+	api.EXPECT().FetchTransactions(mock.Anything, "0x1").
+		RunAndReturn(func(context.Context, string) ([]domain.Transaction, error) {
+			<-release
+			return []domain.Transaction{}, nil
+		}).
+		Once()
+	api.EXPECT().FetchTransactions(mock.Anything, "0x2").
+		RunAndReturn(func(context.Context, string) ([]domain.Transaction, error) {
+			polled <- struct{}{}
+			return []domain.Transaction{}, nil
+		}).
+		Once()
+	api.EXPECT().RemoveFilter(mock.Anything, "0x1").Return(nil).Once()
+	api.EXPECT().RemoveFilter(mock.Anything, "0x2").Return(nil).Once()
+
+	e := NewPoolingEventListener(
+		context.Background(),
+		api,
+		mocks.NewRepositoryWriter(t),
+		WithLogger(logger),
+		WithConfig(&Config{PoolingTime: time.Hour}),
+	)
+
+	if err := e.Listen(context.Background(), stuck, domain.WithPriority(domain.PriorityHigh)); err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+
+	if err := e.Listen(context.Background(), other, domain.WithPriority(domain.PriorityLow)); err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+
+	// the head isn't held up by the stuck poll, and the next subscription is polled anyway
+	start := time.Now()
+	e.NewHead(ctx, 10)
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("NewHead() returned after %s, want the deadline of the head", elapsed)
+	}
+
+	select {
+	case <-polled:
+	case <-time.After(time.Second):
+		t.Fatalf("the subscription after the stuck one wasn't polled")
+	}
+
+	close(release)
+
+	for _, address := range []domain.Address{stuck, other} {
+		if err := e.Unsubscribe(context.Background(), address); err != nil {
+			t.Fatalf("Unsubscribe() error = %v", err)
+		}
+	}
+}
@@ -0,0 +1,274 @@
+package eventlistener
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/infra/backfill"
+)
+
+// Pause stops pooling the address without losing its cursor: the records fetched since the last poll are stored, the
+// filter is uninstalled and the subscription is paused until Resume is called.
+func (e *PoolingEventListener) Pause(ctx context.Context, address domain.Address) error {
+	e.mu.Lock()
+
+	sub, ok := e.subscriptions[address]
+
+	switch {
+	case !ok || sub.IsStopped():
+		e.mu.Unlock()
+		return domain.ErrNotSubscribed
+	case sub.handle == nil || !sub.State.CanTransition(domain.SubscriptionPaused):
+		e.mu.Unlock()
+		return errors.Wrapf(domain.ErrInvalidTransition, "from %s to %s", sub.State, domain.SubscriptionPaused)
+	}
+
+	handle := sub.handle
+	sub.handle = nil
+
+	e.mu.Unlock()
+
+	handle.ctx = ctx
+	handle.pause = true
+	close(handle.stop)
+
+	select {
+	case <-handle.done:
+		return handle.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// pause polls the filter one last time and uninstalls it, moving the cursor of the subscription to the last confirmed
+// block. On failure the cursor is kept, the missed records being caught up on resume.
+func (e *PoolingEventListener) pause(
+	ctx context.Context,
+	address domain.Address,
+	filter string,
+	pending []domain.Transaction,
+) error {
+	head, headErr := e.api.BlockNumber(ctx)
+	if headErr != nil {
+		e.logger.Warn("Failed to fetch block number on pause", "error", headErr, "address", address)
+	}
+
+	_, err := e.poll(ctx, address, filter, pending)
+	if err != nil {
+		err = errors.Wrap(err, "failed to flush pending records")
+	}
+
+	if removeErr := e.api.RemoveFilter(ctx, filter); removeErr != nil {
+		e.logger.Error("Failed to remove filter", "error", removeErr, "address", address)
+
+		if err == nil {
+			err = errors.Wrap(removeErr, "failed to remove filter")
+		}
+	}
+
+	e.mu.Lock()
+
+	sub, ok := e.subscriptions[address]
+	if !ok || sub.filter != filter {
+		e.mu.Unlock()
+		return err
+	}
+
+	var covered *domain.BlockRange
+
+	if headErr == nil && err == nil && head > e.cfg.Confirmations && head-e.cfg.Confirmations > sub.LastBlock {
+		sub.LastBlock = head - e.cfg.Confirmations
+	}
+
+	if err == nil && sub.coverFrom > 0 && sub.coverFrom <= sub.LastBlock {
+		covered = &domain.BlockRange{FromBlock: sub.coverFrom, ToBlock: sub.LastBlock}
+	}
+
+	sub.filter = ""
+	sub.coverFrom = 0
+	e.transition(sub, domain.SubscriptionPaused, err)
+
+	e.logger.Info("Paused pooling", "address", address, "last_block", sub.LastBlock)
+
+	e.mu.Unlock()
+
+	if covered != nil {
+		e.cover(ctx, address, *covered)
+	}
+
+	return err
+}
+
+// Resume catches up the records of a paused subscription since its cursor, then returns to live polling. A new filter
+// is installed first, so that no record is missed in between. Concurrent calls for the same address share the same
+// attempt.
+func (e *PoolingEventListener) Resume(ctx context.Context, address domain.Address) error {
+	e.mu.Lock()
+
+	if e.closed {
+		e.mu.Unlock()
+		return domain.ErrListenerClosed
+	}
+
+	if inFlight, ok := e.attempts[address]; ok {
+		e.mu.Unlock()
+		return inFlight.wait(ctx)
+	}
+
+	sub, ok := e.subscriptions[address]
+
+	switch {
+	case !ok || sub.IsStopped():
+		e.mu.Unlock()
+		return domain.ErrNotSubscribed
+	case sub.State != domain.SubscriptionPaused:
+		e.mu.Unlock()
+		return errors.Wrapf(domain.ErrInvalidTransition, "from %s to %s", sub.State, domain.SubscriptionBackfilling)
+	}
+
+	attempt := &subscribeAttempt{done: make(chan struct{})}
+
+	e.attempts[address] = attempt
+	e.transition(sub, domain.SubscriptionBackfilling, nil)
+	e.running.Add(1)
+
+	fromBlock := sub.LastBlock + 1
+	if sub.LastBlock == 0 {
+		// nothing was indexed yet, catching up from where the subscription started, or from the head when unknown
+		fromBlock = sub.startBlock
+	}
+
+	e.mu.Unlock()
+
+	attempt.err = e.resume(ctx, sub, fromBlock)
+	close(attempt.done)
+
+	return attempt.err
+}
+
+// resume catches up the blocks since fromBlock, zero meaning the head, before pooling a new filter: the confirmed ones
+// through the backfill engine, by chunks, and the ones still waiting for confirmations through a single eth_getLogs,
+// their records being left pending until confirmed.
+func (e *PoolingEventListener) resume(ctx context.Context, sub *subscription, fromBlock uint64) error {
+	defer e.running.Done()
+
+	filter, err := e.api.NewFilter(ctx, sub.Address)
+	if err != nil {
+		return e.abortResume(ctx, sub, "", errors.Wrap(err, "failed to create filter"))
+	}
+
+	head, err := e.api.BlockNumber(ctx)
+	if err != nil {
+		return e.abortResume(ctx, sub, filter, errors.Wrap(err, "failed to fetch block number"))
+	}
+
+	if fromBlock == 0 {
+		fromBlock = head
+	}
+
+	confirmed, err := e.catchUp(ctx, sub.Address, fromBlock, head)
+	if err != nil {
+		return e.abortResume(ctx, sub, filter, err)
+	}
+
+	var missed []domain.Transaction
+
+	if unconfirmed := max(fromBlock, confirmed+1); unconfirmed <= head {
+		if missed, err = e.api.GetLogs(ctx, sub.Address, unconfirmed, head); err != nil {
+			return e.abortResume(ctx, sub, filter, errors.Wrap(err, "failed to fetch missed logs"))
+		}
+	}
+
+	// the records failing to be stored are retried by the live polling
+	pending, storeErr := e.store(ctx, sub.Address, missed)
+
+	e.mu.Lock()
+	delete(e.attempts, sub.Address)
+
+	if e.closed {
+		e.transition(sub, domain.SubscriptionStopped, nil)
+		e.mu.Unlock()
+
+		if err = e.api.RemoveFilter(ctx, filter); err != nil {
+			e.logger.Error("Failed to remove filter", "error", err, "address", sub.Address)
+		}
+
+		return domain.ErrListenerClosed
+	}
+
+	sub.filter = filter
+	sub.handle = newPoolingHandle()
+
+	if storeErr != nil {
+		e.transition(sub, domain.SubscriptionErrored, storeErr)
+	} else {
+		// the new filter follows the missed logs, covering the blocks after the confirmed ones caught up
+		sub.coverFrom = max(fromBlock, confirmed+1)
+		e.transition(sub, domain.SubscriptionActive, nil)
+	}
+
+	e.running.Add(1)
+	go e.startPooling(sub.Address, filter, sub.handle, e.newPacer(sub), pending)
+
+	e.mu.Unlock()
+
+	e.logger.Info("Resumed pooling", "address", sub.Address, "from_block", fromBlock, "to_block", head)
+
+	return nil
+}
+
+// catchUp indexes the confirmed blocks from fromBlock through the backfill engine, moving the cursor to the last one.
+// It returns the last confirmed block, the interrupted catch up resuming from its checkpoint on the next resume.
+func (e *PoolingEventListener) catchUp(
+	ctx context.Context,
+	address domain.Address,
+	fromBlock, head uint64,
+) (uint64, error) {
+	if head < e.cfg.Confirmations {
+		return 0, nil
+	}
+
+	confirmed := head - e.cfg.Confirmations
+	if fromBlock > confirmed {
+		return confirmed, nil
+	}
+
+	job := backfill.Job{
+		ID:        fmt.Sprintf("resume/%d/%s", e.cfg.ChainID, address),
+		Address:   address,
+		FromBlock: fromBlock,
+		ToBlock:   confirmed,
+	}
+
+	if err := e.backfill.Run(ctx, job, rangeWriter{e}); err != nil {
+		return 0, errors.Wrap(err, "failed to catch up missed logs")
+	}
+
+	if err := e.repo.UpdateLastBlock(ctx, address, confirmed); err != nil {
+		e.logger.Error("Failed to update last block", "error", err)
+	}
+
+	e.advance(address, confirmed)
+
+	return confirmed, nil
+}
+
+// abortResume uninstalls the filter created by a failed resume, if any, moving the subscription back to paused.
+func (e *PoolingEventListener) abortResume(ctx context.Context, sub *subscription, filter string, cause error) error {
+	if filter != "" {
+		if err := e.api.RemoveFilter(ctx, filter); err != nil {
+			e.logger.Error("Failed to remove filter", "error", err, "address", sub.Address)
+		}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	delete(e.attempts, sub.Address)
+	e.transition(sub, domain.SubscriptionPaused, cause)
+
+	return cause
+}
@@ -0,0 +1,254 @@
+package eventlistener
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/infra/backfill"
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/mocks"
+)
+
+func TestPoolingEventListener_PauseResume(t *testing.T) {
+	var (
+		logger  = slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))
+		address = domain.Address("0x123")
+		missed  = []domain.Transaction{
+			{Hash: "0x1", Address: "0x35fa164735182de50811e8e2e824cfb9b6118ac2", DecimalBlockNumber: 105},
+		}
+	)
+
+	api := mocks.NewEthJSONAPI(t)
+	api.EXPECT().NewFilter(mock.Anything, address).Return("0x1", nil).Once()
+	api.EXPECT().NewFilter(mock.Anything, address).Return("0x2", nil).Once()
+	api.EXPECT().FetchTransactions(mock.Anything, mock.Anything).Return([]domain.Transaction{}, nil)
+	api.EXPECT().BlockNumber(mock.Anything).Return(100, nil).Once()
+	api.EXPECT().BlockNumber(mock.Anything).Return(110, nil).Once()
+	api.EXPECT().GetLogs(mock.Anything, address, uint64(101), uint64(110)).Return(missed, nil).Once()
+	api.EXPECT().RemoveFilter(mock.Anything, "0x1").Return(nil).Once()
+	api.EXPECT().RemoveFilter(mock.Anything, "0x2").Return(nil).Once()
+
+	repo := mocks.NewRepositoryWriter(t)
+	repo.EXPECT().ReplaceBlockRange(mock.Anything, address, uint64(101), uint64(110), missed).Return(nil, nil).Once()
+	repo.EXPECT().UpdateLastBlock(mock.Anything, address, uint64(110)).Return(nil).Once()
+
+	e := NewPoolingEventListener(
+		context.Background(),
+		api,
+		repo,
+		WithLogger(logger),
+		WithConfig(&Config{PoolingTime: time.Millisecond * 10}),
+	)
+
+	assertState := func(want domain.SubscriptionState, lastBlock uint64) {
+		t.Helper()
+
+		got, err := e.Subscription(address)
+		if err != nil {
+			t.Fatalf("Subscription() error = %v", err)
+		}
+
+		if got.State != want || got.LastBlock != lastBlock {
+			t.Fatalf("Subscription() = %+v, want %s at block %d", got, want, lastBlock)
+		}
+	}
+
+	if err := e.Pause(context.Background(), address); !errors.Is(err, domain.ErrNotSubscribed) {
+		t.Fatalf("Pause() error = %v, want %v", err, domain.ErrNotSubscribed)
+	}
+
+	if err := e.Listen(context.Background(), address); err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+
+	if err := e.Resume(context.Background(), address); !errors.Is(err, domain.ErrInvalidTransition) {
+		t.Fatalf("Resume() error = %v, want %v", err, domain.ErrInvalidTransition)
+	}
+
+	// the cursor moves to the head once the pending records are flushed
+	if err := e.Pause(context.Background(), address); err != nil {
+		t.Fatalf("Pause() error = %v", err)
+	}
+
+	assertState(domain.SubscriptionPaused, 100)
+
+	if err := e.Pause(context.Background(), address); !errors.Is(err, domain.ErrInvalidTransition) {
+		t.Fatalf("Pause() error = %v, want %v", err, domain.ErrInvalidTransition)
+	}
+
+	if got := e.Addresses(); len(got) != 0 {
+		t.Errorf("Addresses() = %v, want none while paused", got)
+	}
+
+	// the records missed while paused are caught up before pooling the new filter
+	if err := e.Resume(context.Background(), address); err != nil {
+		t.Fatalf("Resume() error = %v", err)
+	}
+
+	assertState(domain.SubscriptionActive, 110)
+
+	if err := e.Close(context.Background()); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+}
+
+func TestPoolingEventListener_UnsubscribePaused(t *testing.T) {
+	var (
+		logger  = slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))
+		address = domain.Address("0x123")
+	)
+
+	api := mocks.NewEthJSONAPI(t)
+	api.EXPECT().NewFilter(mock.Anything, address).Return("0x1", nil).Once()
+	api.EXPECT().FetchTransactions(mock.Anything, "0x1").Return([]domain.Transaction{}, nil)
+	api.EXPECT().BlockNumber(mock.Anything).Return(100, nil).Once()
+	api.EXPECT().RemoveFilter(mock.Anything, "0x1").Return(nil).Once()
+
+	e := NewPoolingEventListener(
+		context.Background(),
+		api,
+		mocks.NewRepositoryWriter(t),
+		WithLogger(logger),
+		WithConfig(&Config{PoolingTime: time.Millisecond * 10}),
+	)
+
+	if err := e.Listen(context.Background(), address); err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+
+	if err := e.Pause(context.Background(), address); err != nil {
+		t.Fatalf("Pause() error = %v", err)
+	}
+
+	// the filter is already uninstalled, the subscription is only removed
+	if err := e.Unsubscribe(context.Background(), address); err != nil {
+		t.Fatalf("Unsubscribe() error = %v", err)
+	}
+
+	if _, err := e.Subscription(address); !errors.Is(err, domain.ErrSubscriptionNotFound) {
+		t.Fatalf("Subscription() error = %v, want %v", err, domain.ErrSubscriptionNotFound)
+	}
+
+	if err := e.Unsubscribe(context.Background(), address); !errors.Is(err, domain.ErrNotSubscribed) {
+		t.Fatalf("Unsubscribe() error = %v, want %v", err, domain.ErrNotSubscribed)
+	}
+
+	if err := e.Close(context.Background()); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+}
+
+func TestPoolingEventListener_ResumeFailure(t *testing.T) {
+	var (
+		logger  = slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))
+		address = domain.Address("0x123")
+	)
+
+	api := mocks.NewEthJSONAPI(t)
+	api.EXPECT().NewFilter(mock.Anything, address).Return("0x1", nil).Once()
+	api.EXPECT().NewFilter(mock.Anything, address).Return("0x2", nil).Once()
+	api.EXPECT().FetchTransactions(mock.Anything, "0x1").Return([]domain.Transaction{}, nil)
+	api.EXPECT().BlockNumber(mock.Anything).Return(100, nil)
+	api.EXPECT().RemoveFilter(mock.Anything, "0x1").Return(nil).Once()
+	api.EXPECT().RemoveFilter(mock.Anything, "0x2").Return(nil).Once()
+
+	e := NewPoolingEventListener(
+		context.Background(),
+		api,
+		mocks.NewRepositoryWriter(t),
+		WithLogger(logger),
+		WithConfig(&Config{PoolingTime: time.Millisecond * 10, Confirmations: 10}),
+	)
+
+	if err := e.Listen(context.Background(), address); err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+
+	if err := e.Pause(context.Background(), address); err != nil {
+		t.Fatalf("Pause() error = %v", err)
+	}
+
+	got, _ := e.Subscription(address)
+	if got.LastBlock != 90 {
+		t.Fatalf("Subscription() = %+v, want the last confirmed block", got)
+	}
+
+	api.EXPECT().GetLogs(mock.Anything, address, uint64(91), uint64(100)).
+		Return(nil, errors.New("node unavailable")).Once()
+
+	// the new filter is uninstalled and the subscription kept paused, along with the error
+	if err := e.Resume(context.Background(), address); err == nil {
+		t.Fatalf("Resume() expected error")
+	}
+
+	got, _ = e.Subscription(address)
+	if got.State != domain.SubscriptionPaused || got.LastBlock != 90 || got.LastError == "" {
+		t.Errorf("Subscription() = %+v, want paused at block 90 with the last error", got)
+	}
+}
+
+func TestPoolingEventListener_ResumeWithoutCursor(t *testing.T) {
+	var (
+		logger  = slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))
+		address = domain.Address("0x123")
+		missed  = []domain.Transaction{{Hash: "0x1", DecimalBlockNumber: 230}, {Hash: "0x2", DecimalBlockNumber: 248}}
+	)
+
+	api := mocks.NewEthJSONAPI(t)
+	api.EXPECT().NewFilter(mock.Anything, address).Return("0x1", nil).Once()
+	api.EXPECT().NewFilter(mock.Anything, address).Return("0x2", nil).Once()
+	api.EXPECT().FetchTransactions(mock.Anything, mock.Anything).Return([]domain.Transaction{}, nil)
+	// the head is unknown on pause, the cursor being left at zero
+	api.EXPECT().BlockNumber(mock.Anything).Return(0, errors.New("node unavailable")).Once()
+	api.EXPECT().BlockNumber(mock.Anything).Return(250, nil)
+	api.EXPECT().RemoveFilter(mock.Anything, mock.Anything).Return(nil).Twice()
+	// the confirmed blocks since the subscription started are caught up by chunks, the others left pending
+	api.EXPECT().GetLogs(mock.Anything, address, uint64(200), uint64(219)).Return(nil, nil).Once()
+	api.EXPECT().GetLogs(mock.Anything, address, uint64(220), uint64(239)).Return(missed[:1], nil).Once()
+	api.EXPECT().GetLogs(mock.Anything, address, uint64(240), uint64(240)).Return(nil, nil).Once()
+	api.EXPECT().GetLogs(mock.Anything, address, uint64(241), uint64(250)).Return(missed[1:], nil).Once()
+
+	repo := mocks.NewRepositoryWriter(t)
+	repo.EXPECT().ReplaceBlockRange(mock.Anything, address, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, nil).
+		Times(3)
+	repo.EXPECT().UpdateLastBlock(mock.Anything, address, uint64(240)).Return(nil).Once()
+
+	e := NewPoolingEventListener(
+		context.Background(),
+		api,
+		repo,
+		WithLogger(logger),
+		WithConfig(&Config{PoolingTime: time.Hour, Confirmations: 10}),
+		WithBackfill(backfill.NewEngine(api, backfill.WithConfig(&backfill.Config{Workers: 1, ChunkSize: 20}))),
+	)
+
+	e.NewHead(context.Background(), 200)
+
+	if err := e.Listen(context.Background(), address); err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+
+	if err := e.Pause(context.Background(), address); err != nil {
+		t.Fatalf("Pause() error = %v", err)
+	}
+
+	if err := e.Resume(context.Background(), address); err != nil {
+		t.Fatalf("Resume() error = %v", err)
+	}
+
+	got, _ := e.Subscription(address)
+	if got.State != domain.SubscriptionActive || got.LastBlock != 240 {
+		t.Errorf("Subscription() = %+v, want active at the last confirmed block", got)
+	}
+
+	if err := e.Unsubscribe(context.Background(), address); err != nil {
+		t.Fatalf("Unsubscribe() error = %v", err)
+	}
+}
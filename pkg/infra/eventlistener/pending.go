@@ -0,0 +1,267 @@
+package eventlistener
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+const (
+	defaultDropTimeout = 10 * time.Minute
+)
+
+type PendingAPI interface {
+	NewPendingTransactionFilter(ctx context.Context) (string, error)
+	FetchPendingTransactionHashes(ctx context.Context, filter string) ([]string, error)
+	GetTransactionsByHash(ctx context.Context, hashes []string) (map[string]domain.PendingTransaction, error)
+	GetTransactionCount(ctx context.Context, address domain.Address) (int64, error)
+	RemoveFilter(ctx context.Context, filter string) error
+}
+
+type PendingRepositoryWriter interface {
+	SavePendingTransactions(ctx context.Context, transactions []domain.PendingTransaction) error
+}
+
+type PendingTrackerOptions func(*PendingTransactionTracker)
+
+func WithPendingTrackerConfig(cfg *PendingConfig) PendingTrackerOptions {
+	return func(p *PendingTransactionTracker) {
+		p.cfg = cfg
+	}
+}
+
+func WithPendingTrackerLogger(l *slog.Logger) PendingTrackerOptions {
+	return func(p *PendingTransactionTracker) {
+		p.logger = l
+	}
+}
+
+type PendingConfig struct {
+	PoolingTime time.Duration
+	ChainID     domain.ChainID
+	// DropTimeout is how long a transaction unknown by the node is kept pending before being considered dropped
+	DropTimeout time.Duration
+}
+
+// PendingTransactionTracker records the mempool transactions touching the subscribed addresses,
+// and transitions them to mined, dropped or replaced.
+type PendingTransactionTracker struct {
+	logger        *slog.Logger
+	cfg           *PendingConfig
+	api           PendingAPI
+	repo          PendingRepositoryWriter
+	subscriptions Subscriptions
+	now           func() time.Time
+
+	// tracked holds the still pending transactions by hash, one record per subscribed address touched
+	tracked map[string][]domain.PendingTransaction
+}
+
+func NewPendingTransactionTracker(
+	api PendingAPI,
+	storage PendingRepositoryWriter,
+	subscriptions Subscriptions,
+	opts ...PendingTrackerOptions,
+) *PendingTransactionTracker {
+	p := &PendingTransactionTracker{
+		logger:        slog.Default(),
+		cfg:           &PendingConfig{PoolingTime: defaultPoolingTime, DropTimeout: defaultDropTimeout},
+		api:           api,
+		repo:          storage,
+		subscriptions: subscriptions,
+		now:           time.Now,
+		tracked:       make(map[string][]domain.PendingTransaction),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// Run tracks the pending transactions until the context is done.
+func (p *PendingTransactionTracker) Run(ctx context.Context) error {
+	filter, err := p.api.NewPendingTransactionFilter(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to create pending transaction filter")
+	}
+
+	defer func() {
+		if err := p.api.RemoveFilter(context.Background(), filter); err != nil {
+			p.logger.Error("Failed to remove pending transaction filter", "error", err)
+		}
+	}()
+
+	ticker := time.NewTicker(p.cfg.PoolingTime)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case <-ticker.C:
+			if err = p.trackNew(ctx, filter); err != nil {
+				p.logger.Error("Failed to track new pending transactions", "error", err)
+			}
+
+			if err = p.reconcile(ctx); err != nil {
+				p.logger.Error("Failed to reconcile pending transactions", "error", err)
+			}
+		}
+	}
+}
+
+// trackNew stores the new pending transactions touching any subscribed address.
+func (p *PendingTransactionTracker) trackNew(ctx context.Context, filter string) error {
+	hashes, err := p.api.FetchPendingTransactionHashes(ctx, filter)
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch pending transactions")
+	}
+
+	addresses := p.subscriptions.Addresses()
+	if len(hashes) == 0 || len(addresses) == 0 {
+		return nil
+	}
+
+	transactions, err := p.api.GetTransactionsByHash(ctx, hashes)
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch transactions")
+	}
+
+	var (
+		now     = p.now()
+		records []domain.PendingTransaction
+	)
+
+	for _, hash := range hashes {
+		tx, ok := transactions[hash]
+		if !ok {
+			continue
+		}
+
+		for _, address := range addresses {
+			if !tx.Touches(address) {
+				continue
+			}
+
+			record := tx
+			record.ChainID = p.cfg.ChainID
+			record.Address = address
+			record.FirstSeenAt = now
+			record.UpdatedAt = now
+
+			records = append(records, record)
+		}
+	}
+
+	if len(records) == 0 {
+		return nil
+	}
+
+	if err = p.repo.SavePendingTransactions(ctx, records); err != nil {
+		return errors.Wrap(err, "failed to store pending transactions")
+	}
+
+	for _, record := range records {
+		if !record.IsFinal() {
+			p.tracked[record.Hash] = append(p.tracked[record.Hash], record)
+		}
+	}
+
+	return nil
+}
+
+// reconcile transitions the tracked transactions which have been mined, dropped or replaced.
+func (p *PendingTransactionTracker) reconcile(ctx context.Context) error {
+	if len(p.tracked) == 0 {
+		return nil
+	}
+
+	var hashes = make([]string, 0, len(p.tracked))
+
+	for hash := range p.tracked {
+		hashes = append(hashes, hash)
+	}
+
+	transactions, err := p.api.GetTransactionsByHash(ctx, hashes)
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch transactions")
+	}
+
+	var (
+		now     = p.now()
+		updates []domain.PendingTransaction
+	)
+
+	for _, hash := range hashes {
+		records := p.tracked[hash]
+
+		transition, ok := p.transition(ctx, records[0], transactions, now)
+		if !ok {
+			continue
+		}
+
+		for _, record := range records {
+			updates = append(updates, transition(record))
+		}
+	}
+
+	if len(updates) == 0 {
+		return nil
+	}
+
+	if err = p.repo.SavePendingTransactions(ctx, updates); err != nil {
+		return errors.Wrap(err, "failed to store pending transactions")
+	}
+
+	for _, update := range updates {
+		delete(p.tracked, update.Hash)
+	}
+
+	return nil
+}
+
+// transition returns the state transition to apply to the given pending transaction, if any.
+func (p *PendingTransactionTracker) transition(
+	ctx context.Context,
+	pending domain.PendingTransaction,
+	transactions map[string]domain.PendingTransaction,
+	now time.Time,
+) (func(domain.PendingTransaction) domain.PendingTransaction, bool) {
+	if tx, found := transactions[pending.Hash]; found {
+		if tx.Status != domain.PendingStatusMined {
+			return nil, false
+		}
+
+		return func(r domain.PendingTransaction) domain.PendingTransaction {
+			return r.Mined(tx.DecimalBlockNumber, tx.BlockHash, now)
+		}, true
+	}
+
+	// the node no longer knows the transaction, a mined one with the same nonce means it was replaced
+	nonce, err := p.api.GetTransactionCount(ctx, pending.From)
+	if err != nil {
+		p.logger.Error("Failed to fetch transaction count", "error", err, "address", pending.From)
+		return nil, false
+	}
+
+	if nonce > pending.Nonce {
+		return func(r domain.PendingTransaction) domain.PendingTransaction {
+			return r.Replaced(now)
+		}, true
+	}
+
+	if now.Sub(pending.FirstSeenAt) > p.cfg.DropTimeout {
+		return func(r domain.PendingTransaction) domain.PendingTransaction {
+			return r.Dropped(now)
+		}, true
+	}
+
+	return nil, false
+}
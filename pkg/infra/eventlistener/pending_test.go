@@ -0,0 +1,102 @@
+package eventlistener
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/mocks"
+)
+
+func TestPendingTransactionTracker(t *testing.T) {
+	var (
+		logger     = slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))
+		ctx        = context.Background()
+		subscribed = domain.Address("0x35fa164735182de50811e8e2e824cfb9b6118ac2")
+		firstSeen  = time.Unix(1000, 0)
+		later      = firstSeen.Add(time.Minute)
+
+		incoming = domain.PendingTransaction{Hash: "0x1", From: "0xa", To: subscribed, Nonce: 1, Status: domain.PendingStatusPending}
+		outgoing = domain.PendingTransaction{Hash: "0x2", From: subscribed, To: "0xb", Nonce: 7, Status: domain.PendingStatusPending}
+		dropped  = domain.PendingTransaction{Hash: "0x3", From: "0xc", To: subscribed, Nonce: 3, Status: domain.PendingStatusPending}
+		other    = domain.PendingTransaction{Hash: "0x4", From: "0xd", To: "0xe", Nonce: 1, Status: domain.PendingStatusPending}
+	)
+
+	seen := func(tx domain.PendingTransaction) domain.PendingTransaction {
+		tx.Address = subscribed
+		tx.FirstSeenAt = firstSeen
+		tx.UpdatedAt = firstSeen
+		return tx
+	}
+
+	api := mocks.NewPendingAPI(t)
+	api.EXPECT().FetchPendingTransactionHashes(mock.Anything, "0xf").Return([]string{"0x1", "0x2", "0x3", "0x4"}, nil).Once()
+	api.EXPECT().GetTransactionsByHash(mock.Anything, []string{"0x1", "0x2", "0x3", "0x4"}).
+		Return(map[string]domain.PendingTransaction{"0x1": incoming, "0x2": outgoing, "0x3": dropped, "0x4": other}, nil).
+		Once()
+	api.EXPECT().GetTransactionsByHash(mock.Anything, mock.Anything).
+		Return(map[string]domain.PendingTransaction{"0x1": incoming.Mined(10, "0xb10", later)}, nil).
+		Once()
+	api.EXPECT().GetTransactionCount(mock.Anything, subscribed).Return(int64(8), nil).Once()
+	api.EXPECT().GetTransactionCount(mock.Anything, domain.Address("0xc")).Return(int64(3), nil).Once()
+
+	repo := mocks.NewPendingRepositoryWriter(t)
+	repo.EXPECT().SavePendingTransactions(mock.Anything, []domain.PendingTransaction{
+		seen(incoming), seen(outgoing), seen(dropped),
+	}).Return(nil).Once()
+	repo.EXPECT().SavePendingTransactions(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, updates []domain.PendingTransaction) error {
+			var statuses = make(map[string]domain.PendingStatus)
+			for _, v := range updates {
+				statuses[v.Hash] = v.Status
+			}
+
+			if statuses["0x1"] != domain.PendingStatusMined {
+				t.Errorf("expected 0x1 to be mined, got: %v", statuses["0x1"])
+			}
+			if statuses["0x2"] != domain.PendingStatusReplaced {
+				t.Errorf("expected 0x2 to be replaced, got: %v", statuses["0x2"])
+			}
+			if _, ok := statuses["0x3"]; ok {
+				t.Errorf("expected 0x3 to be still pending")
+			}
+
+			return nil
+		}).
+		Once()
+
+	subscriptions := mocks.NewSubscriptions(t)
+	subscriptions.EXPECT().Addresses().Return([]domain.Address{subscribed}).Once()
+
+	tracker := NewPendingTransactionTracker(
+		api,
+		repo,
+		subscriptions,
+		WithPendingTrackerLogger(logger),
+		WithPendingTrackerConfig(&PendingConfig{PoolingTime: time.Second, DropTimeout: time.Hour}),
+	)
+	tracker.now = func() time.Time { return firstSeen }
+
+	if err := tracker.trackNew(ctx, "0xf"); err != nil {
+		t.Fatalf("trackNew() error = %v", err)
+	}
+
+	if len(tracker.tracked) != 3 {
+		t.Fatalf("expected 3 tracked transactions, got: %v", len(tracker.tracked))
+	}
+
+	tracker.now = func() time.Time { return later }
+
+	if err := tracker.reconcile(ctx); err != nil {
+		t.Fatalf("reconcile() error = %v", err)
+	}
+
+	if _, ok := tracker.tracked["0x3"]; !ok || len(tracker.tracked) != 1 {
+		t.Fatalf("expected only 0x3 to be tracked, got: %v", tracker.tracked)
+	}
+}
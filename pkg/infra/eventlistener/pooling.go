@@ -2,13 +2,17 @@ package eventlistener
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/pkg/errors"
 
 	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/infra/backfill"
 )
 
 const (
@@ -16,14 +20,35 @@ const (
 )
 
 type RepositoryWriter interface {
-	Add(ctx context.Context, address string, transactions []domain.Transaction) error
-	UpdateLastBlock(ctx context.Context, address string, blockNumber int64) error
+	Add(ctx context.Context, address domain.Address, transactions []domain.Transaction) error
+	Remove(ctx context.Context, address domain.Address, transactions []domain.Transaction) error
+	UpdateLastBlock(ctx context.Context, address domain.Address, blockNumber uint64) error
+	// ReplaceBlockRange supersedes the stored logs of the address within [fromBlock, toBlock], returning the logs
+	// superseded, the internal transactions being kept
+	ReplaceBlockRange(
+		ctx context.Context,
+		address domain.Address,
+		fromBlock, toBlock uint64,
+		transactions []domain.Transaction,
+	) ([]domain.Transaction, error)
 }
 
 type EthJSONAPI interface {
-	NewFilter(ctx context.Context, address string) (string, error)
+	BlockNumber(ctx context.Context) (uint64, error)
+	NewFilter(ctx context.Context, address domain.Address) (string, error)
 	FetchTransactions(ctx context.Context, filter string) ([]domain.Transaction, error)
 	RemoveFilter(ctx context.Context, address string) error
+	GetLogs(ctx context.Context, address domain.Address, fromBlock, toBlock uint64) ([]domain.Transaction, error)
+}
+
+// Notifier is notified of the transactions stored for an address, e.g. to deliver webhooks.
+type Notifier interface {
+	Notify(ctx context.Context, chainID domain.ChainID, address domain.Address, transactions []domain.Transaction) error
+}
+
+// EventPublisher publishes the events of the records stored and removed, e.g. to stream them.
+type EventPublisher interface {
+	Publish(ctx context.Context, events []domain.Event) error
 }
 
 type Options func(*PoolingEventListener)
@@ -40,19 +65,122 @@ func WithLogger(l *slog.Logger) Options {
 	}
 }
 
+// WithEnrichers registers enrichers to run, in the given order, before transactions are stored.
+func WithEnrichers(enrichers ...Enricher) Options {
+	return func(e *PoolingEventListener) {
+		e.enrichers = append(e.enrichers, enrichers...)
+	}
+}
+
+// WithNotifiers registers notifiers to call once transactions are stored.
+func WithNotifiers(notifiers ...Notifier) Options {
+	return func(e *PoolingEventListener) {
+		e.notifiers = append(e.notifiers, notifiers...)
+	}
+}
+
+// WithBackfill sets the engine of the rescans, fetching their chunks concurrently.
+func WithBackfill(engine *backfill.Engine) Options {
+	return func(e *PoolingEventListener) {
+		e.backfill = engine
+	}
+}
+
+// WithCoverage records the block ranges indexed for each address, for the gaps between them to be repaired.
+func WithCoverage(store CoverageStore) Options {
+	return func(e *PoolingEventListener) {
+		e.coverage = store
+	}
+}
+
+// WithPublishers registers publishers of the events of the records stored and removed.
+func WithPublishers(publishers ...EventPublisher) Options {
+	return func(e *PoolingEventListener) {
+		e.publishers = append(e.publishers, publishers...)
+	}
+}
+
 type Config struct {
 	PoolingTime time.Duration
+	// MaxPoolingTime bounds the backoff of the polls while no new head appears, eight pooling times when zero
+	MaxPoolingTime time.Duration
+	// ChainID is stamped on every record stored
+	ChainID domain.ChainID
+	// Confirmations is the number of blocks to wait on top of a record's block before storing it
+	Confirmations uint64
 }
 
 type PoolingEventListener struct {
-	mu          sync.Mutex
-	ctx         context.Context
-	logger      *slog.Logger
-	cfg         *Config
-	api         EthJSONAPI
-	repo        RepositoryWriter
-	stopPooling map[string]chan struct{}
-	filters     map[string]string
+	mu            sync.Mutex
+	ctx           context.Context
+	logger        *slog.Logger
+	cfg           *Config
+	api           EthJSONAPI
+	repo          RepositoryWriter
+	enrichers     []Enricher
+	notifiers     []Notifier
+	publishers    []EventPublisher
+	backfill      *backfill.Engine
+	coverage      CoverageStore
+	subscriptions map[domain.Address]*subscription
+	attempts      map[domain.Address]*subscribeAttempt
+	// head is the latest block notified through NewHead, zero while the head isn't tracked
+	head uint64
+	// running tracks the subscribe attempts and the pooling goroutines, done once their filter is uninstalled
+	running sync.WaitGroup
+	closed  bool
+	// rescanCtx is the context of the rescans, canceled on Close
+	rescanCtx     context.Context
+	cancelRescans context.CancelFunc
+}
+
+// subscription is the lifecycle of a subscribed address along with its filter and pooling goroutine, guarded by the
+// listener lock. Stopped subscriptions are kept for status queries, until the address is subscribed again.
+type subscription struct {
+	domain.Subscription
+	filter string
+	// handle is nil once the pooling goroutine is asked to stop
+	handle *poolingHandle
+	// coverFrom is the first block covered by the filter, zero until known
+	coverFrom uint64
+	// startBlock is the first block followed since the address was subscribed, zero until known
+	startBlock uint64
+}
+
+type poolingHandle struct {
+	stop chan struct{}
+	done chan struct{}
+	// heads wakes up the pooling goroutine on a new head, the channel received being closed once polled
+	heads chan chan struct{}
+	// ctx bounds the last poll and the filter removal, flush tells whether to poll a last time and pause whether
+	// to pause the subscription instead of stopping it, all being set before stop is closed
+	ctx   context.Context
+	flush bool
+	pause bool
+	// err is the failure of the last poll or of the filter removal, it's set before done is closed
+	err error
+}
+
+func newPoolingHandle() *poolingHandle {
+	return &poolingHandle{stop: make(chan struct{}), done: make(chan struct{}), heads: make(chan chan struct{}, 1)}
+}
+
+// CloseError reports the addresses whose last records couldn't be stored, or whose filter couldn't be uninstalled,
+// on Close.
+type CloseError struct {
+	Failed map[domain.Address]error
+}
+
+func (e *CloseError) Error() string {
+	var failures = make([]string, 0, len(e.Failed))
+
+	for address, err := range e.Failed {
+		failures = append(failures, fmt.Sprintf("%s: %v", address, err))
+	}
+
+	sort.Strings(failures)
+
+	return fmt.Sprintf("failed to close %d subscription(s): %s", len(e.Failed), strings.Join(failures, "; "))
 }
 
 func NewPoolingEventListener(
@@ -62,118 +190,659 @@ func NewPoolingEventListener(
 	opts ...Options,
 ) *PoolingEventListener {
 	e := &PoolingEventListener{
-		ctx:         ctx,
-		logger:      slog.Default(),
-		cfg:         &Config{PoolingTime: defaultPoolingTime},
-		api:         api,
-		repo:        storage,
-		stopPooling: make(map[string]chan struct{}),
-		filters:     make(map[string]string),
+		ctx:           ctx,
+		logger:        slog.Default(),
+		cfg:           &Config{PoolingTime: defaultPoolingTime},
+		api:           api,
+		repo:          storage,
+		subscriptions: make(map[domain.Address]*subscription),
+		attempts:      make(map[domain.Address]*subscribeAttempt),
 	}
 
 	for _, opt := range opts {
 		opt(e)
 	}
 
+	if e.backfill == nil {
+		e.backfill = backfill.NewEngine(api, backfill.WithLogger(e.logger))
+	}
+
+	e.rescanCtx, e.cancelRescans = context.WithCancel(ctx)
+
 	return e
 }
 
-func (e *PoolingEventListener) Listen(ctx context.Context, address string) error {
+// Listen subscribes to the address, creating its filter and pooling it. The node is called without holding the
+// listener lock, concurrent calls for the same address sharing the same attempt and its result.
+func (e *PoolingEventListener) Listen(
+	ctx context.Context,
+	address domain.Address,
+	opts ...domain.SubscribeOption,
+) error {
+	options, err := domain.NewSubscribeOptions(opts...)
+	if err != nil {
+		return err
+	}
+
 	e.mu.Lock()
-	defer e.mu.Unlock()
 
-	if f, ok := e.filters[address]; ok && f != "" {
+	if e.closed {
+		e.mu.Unlock()
+		return domain.ErrListenerClosed
+	}
+
+	if inFlight, ok := e.attempts[address]; ok {
+		e.mu.Unlock()
+		return inFlight.wait(ctx)
+	}
+
+	if sub, ok := e.subscriptions[address]; ok && !sub.IsStopped() {
+		e.mu.Unlock()
 		return domain.ErrAlreadySubscribed
 	}
 
-	filter, err := e.api.NewFilter(ctx, address)
+	sub := &subscription{Subscription: domain.NewSubscription(e.cfg.ChainID, address, time.Now())}
+	sub.Polling = domain.SubscriptionPolling{Interval: options.Interval, Priority: options.Priority}
+	attempt := &subscribeAttempt{done: make(chan struct{})}
+
+	e.subscriptions[address] = sub
+	e.attempts[address] = attempt
+	// Close waits for the attempt, which could otherwise leave a filter installed
+	e.running.Add(1)
+
+	e.mu.Unlock()
+
+	attempt.err = e.subscribe(ctx, sub)
+	close(attempt.done)
+
+	return attempt.err
+}
+
+// subscribe creates the filter of the pending subscription and starts pooling it, unless the listener was closed
+// meanwhile.
+func (e *PoolingEventListener) subscribe(ctx context.Context, sub *subscription) error {
+	defer e.running.Done()
+
+	filter, err := e.api.NewFilter(ctx, sub.Address)
+
+	e.mu.Lock()
+	delete(e.attempts, sub.Address)
+
 	if err != nil {
-		return errors.Wrap(err, "failed to create filter")
+		err = errors.Wrap(err, "failed to create filter")
+		e.transition(sub, domain.SubscriptionStopped, err)
+		e.mu.Unlock()
+
+		return err
+	}
+
+	if e.closed {
+		e.transition(sub, domain.SubscriptionStopped, nil)
+		e.mu.Unlock()
+
+		if err = e.api.RemoveFilter(ctx, filter); err != nil {
+			e.logger.Error("Failed to remove filter", "error", err, "address", sub.Address)
+		}
+
+		return domain.ErrListenerClosed
 	}
 
-	e.filters[address] = filter
+	sub.filter = filter
+	sub.handle = newPoolingHandle()
+	sub.startBlock = e.head
+	e.transition(sub, domain.SubscriptionActive, nil)
 
-	go e.startPooling(address, filter)
+	e.running.Add(1)
+	go e.startPooling(sub.Address, filter, sub.handle, e.newPacer(sub), nil)
+
+	e.mu.Unlock()
 
 	return nil
 }
 
-func (e *PoolingEventListener) startPooling(address string, filter string) {
-	ticker := time.NewTicker(e.cfg.PoolingTime)
-	stopPoolingCh := make(chan struct{})
+// subscribeAttempt is an in-flight subscription, whose result is shared by the concurrent calls to Listen.
+type subscribeAttempt struct {
+	done chan struct{}
+	// err is set before done is closed
+	err error
+}
 
-	e.mu.Lock()
-	e.stopPooling[address] = stopPoolingCh
-	e.mu.Unlock()
+func (a *subscribeAttempt) wait(ctx context.Context) error {
+	select {
+	case <-a.done:
+		return a.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
 
-	defer ticker.Stop()
+// newPacer paces the polls of the subscription, recording its initial interval. It must be called with the lock held.
+func (e *PoolingEventListener) newPacer(sub *subscription) *pacer {
+	pace := newPacer(e.cfg, sub.Polling)
+	sub.Polling.EffectiveInterval = pace.effective
+
+	return pace
+}
+
+// startPooling polls the filter of the address on every new head, or once the interval of the pacer elapsed, pending
+// being the transactions already fetched but not stored yet, since filter changes are returned only once.
+func (e *PoolingEventListener) startPooling(
+	address domain.Address,
+	filter string,
+	handle *poolingHandle,
+	pace *pacer,
+	pending []domain.Transaction,
+) {
+	timer := time.NewTimer(pace.interval)
+
+	defer e.running.Done()
+	defer close(handle.done)
+	defer timer.Stop()
 
 	for {
+		// polled is closed once the poll woken up by a new head is over
+		var polled chan struct{}
+
 		select {
 		case <-e.ctx.Done():
-			e.stopPoolingFn(context.Background(), address, filter)
+			_ = e.stopPoolingFn(context.Background(), address, filter)
 			return
 
-		case <-stopPoolingCh:
-			e.stopPoolingFn(context.Background(), address, filter)
+		case <-handle.stop:
+			switch {
+			case handle.pause:
+				handle.err = e.pause(handle.ctx, address, filter, pending)
+			case handle.flush:
+				handle.err = e.drain(handle.ctx, address, filter, pending)
+			default:
+				handle.err = e.stopPoolingFn(handle.ctx, address, filter)
+			}
 			return
 
-		case <-ticker.C:
-			e.logger.Debug("Pooling transactions", "address", address, "filter", filter)
-
-			ctx := context.Background()
-
-			transactions, err := e.api.FetchTransactions(ctx, filter)
-			if err != nil {
-				e.logger.Error("Failed to fetch transactions", "error", err)
+		case polled = <-handle.heads:
+			if pace.throttled(time.Now()) {
+				close(polled)
 				continue
 			}
 
-			if len(transactions) == 0 {
-				continue
-			}
+		case <-timer.C:
+		}
 
-			if err = e.repo.Add(ctx, address, transactions); err != nil {
-				e.logger.Error("Failed to store transactions", "error", err)
-				continue
-			}
+		e.logger.Debug("Pooling transactions", "address", address, "filter", filter)
 
-			lastBlock := e.highestBlockNumber(transactions)
-			if err = e.repo.UpdateLastBlock(ctx, address, lastBlock); err != nil {
-				e.logger.Error("Failed to update last block", "error", err)
-			}
+		var err error
+
+		pending, err = e.poll(context.Background(), address, filter, pending)
+		e.reportPoll(address, err)
+
+		if errors.Is(err, domain.ErrFilterNotFound) {
+			filter = e.reinstallFilter(context.Background(), address, filter)
+		}
+
+		if err == nil {
+			e.coverPoll(context.Background(), address, pending)
+		}
+
+		resetTimer(timer, e.paced(address, pace, time.Now()))
+
+		if polled != nil {
+			close(polled)
 		}
 	}
 }
 
-func (e *PoolingEventListener) stopPoolingFn(ctx context.Context, address, filter string) {
+// reinstallFilter replaces the filter of the address the node no longer holds, returning the filter to poll, which is
+// the expired one until a new one can be created. The coverage of the new filter starts on its first poll, the blocks
+// mined in between being left to the gap repair.
+func (e *PoolingEventListener) reinstallFilter(ctx context.Context, address domain.Address, expired string) string {
+	filter, err := e.api.NewFilter(ctx, address)
+	if err != nil {
+		e.logger.Error("Failed to reinstall filter", "error", err, "address", address)
+		return expired
+	}
+
+	e.mu.Lock()
+	if sub, ok := e.subscriptions[address]; ok && sub.filter == expired {
+		sub.filter = filter
+		sub.coverFrom = 0
+	}
+	e.mu.Unlock()
+
+	e.logger.Warn("Reinstalled expired filter", "address", address, "filter", filter)
+
+	return filter
+}
+
+// drain polls the filter one last time, storing the records fetched since the previous poll, then uninstalls it.
+// The records still waiting for confirmations are dropped.
+func (e *PoolingEventListener) drain(
+	ctx context.Context,
+	address domain.Address,
+	filter string,
+	pending []domain.Transaction,
+) error {
+	pending, pollErr := e.poll(ctx, address, filter, pending)
+	if pollErr != nil {
+		pollErr = errors.Wrap(pollErr, "failed to flush pending records")
+	} else if len(pending) > 0 {
+		e.logger.Warn("Dropping unconfirmed records", "address", address, "count", len(pending))
+	}
+
+	if err := e.stopPoolingFn(ctx, address, filter); err != nil {
+		return err
+	}
+
+	return pollErr
+}
+
+// poll fetches the filter changes and stores them along with the pending ones.
+// It returns the transactions that could not be stored, to be retried on the next tick, along with the failure.
+func (e *PoolingEventListener) poll(
+	ctx context.Context,
+	address domain.Address,
+	filter string,
+	pending []domain.Transaction,
+) ([]domain.Transaction, error) {
+	transactions, fetchErr := e.api.FetchTransactions(ctx, filter)
+	if fetchErr != nil {
+		fetchErr = errors.Wrap(fetchErr, "failed to fetch transactions")
+		e.logger.Error("Failed to fetch transactions", "error", fetchErr)
+		e.publishError(ctx, address, fetchErr)
+	}
+
+	pending, err := e.store(ctx, address, append(pending, transactions...))
+	if err != nil {
+		return pending, err
+	}
+
+	return pending, fetchErr
+}
+
+// store stores the confirmed transactions, and deletes the ones removed by a reorg.
+// It returns the transactions that could not be stored, to be retried later, along with the failure.
+func (e *PoolingEventListener) store(
+	ctx context.Context,
+	address domain.Address,
+	pending []domain.Transaction,
+) ([]domain.Transaction, error) {
+	pending, err := e.remove(ctx, address, pending)
+	if err != nil {
+		err = errors.Wrap(err, "failed to remove transactions")
+		e.logger.Error("Failed to remove transactions", "error", err, "address", address)
+		e.publishError(ctx, address, err)
+		return pending, err
+	}
+
+	if len(pending) == 0 {
+		return nil, nil
+	}
+
+	confirmed, unconfirmed, err := e.splitConfirmed(ctx, pending)
+	if err != nil {
+		e.logger.Error("Failed to check confirmations", "error", err, "address", address)
+		e.publishError(ctx, address, err)
+		return pending, err
+	}
+
+	if len(confirmed) == 0 {
+		return unconfirmed, nil
+	}
+
+	for i := range confirmed {
+		confirmed[i].ChainID = e.cfg.ChainID
+	}
+
+	enriched, err := e.enrich(ctx, confirmed)
+	if err != nil {
+		err = errors.Wrap(err, "failed to enrich transactions")
+		e.logger.Error("Failed to enrich transactions", "error", err, "address", address)
+		e.publishError(ctx, address, err)
+		return pending, err
+	}
+
+	if err = e.repo.Add(ctx, address, enriched); err != nil {
+		err = errors.Wrap(err, "failed to store transactions")
+		e.logger.Error("Failed to store transactions", "error", err)
+		e.publishError(ctx, address, err)
+		return pending, err
+	}
+
+	e.deliver(ctx, address, enriched)
+
+	lastBlock := e.highestBlockNumber(enriched)
+	if err = e.repo.UpdateLastBlock(ctx, address, lastBlock); err != nil {
+		e.logger.Error("Failed to update last block", "error", err)
+	}
+
+	e.advance(address, lastBlock)
+
+	return unconfirmed, nil
+}
+
+// deliver notifies the records stored and publishes their events.
+func (e *PoolingEventListener) deliver(ctx context.Context, address domain.Address, transactions []domain.Transaction) {
+	for _, notifier := range e.notifiers {
+		if err := notifier.Notify(ctx, e.cfg.ChainID, address, transactions); err != nil {
+			e.logger.Error("Failed to notify transactions", "error", err, "address", address)
+		}
+	}
+
+	e.publish(ctx, domain.NewTransactionEvents(domain.EventTransactionAdded, address, transactions, time.Now()))
+}
+
+// advance moves the cursor of the subscription up to the given block.
+func (e *PoolingEventListener) advance(address domain.Address, blockNumber uint64) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
-	delete(e.filters, address)
-	delete(e.stopPooling, address)
+	if sub, ok := e.subscriptions[address]; ok && blockNumber > sub.LastBlock {
+		sub.LastBlock = blockNumber
+	}
+}
+
+// remove deletes the records of the logs removed by a reorg, publishing their removal.
+// Logs still waiting to be stored are just discarded, the remaining ones are returned.
+// On failure the given transactions are returned untouched, to be retried on the next tick.
+func (e *PoolingEventListener) remove(
+	ctx context.Context,
+	address domain.Address,
+	transactions []domain.Transaction,
+) ([]domain.Transaction, error) {
+	var removed = make(map[string]domain.Transaction)
+
+	for _, v := range transactions {
+		if v.Removed {
+			removed[v.Key()+v.BlockHash] = v
+		}
+	}
+
+	if len(removed) == 0 {
+		return transactions, nil
+	}
+
+	var kept, stored = make([]domain.Transaction, 0, len(transactions)), make([]domain.Transaction, 0, len(removed))
+
+	for _, v := range transactions {
+		if v.Removed {
+			continue
+		}
+
+		if _, ok := removed[v.Key()+v.BlockHash]; ok {
+			delete(removed, v.Key()+v.BlockHash)
+			continue
+		}
+
+		kept = append(kept, v)
+	}
+
+	for _, v := range removed {
+		v.ChainID = e.cfg.ChainID
+		stored = append(stored, v)
+	}
+
+	if len(stored) == 0 {
+		return kept, nil
+	}
+
+	if err := e.repo.Remove(ctx, address, stored); err != nil {
+		return transactions, err
+	}
+
+	e.publish(ctx, domain.NewTransactionEvents(domain.EventTransactionRemoved, address, stored, time.Now()))
+
+	return kept, nil
+}
+
+// publishError notifies the consumers of an error processing the subscription, the work being retried on the next tick.
+func (e *PoolingEventListener) publishError(ctx context.Context, address domain.Address, err error) {
+	e.publish(ctx, []domain.Event{domain.NewSubscriptionErrorEvent(e.cfg.ChainID, address, err, time.Now())})
+}
+
+func (e *PoolingEventListener) publish(ctx context.Context, events []domain.Event) {
+	for _, publisher := range e.publishers {
+		if err := publisher.Publish(ctx, events); err != nil {
+			e.logger.Error("Failed to publish events", "error", err)
+		}
+	}
+}
+
+// splitConfirmed splits the transactions between the ones with enough confirmations and the ones without.
+func (e *PoolingEventListener) splitConfirmed(
+	ctx context.Context,
+	transactions []domain.Transaction,
+) ([]domain.Transaction, []domain.Transaction, error) {
+	if e.cfg.Confirmations == 0 {
+		return transactions, nil, nil
+	}
+
+	head, err := e.api.BlockNumber(ctx)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to fetch block number")
+	}
+
+	var confirmed, unconfirmed []domain.Transaction
 
-	if err := e.api.RemoveFilter(ctx, filter); err != nil {
+	for _, v := range transactions {
+		if v.DecimalBlockNumber+e.cfg.Confirmations <= head {
+			confirmed = append(confirmed, v)
+		} else {
+			unconfirmed = append(unconfirmed, v)
+		}
+	}
+
+	return confirmed, unconfirmed, nil
+}
+
+func (e *PoolingEventListener) enrich(ctx context.Context, transactions []domain.Transaction) ([]domain.Transaction, error) {
+	var err error
+
+	for _, enricher := range e.enrichers {
+		transactions, err = enricher.Enrich(ctx, transactions)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return transactions, nil
+}
+
+// stopPoolingFn uninstalls the filter of the address and stops its subscription, the lock being released before
+// calling the node.
+func (e *PoolingEventListener) stopPoolingFn(ctx context.Context, address domain.Address, filter string) error {
+	err := e.api.RemoveFilter(ctx, filter)
+	if err != nil {
+		err = errors.Wrap(err, "failed to remove filter")
 		e.logger.Error("Failed to remove filter", "error", err, "address", address)
 	}
 
+	e.mu.Lock()
+	if sub, ok := e.subscriptions[address]; ok && sub.filter == filter {
+		sub.handle = nil
+		e.transition(sub, domain.SubscriptionStopped, err)
+	}
+	e.mu.Unlock()
+
 	e.logger.Info("Stopped pooling", "address", address)
+
+	return err
 }
 
-func (e *PoolingEventListener) Unsubscribe(_ context.Context, address string) error {
+// reportPoll moves the subscription to errored on a failed poll, and back to active on the next successful one.
+func (e *PoolingEventListener) reportPoll(address domain.Address, err error) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
-	if _, ok := e.filters[address]; !ok {
+	sub, ok := e.subscriptions[address]
+	if !ok {
+		return
+	}
+
+	switch {
+	case err != nil:
+		e.transition(sub, domain.SubscriptionErrored, err)
+	case sub.State == domain.SubscriptionErrored:
+		e.transition(sub, domain.SubscriptionActive, nil)
+	}
+}
+
+// transition moves the subscription to the given state, recording the error first when given one.
+// It must be called with the lock held.
+func (e *PoolingEventListener) transition(sub *subscription, to domain.SubscriptionState, cause error) {
+	now := time.Now()
+
+	if cause != nil {
+		if err := sub.Fail(cause, now); err != nil {
+			e.logger.Warn("Failed to record subscription error", "error", err, "address", sub.Address)
+		}
+	}
+
+	if err := sub.Transition(to, now); err != nil {
+		e.logger.Warn("Invalid subscription transition", "error", err, "address", sub.Address)
+	}
+}
+
+// Subscription returns the subscription of the address, failing with ErrSubscriptionNotFound when it never was.
+func (e *PoolingEventListener) Subscription(address domain.Address) (domain.Subscription, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	sub, ok := e.subscriptions[address]
+	if !ok {
+		return domain.Subscription{}, errors.Wrapf(domain.ErrSubscriptionNotFound, "address %s", address)
+	}
+
+	return sub.Clone(), nil
+}
+
+// Subscriptions returns every subscription, including the stopped ones, ordered by address.
+func (e *PoolingEventListener) Subscriptions() []domain.Subscription {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var subscriptions = make([]domain.Subscription, 0, len(e.subscriptions))
+
+	for _, sub := range e.subscriptions {
+		subscriptions = append(subscriptions, sub.Clone())
+	}
+
+	sort.Slice(subscriptions, func(i, j int) bool {
+		return subscriptions[i].Address < subscriptions[j].Address
+	})
+
+	return subscriptions
+}
+
+// Unsubscribe stops pooling the given address, waiting until its filter has been removed. A paused subscription,
+// having neither filter nor pooling goroutine left, is removed right away.
+func (e *PoolingEventListener) Unsubscribe(ctx context.Context, address domain.Address) error {
+	e.mu.Lock()
+	var handle *poolingHandle
+	if sub, ok := e.subscriptions[address]; ok {
+		if sub.handle == nil && sub.State == domain.SubscriptionPaused {
+			delete(e.subscriptions, address)
+			e.mu.Unlock()
+
+			return nil
+		}
+
+		handle, sub.handle = sub.handle, nil
+	}
+	e.mu.Unlock()
+
+	if handle == nil {
 		return domain.ErrNotSubscribed
 	}
 
-	close(e.stopPooling[address])
+	handle.ctx = ctx
+	close(handle.stop)
 
-	return nil
+	select {
+	case <-handle.done:
+		return handle.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops accepting subscriptions and stops pooling every address, within the context deadline: the in-flight
+// polls are waited for, the records fetched since then are stored and the filters are uninstalled in parallel.
+// The addresses that failed are reported through a *CloseError.
+func (e *PoolingEventListener) Close(ctx context.Context) error {
+	e.mu.Lock()
+	var handles = make(map[domain.Address]*poolingHandle)
+	for address, sub := range e.subscriptions {
+		if sub.handle != nil {
+			handles[address], sub.handle = sub.handle, nil
+		}
+	}
+	e.closed = true
+	e.mu.Unlock()
+
+	// the interrupted rescans resume from their checkpoint once started again
+	e.cancelRescans()
+
+	for _, handle := range handles {
+		handle.ctx = ctx
+		handle.flush = true
+		close(handle.stop)
+	}
+
+	var failed = make(map[domain.Address]error)
+
+	for address, handle := range handles {
+		select {
+		case <-handle.done:
+			if handle.err != nil {
+				failed[address] = handle.err
+			}
+		case <-ctx.Done():
+			failed[address] = errors.Wrap(ctx.Err(), "not stopped in time")
+		}
+	}
+
+	if len(failed) > 0 {
+		return &CloseError{Failed: failed}
+	}
+
+	// waits for the addresses being unsubscribed concurrently as well
+	return e.Wait(ctx)
+}
+
+// Wait blocks until every address stopped pooling, i.e. once the listener context is done, and their filter has been
+// uninstalled.
+func (e *PoolingEventListener) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+
+	go func() {
+		e.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Addresses returns the addresses currently subscribed.
+func (e *PoolingEventListener) Addresses() []domain.Address {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var addresses = make([]domain.Address, 0, len(e.subscriptions))
+
+	for address, sub := range e.subscriptions {
+		if sub.filter != "" && !sub.IsStopped() {
+			addresses = append(addresses, address)
+		}
+	}
+
+	return addresses
 }
 
-func (e *PoolingEventListener) highestBlockNumber(transactions []domain.Transaction) int64 {
+func (e *PoolingEventListener) highestBlockNumber(transactions []domain.Transaction) uint64 {
 	length := len(transactions)
 
 	if length == 0 {
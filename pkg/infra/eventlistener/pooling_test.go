@@ -3,10 +3,15 @@ package eventlistener
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"log/slog"
+	"reflect"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/pkg/errors"
+
 	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
 
 	"github.com/stretchr/testify/mock"
@@ -14,7 +19,10 @@ import (
 )
 
 func TestPoolingEventListener_Listen(t *testing.T) {
-	var logger = slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))
+	var (
+		logger  = slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))
+		address = domain.Address("0x123")
+	)
 
 	var transactions = []domain.Transaction{
 		{
@@ -42,7 +50,7 @@ func TestPoolingEventListener_Listen(t *testing.T) {
 	}
 	type args struct {
 		ctx     context.Context
-		address string
+		address domain.Address
 	}
 	tests := []struct {
 		name     string
@@ -59,7 +67,7 @@ func TestPoolingEventListener_Listen(t *testing.T) {
 				cfg:    &Config{PoolingTime: time.Millisecond * 10},
 				api: func(t *testing.T) EthJSONAPI {
 					api := mocks.NewEthJSONAPI(t)
-					api.EXPECT().NewFilter(mock.Anything, "0x123").Return("0x1", nil).Once()
+					api.EXPECT().NewFilter(mock.Anything, address).Return("0x1", nil).Once()
 					api.EXPECT().FetchTransactions(mock.Anything, "0x1").Return([]domain.Transaction{}, nil).Once()
 					api.EXPECT().RemoveFilter(mock.Anything, "0x1").Return(nil).Maybe()
 					return api
@@ -70,7 +78,7 @@ func TestPoolingEventListener_Listen(t *testing.T) {
 			},
 			args: args{
 				ctx:     context.Background(),
-				address: "0x123",
+				address: address,
 			},
 			waitTime: time.Millisecond * 15,
 			wantErr:  false,
@@ -83,21 +91,21 @@ func TestPoolingEventListener_Listen(t *testing.T) {
 				cfg:    &Config{PoolingTime: time.Millisecond * 50},
 				api: func(t *testing.T) EthJSONAPI {
 					api := mocks.NewEthJSONAPI(t)
-					api.EXPECT().NewFilter(mock.Anything, "0x123").Return("0x2", nil).Once()
+					api.EXPECT().NewFilter(mock.Anything, address).Return("0x2", nil).Once()
 					api.EXPECT().FetchTransactions(mock.Anything, "0x2").Return(transactions, nil).Once()
 					api.EXPECT().RemoveFilter(mock.Anything, "0x2").Return(nil).Once()
 					return api
 				},
 				repo: func(t *testing.T) RepositoryWriter {
 					repo := mocks.NewRepositoryWriter(t)
-					repo.EXPECT().Add(mock.Anything, "0x123", transactions).Return(nil).Once()
-					repo.EXPECT().UpdateLastBlock(mock.Anything, "0x123", int64(19542419)).Return(nil).Once()
+					repo.EXPECT().Add(mock.Anything, address, transactions).Return(nil).Once()
+					repo.EXPECT().UpdateLastBlock(mock.Anything, address, uint64(19542419)).Return(nil).Once()
 					return repo
 				},
 			},
 			args: args{
 				ctx:     context.Background(),
-				address: "0x123",
+				address: address,
 			},
 			waitTime: time.Millisecond * 60,
 			wantErr:  false,
@@ -129,3 +137,756 @@ func TestPoolingEventListener_Listen(t *testing.T) {
 		})
 	}
 }
+
+func TestPoolingEventListener_ListenWithEnrichers(t *testing.T) {
+	var (
+		logger  = slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))
+		address = domain.Address("0x123")
+		receipt = domain.Receipt{TransactionHash: "0x1", Status: domain.ReceiptStatusSuccess}
+
+		transactions = []domain.Transaction{{Hash: "0x1", Address: address, DecimalBlockNumber: 1}}
+		enriched     = []domain.Transaction{{Hash: "0x1", Address: address, DecimalBlockNumber: 1, Receipt: &receipt}}
+	)
+
+	api := mocks.NewEthJSONAPI(t)
+	api.EXPECT().NewFilter(mock.Anything, address).Return("0x1", nil).Once()
+	api.EXPECT().FetchTransactions(mock.Anything, "0x1").Return(transactions, nil).Once()
+	api.EXPECT().FetchTransactions(mock.Anything, "0x1").Return([]domain.Transaction{}, nil)
+	api.EXPECT().RemoveFilter(mock.Anything, "0x1").Return(nil).Once()
+
+	// the first enrichment fails, so the already fetched transactions must be retried on the next tick
+	enricher := mocks.NewEnricher(t)
+	enricher.EXPECT().Enrich(mock.Anything, transactions).Return(nil, errors.New("receipt not available")).Once()
+	enricher.EXPECT().Enrich(mock.Anything, transactions).Return(enriched, nil).Once()
+
+	repo := mocks.NewRepositoryWriter(t)
+	repo.EXPECT().Add(mock.Anything, address, enriched).Return(nil).Once()
+	repo.EXPECT().UpdateLastBlock(mock.Anything, address, uint64(1)).Return(nil).Once()
+
+	e := NewPoolingEventListener(
+		context.Background(),
+		api,
+		repo,
+		WithLogger(logger),
+		WithConfig(&Config{PoolingTime: time.Millisecond * 10}),
+		WithEnrichers(enricher),
+	)
+
+	if err := e.Listen(context.Background(), address); err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+
+	time.Sleep(time.Millisecond * 45) // sleep time to process async events
+
+	if err := e.Unsubscribe(context.Background(), address); err != nil {
+		t.Errorf("Unsubscribe() error = %v", err)
+	}
+}
+
+func TestPoolingEventListener_ListenWithConfirmations(t *testing.T) {
+	var (
+		logger  = slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))
+		address = domain.Address("0x123")
+		chainID = domain.ChainID(8453)
+
+		confirmed   = domain.Transaction{Hash: "0x1", Address: address, DecimalBlockNumber: 5}
+		unconfirmed = domain.Transaction{Hash: "0x2", Address: address, DecimalBlockNumber: 9}
+	)
+
+	withChain := func(tx domain.Transaction) domain.Transaction {
+		tx.ChainID = chainID
+		return tx
+	}
+
+	api := mocks.NewEthJSONAPI(t)
+	api.EXPECT().NewFilter(mock.Anything, address).Return("0x1", nil).Once()
+	api.EXPECT().FetchTransactions(mock.Anything, "0x1").Return([]domain.Transaction{confirmed, unconfirmed}, nil).Once()
+	api.EXPECT().FetchTransactions(mock.Anything, "0x1").Return([]domain.Transaction{}, nil)
+	api.EXPECT().BlockNumber(mock.Anything).Return(uint64(10), nil).Once()
+	api.EXPECT().BlockNumber(mock.Anything).Return(uint64(12), nil)
+	api.EXPECT().RemoveFilter(mock.Anything, "0x1").Return(nil).Once()
+
+	// with 3 confirmations, block 9 is only stored once the head reaches block 12
+	repo := mocks.NewRepositoryWriter(t)
+	repo.EXPECT().Add(mock.Anything, address, []domain.Transaction{withChain(confirmed)}).Return(nil).Once()
+	repo.EXPECT().UpdateLastBlock(mock.Anything, address, uint64(5)).Return(nil).Once()
+	repo.EXPECT().Add(mock.Anything, address, []domain.Transaction{withChain(unconfirmed)}).Return(nil).Once()
+	repo.EXPECT().UpdateLastBlock(mock.Anything, address, uint64(9)).Return(nil).Once()
+
+	e := NewPoolingEventListener(
+		context.Background(),
+		api,
+		repo,
+		WithLogger(logger),
+		WithConfig(&Config{PoolingTime: time.Millisecond * 10, ChainID: chainID, Confirmations: 3}),
+	)
+
+	if err := e.Listen(context.Background(), address); err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+
+	time.Sleep(time.Millisecond * 45) // sleep time to process async events
+
+	if err := e.Unsubscribe(context.Background(), address); err != nil {
+		t.Errorf("Unsubscribe() error = %v", err)
+	}
+}
+
+func TestPoolingEventListener_ListenWithNotifiers(t *testing.T) {
+	var (
+		logger  = slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))
+		address = domain.Address("0x123")
+
+		transactions = []domain.Transaction{{Hash: "0x1", Address: address, DecimalBlockNumber: 1}}
+		errQueueFull = errors.New("queue is full")
+	)
+
+	api := mocks.NewEthJSONAPI(t)
+	api.EXPECT().NewFilter(mock.Anything, address).Return("0x1", nil).Once()
+	api.EXPECT().FetchTransactions(mock.Anything, "0x1").Return(transactions, nil).Once()
+	api.EXPECT().FetchTransactions(mock.Anything, "0x1").Return([]domain.Transaction{}, nil)
+	api.EXPECT().RemoveFilter(mock.Anything, "0x1").Return(nil).Once()
+
+	repo := mocks.NewRepositoryWriter(t)
+	repo.EXPECT().Add(mock.Anything, address, transactions).Return(nil).Once()
+	repo.EXPECT().UpdateLastBlock(mock.Anything, address, uint64(1)).Return(nil).Once()
+
+	// a failing notifier must neither prevent the others from running nor the last block from being updated
+	failing := mocks.NewNotifier(t)
+	failing.EXPECT().Notify(mock.Anything, domain.ChainID(0), address, transactions).Return(errQueueFull).Once()
+
+	notifier := mocks.NewNotifier(t)
+	notifier.EXPECT().Notify(mock.Anything, domain.ChainID(0), address, transactions).Return(nil).Once()
+
+	e := NewPoolingEventListener(
+		context.Background(),
+		api,
+		repo,
+		WithLogger(logger),
+		WithConfig(&Config{PoolingTime: time.Millisecond * 10}),
+		WithNotifiers(failing, notifier),
+	)
+
+	if err := e.Listen(context.Background(), address); err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+
+	time.Sleep(time.Millisecond * 45) // sleep time to process async events
+
+	if err := e.Unsubscribe(context.Background(), address); err != nil {
+		t.Errorf("Unsubscribe() error = %v", err)
+	}
+}
+
+func TestPoolingEventListener_ListenWithRemovedLogs(t *testing.T) {
+	var (
+		logger  = slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))
+		address = domain.Address("0x123")
+
+		stored   = domain.Transaction{Hash: "0x1", Address: address, BlockHash: "0xa", DecimalBlockNumber: 1}
+		reverted = domain.Transaction{Hash: "0x1", Address: address, BlockHash: "0xa", DecimalBlockNumber: 1, Removed: true}
+		added    = domain.Transaction{Hash: "0x2", Address: address, BlockHash: "0xb", DecimalBlockNumber: 2}
+	)
+
+	api := mocks.NewEthJSONAPI(t)
+	api.EXPECT().NewFilter(mock.Anything, address).Return("0x1", nil).Once()
+	api.EXPECT().FetchTransactions(mock.Anything, "0x1").Return([]domain.Transaction{stored}, nil).Once()
+	api.EXPECT().FetchTransactions(mock.Anything, "0x1").Return([]domain.Transaction{reverted, added}, nil).Once()
+	api.EXPECT().FetchTransactions(mock.Anything, "0x1").Return([]domain.Transaction{}, nil)
+	api.EXPECT().RemoveFilter(mock.Anything, "0x1").Return(nil).Once()
+
+	repo := mocks.NewRepositoryWriter(t)
+	repo.EXPECT().Add(mock.Anything, address, []domain.Transaction{stored}).Return(nil).Once()
+	repo.EXPECT().UpdateLastBlock(mock.Anything, address, uint64(1)).Return(nil).Once()
+	repo.EXPECT().Remove(mock.Anything, address, []domain.Transaction{reverted}).Return(nil).Once()
+	repo.EXPECT().Add(mock.Anything, address, []domain.Transaction{added}).Return(nil).Once()
+	repo.EXPECT().UpdateLastBlock(mock.Anything, address, uint64(2)).Return(nil).Once()
+
+	var eventTypes []domain.EventType
+
+	publisher := mocks.NewEventPublisher(t)
+	publisher.EXPECT().Publish(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, events []domain.Event) error {
+			for _, v := range events {
+				eventTypes = append(eventTypes, v.Type)
+			}
+			return nil
+		}).
+		Times(3)
+
+	e := NewPoolingEventListener(
+		context.Background(),
+		api,
+		repo,
+		WithLogger(logger),
+		WithConfig(&Config{PoolingTime: time.Millisecond * 10}),
+		WithPublishers(publisher),
+	)
+
+	if err := e.Listen(context.Background(), address); err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+
+	time.Sleep(time.Millisecond * 45) // sleep time to process async events
+
+	if err := e.Unsubscribe(context.Background(), address); err != nil {
+		t.Errorf("Unsubscribe() error = %v", err)
+	}
+
+	want := []domain.EventType{domain.EventTransactionAdded, domain.EventTransactionRemoved, domain.EventTransactionAdded}
+	if !reflect.DeepEqual(eventTypes, want) {
+		t.Errorf("published events = %v, want %v", eventTypes, want)
+	}
+}
+
+func TestPoolingEventListener_ListenPublishesErrors(t *testing.T) {
+	var (
+		logger  = slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))
+		address = domain.Address("0x123")
+	)
+
+	api := mocks.NewEthJSONAPI(t)
+	api.EXPECT().NewFilter(mock.Anything, address).Return("0x1", nil).Once()
+	api.EXPECT().FetchTransactions(mock.Anything, "0x1").Return(nil, errors.New("filter not found")).Once()
+	api.EXPECT().FetchTransactions(mock.Anything, "0x1").Return([]domain.Transaction{}, nil)
+	api.EXPECT().RemoveFilter(mock.Anything, "0x1").Return(nil).Once()
+
+	publisher := mocks.NewEventPublisher(t)
+	publisher.EXPECT().Publish(mock.Anything, mock.MatchedBy(func(events []domain.Event) bool {
+		return len(events) == 1 &&
+			events[0].Type == domain.EventSubscriptionError &&
+			events[0].Address == address &&
+			events[0].Error == "failed to fetch transactions: filter not found"
+	})).Return(nil).Once()
+
+	e := NewPoolingEventListener(
+		context.Background(),
+		api,
+		mocks.NewRepositoryWriter(t),
+		WithLogger(logger),
+		WithConfig(&Config{PoolingTime: time.Millisecond * 10}),
+		WithPublishers(publisher),
+	)
+
+	if err := e.Listen(context.Background(), address); err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+
+	time.Sleep(time.Millisecond * 35) // sleep time to process async events
+
+	if err := e.Unsubscribe(context.Background(), address); err != nil {
+		t.Errorf("Unsubscribe() error = %v", err)
+	}
+}
+
+func TestPoolingEventListener_ListenReinstallsExpiredFilter(t *testing.T) {
+	var (
+		logger  = slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))
+		address = domain.Address("0x123")
+		polled  = make(chan struct{}, 1)
+	)
+
+	api := mocks.NewEthJSONAPI(t)
+	api.EXPECT().NewFilter(mock.Anything, address).Return("0x1", nil).Once()
+	api.EXPECT().FetchTransactions(mock.Anything, "0x1").
+		Return(nil, errors.Wrap(domain.ErrFilterNotFound, "filter not found")).
+		Once()
+	api.EXPECT().NewFilter(mock.Anything, address).Return("0x2", nil).Once()
+	api.EXPECT().FetchTransactions(mock.Anything, "0x2").
+		RunAndReturn(func(context.Context, string) ([]domain.Transaction, error) {
+			select {
+			case polled <- struct{}{}:
+			default:
+			}
+			return []domain.Transaction{}, nil
+		})
+	api.EXPECT().RemoveFilter(mock.Anything, "0x2").Return(nil).Once()
+
+	e := NewPoolingEventListener(
+		context.Background(),
+		api,
+		mocks.NewRepositoryWriter(t),
+		WithLogger(logger),
+		WithConfig(&Config{PoolingTime: time.Millisecond * 5}),
+	)
+
+	if err := e.Listen(context.Background(), address); err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+
+	select {
+	case <-polled:
+	case <-time.After(time.Second):
+		t.Fatalf("the reinstalled filter wasn't polled")
+	}
+
+	// the reinstalled filter is the one uninstalled
+	if err := e.Unsubscribe(context.Background(), address); err != nil {
+		t.Errorf("Unsubscribe() error = %v", err)
+	}
+}
+
+func TestPoolingEventListener_Wait(t *testing.T) {
+	var (
+		logger    = slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))
+		addresses = []domain.Address{"0x123", "0x456"}
+	)
+
+	api := mocks.NewEthJSONAPI(t)
+	api.EXPECT().NewFilter(mock.Anything, addresses[0]).Return("0x1", nil).Once()
+	api.EXPECT().NewFilter(mock.Anything, addresses[1]).Return("0x2", nil).Once()
+	api.EXPECT().FetchTransactions(mock.Anything, mock.Anything).Return([]domain.Transaction{}, nil).Maybe()
+	api.EXPECT().RemoveFilter(mock.Anything, "0x1").Return(nil).Once()
+	api.EXPECT().RemoveFilter(mock.Anything, "0x2").Return(errors.New("filter not found")).Once()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	e := NewPoolingEventListener(
+		ctx,
+		api,
+		mocks.NewRepositoryWriter(t),
+		WithLogger(logger),
+		WithConfig(&Config{PoolingTime: time.Millisecond * 10}),
+	)
+
+	for _, address := range addresses {
+		if err := e.Listen(context.Background(), address); err != nil {
+			t.Fatalf("Listen() error = %v", err)
+		}
+	}
+
+	waitCtx, waitCancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+	defer waitCancel()
+
+	// still pooling until the listener context is done
+	if err := e.Wait(waitCtx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Wait() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	cancel()
+
+	if err := e.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+
+	if got := e.Addresses(); len(got) != 0 {
+		t.Errorf("Addresses() = %v, want none once the filters are uninstalled", got)
+	}
+}
+
+func TestPoolingEventListener_Close(t *testing.T) {
+	var (
+		logger  = slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))
+		stored  = domain.Address("0x123")
+		failing = domain.Address("0x456")
+
+		transactions = []domain.Transaction{{Hash: "0x1", Address: stored, DecimalBlockNumber: 1}}
+	)
+
+	api := mocks.NewEthJSONAPI(t)
+	api.EXPECT().NewFilter(mock.Anything, stored).Return("0x1", nil).Once()
+	api.EXPECT().NewFilter(mock.Anything, failing).Return("0x2", nil).Once()
+	// the records fetched since the last tick are stored before the filters are uninstalled
+	api.EXPECT().FetchTransactions(mock.Anything, "0x1").Return(transactions, nil).Once()
+	api.EXPECT().FetchTransactions(mock.Anything, "0x2").Return([]domain.Transaction{}, nil).Once()
+	api.EXPECT().RemoveFilter(mock.Anything, "0x1").Return(nil).Once()
+	api.EXPECT().RemoveFilter(mock.Anything, "0x2").Return(errors.New("filter not found")).Once()
+
+	repo := mocks.NewRepositoryWriter(t)
+	repo.EXPECT().Add(mock.Anything, stored, transactions).Return(nil).Once()
+	repo.EXPECT().UpdateLastBlock(mock.Anything, stored, uint64(1)).Return(nil).Once()
+
+	e := NewPoolingEventListener(
+		context.Background(),
+		api,
+		repo,
+		WithLogger(logger),
+		WithConfig(&Config{PoolingTime: time.Hour}),
+	)
+
+	for _, address := range []domain.Address{stored, failing} {
+		if err := e.Listen(context.Background(), address); err != nil {
+			t.Fatalf("Listen() error = %v", err)
+		}
+	}
+
+	err := e.Close(context.Background())
+
+	var closeErr *CloseError
+	if !errors.As(err, &closeErr) {
+		t.Fatalf("Close() error = %v, want a *CloseError", err)
+	}
+
+	if _, ok := closeErr.Failed[failing]; !ok || len(closeErr.Failed) != 1 {
+		t.Errorf("Close() failed = %v, want %s only", closeErr.Failed, failing)
+	}
+
+	if err = e.Listen(context.Background(), stored); !errors.Is(err, domain.ErrListenerClosed) {
+		t.Errorf("Listen() error = %v, want %v", err, domain.ErrListenerClosed)
+	}
+
+	if err = e.Close(context.Background()); err != nil {
+		t.Errorf("Close() error = %v, want nil once closed", err)
+	}
+}
+
+func TestPoolingEventListener_CloseDeadline(t *testing.T) {
+	var (
+		logger  = slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))
+		address = domain.Address("0x123")
+	)
+
+	api := mocks.NewEthJSONAPI(t)
+	api.EXPECT().NewFilter(mock.Anything, address).Return("0x1", nil).Once()
+	api.EXPECT().FetchTransactions(mock.Anything, "0x1").Return([]domain.Transaction{}, nil).Once()
+	api.EXPECT().RemoveFilter(mock.Anything, "0x1").
+		RunAndReturn(func(ctx context.Context, _ string) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}).
+		Once()
+
+	e := NewPoolingEventListener(
+		context.Background(),
+		api,
+		mocks.NewRepositoryWriter(t),
+		WithLogger(logger),
+		WithConfig(&Config{PoolingTime: time.Hour}),
+	)
+
+	if err := e.Listen(context.Background(), address); err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*20)
+	defer cancel()
+
+	var closeErr *CloseError
+	if err := e.Close(ctx); !errors.As(err, &closeErr) || !errors.Is(closeErr.Failed[address], context.DeadlineExceeded) {
+		t.Errorf("Close() error = %v, want the address failing on the deadline", err)
+	}
+}
+
+func TestPoolingEventListener_Subscription(t *testing.T) {
+	var (
+		logger  = slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))
+		address = domain.Address("0x123")
+		failing = domain.Address("0x456")
+
+		// the polls following the failing one are blocked until the errored state is checked
+		recovered = make(chan struct{})
+	)
+
+	api := mocks.NewEthJSONAPI(t)
+	api.EXPECT().NewFilter(mock.Anything, failing).Return("", errors.New("node unavailable")).Once()
+	api.EXPECT().NewFilter(mock.Anything, address).Return("0x1", nil).Twice()
+	api.EXPECT().FetchTransactions(mock.Anything, "0x1").Return(nil, errors.New("node unavailable")).Once()
+	api.EXPECT().FetchTransactions(mock.Anything, "0x1").
+		RunAndReturn(func(context.Context, string) ([]domain.Transaction, error) {
+			<-recovered
+			return []domain.Transaction{}, nil
+		})
+	api.EXPECT().RemoveFilter(mock.Anything, "0x1").Return(nil)
+
+	e := NewPoolingEventListener(
+		context.Background(),
+		api,
+		mocks.NewRepositoryWriter(t),
+		WithLogger(logger),
+		WithConfig(&Config{PoolingTime: time.Millisecond * 10, ChainID: 10}),
+	)
+
+	assertState := func(address domain.Address, want domain.SubscriptionState) domain.Subscription {
+		t.Helper()
+
+		got, err := e.Subscription(address)
+		if err != nil {
+			t.Fatalf("Subscription() error = %v", err)
+		}
+
+		if got.State != want || got.ChainID != 10 {
+			t.Fatalf("Subscription() = %+v, want %s", got, want)
+		}
+
+		return got
+	}
+
+	if _, err := e.Subscription(address); !errors.Is(err, domain.ErrSubscriptionNotFound) {
+		t.Fatalf("Subscription() error = %v, want %v", err, domain.ErrSubscriptionNotFound)
+	}
+
+	// a failing subscription is stopped along with its error
+	if err := e.Listen(context.Background(), failing); err == nil {
+		t.Fatalf("Listen() expected error")
+	}
+
+	if got := assertState(failing, domain.SubscriptionStopped); got.LastError == "" {
+		t.Errorf("Subscription() = %+v, want the last error", got)
+	}
+
+	if err := e.Listen(context.Background(), address); err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+
+	time.Sleep(time.Millisecond * 30) // the first poll fails
+
+	if got := assertState(address, domain.SubscriptionErrored); got.LastError == "" || got.LastErrorAt.IsZero() {
+		t.Errorf("Subscription() = %+v, want the last error", got)
+	}
+
+	close(recovered)
+	time.Sleep(time.Millisecond * 30) // the next poll succeeds
+
+	got := assertState(address, domain.SubscriptionActive)
+	if got.StateTimes[domain.SubscriptionPending].IsZero() || got.StateTimes[domain.SubscriptionErrored].IsZero() {
+		t.Errorf("Subscription() = %+v, want the time of every state entered", got)
+	}
+
+	if err := e.Unsubscribe(context.Background(), address); err != nil {
+		t.Fatalf("Unsubscribe() error = %v", err)
+	}
+
+	assertState(address, domain.SubscriptionStopped)
+
+	if subscriptions := e.Subscriptions(); len(subscriptions) != 2 || len(e.Addresses()) != 0 {
+		t.Errorf("Subscriptions() = %v, want both stopped subscriptions", subscriptions)
+	}
+
+	// a stopped address can be subscribed again
+	if err := e.Listen(context.Background(), address); err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+
+	assertState(address, domain.SubscriptionActive)
+
+	if err := e.Close(context.Background()); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+}
+
+func TestPoolingEventListener_ListenDoesNotBlockOnSlowNode(t *testing.T) {
+	var (
+		logger  = slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))
+		slow    = domain.Address("0x123")
+		address = domain.Address("0x456")
+		release = make(chan struct{})
+	)
+
+	api := mocks.NewEthJSONAPI(t)
+	api.EXPECT().NewFilter(mock.Anything, slow).
+		RunAndReturn(func(context.Context, domain.Address) (string, error) {
+			<-release
+			return "0x1", nil
+		}).
+		Once()
+	api.EXPECT().NewFilter(mock.Anything, address).Return("0x2", nil).Once()
+	api.EXPECT().FetchTransactions(mock.Anything, "0x1").Return([]domain.Transaction{}, nil).Once()
+	api.EXPECT().RemoveFilter(mock.Anything, mock.Anything).Return(nil).Twice()
+
+	e := NewPoolingEventListener(
+		context.Background(),
+		api,
+		mocks.NewRepositoryWriter(t),
+		WithLogger(logger),
+		WithConfig(&Config{PoolingTime: time.Hour}),
+	)
+
+	slowErr := make(chan error, 1)
+
+	go func() {
+		slowErr <- e.Listen(context.Background(), slow)
+	}()
+
+	// waits for the slow subscription to be in-flight
+	for deadline := time.Now().Add(time.Second); ; time.Sleep(time.Millisecond) {
+		if sub, err := e.Subscription(slow); err == nil && sub.State == domain.SubscriptionPending {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatalf("the slow subscription never became pending")
+		}
+	}
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- e.Listen(context.Background(), address)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Listen() error = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Listen() blocked by the subscription of another address")
+	}
+
+	if err := e.Unsubscribe(context.Background(), address); err != nil {
+		t.Errorf("Unsubscribe() error = %v", err)
+	}
+
+	close(release)
+
+	if err := <-slowErr; err != nil {
+		t.Errorf("Listen() error = %v", err)
+	}
+
+	if err := e.Close(context.Background()); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+}
+
+func TestPoolingEventListener_ListenConcurrently(t *testing.T) {
+	const (
+		addressCount = 8
+		callsPerAddr = 25
+	)
+
+	var (
+		logger    = slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))
+		mu        sync.Mutex
+		created   = make(map[domain.Address]int)
+		addresses = make([]domain.Address, addressCount)
+	)
+
+	for i := range addresses {
+		addresses[i] = domain.Address(fmt.Sprintf("0x%d", i))
+	}
+
+	api := mocks.NewEthJSONAPI(t)
+	api.EXPECT().NewFilter(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, address domain.Address) (string, error) {
+			time.Sleep(time.Millisecond * 5) // slow node, for the calls to overlap
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			created[address]++
+
+			return "filter-" + address.String(), nil
+		})
+	api.EXPECT().FetchTransactions(mock.Anything, mock.Anything).Return([]domain.Transaction{}, nil).Maybe()
+	api.EXPECT().RemoveFilter(mock.Anything, mock.Anything).Return(nil)
+
+	e := NewPoolingEventListener(
+		context.Background(),
+		api,
+		mocks.NewRepositoryWriter(t),
+		WithLogger(logger),
+		WithConfig(&Config{PoolingTime: time.Millisecond}),
+	)
+
+	var (
+		wg   sync.WaitGroup
+		errs = make(chan error, addressCount*callsPerAddr)
+	)
+
+	for i := 0; i < addressCount*callsPerAddr; i++ {
+		address := addresses[i%addressCount]
+
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			if err := e.Listen(context.Background(), address); err != nil && !errors.Is(err, domain.ErrAlreadySubscribed) {
+				errs <- err
+			}
+		}()
+
+		// status queries and unsubscribes of unknown addresses are served meanwhile
+		go func() {
+			defer wg.Done()
+
+			_ = e.Subscriptions()
+			_ = e.Addresses()
+			_ = e.Unsubscribe(context.Background(), "0xunknown")
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("Listen() error = %v", err)
+	}
+
+	for _, address := range addresses {
+		if created[address] != 1 {
+			t.Errorf("%d filters created for %s, want 1", created[address], address)
+		}
+
+		if sub, err := e.Subscription(address); err != nil || sub.State != domain.SubscriptionActive {
+			t.Errorf("Subscription() = %+v, %v, want active", sub, err)
+		}
+	}
+
+	if err := e.Close(context.Background()); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+}
+
+func TestPoolingEventListener_CloseDuringListen(t *testing.T) {
+	var (
+		logger  = slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))
+		address = domain.Address("0x123")
+		started = make(chan struct{})
+		release = make(chan struct{})
+	)
+
+	api := mocks.NewEthJSONAPI(t)
+	api.EXPECT().NewFilter(mock.Anything, address).
+		RunAndReturn(func(context.Context, domain.Address) (string, error) {
+			close(started)
+			<-release
+			return "0x1", nil
+		}).
+		Once()
+	// the filter created once closed is uninstalled straight away
+	api.EXPECT().RemoveFilter(mock.Anything, "0x1").Return(nil).Once()
+
+	e := NewPoolingEventListener(
+		context.Background(),
+		api,
+		mocks.NewRepositoryWriter(t),
+		WithLogger(logger),
+		WithConfig(&Config{PoolingTime: time.Hour}),
+	)
+
+	listenErr := make(chan error, 1)
+
+	go func() {
+		listenErr <- e.Listen(context.Background(), address)
+	}()
+
+	<-started
+
+	closeErr := make(chan error, 1)
+
+	go func() {
+		closeErr <- e.Close(context.Background())
+	}()
+
+	// waits for the listener to be closed before the filter is created
+	for closed := false; !closed; time.Sleep(time.Millisecond) {
+		e.mu.Lock()
+		closed = e.closed
+		e.mu.Unlock()
+	}
+
+	close(release)
+
+	if err := <-listenErr; !errors.Is(err, domain.ErrListenerClosed) {
+		t.Errorf("Listen() error = %v, want %v", err, domain.ErrListenerClosed)
+	}
+
+	if err := <-closeErr; err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+
+	if sub, _ := e.Subscription(address); sub.State != domain.SubscriptionStopped {
+		t.Errorf("Subscription() state = %s, want %s", sub.State, domain.SubscriptionStopped)
+	}
+}
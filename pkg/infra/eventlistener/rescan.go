@@ -0,0 +1,228 @@
+package eventlistener
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/infra/backfill"
+)
+
+// Rescan re-indexes the address from the given block up to the last confirmed one, returning once the rescan is
+// started. The range is fetched again through eth_getLogs by the backfill engine, each chunk superseding the stored
+// records of its blocks, while the address keeps being polled. Its progress is reported by the subscription.
+func (e *PoolingEventListener) Rescan(ctx context.Context, address domain.Address, fromBlock uint64) error {
+	head, err := e.api.BlockNumber(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch block number")
+	}
+
+	if head < e.cfg.Confirmations || fromBlock > head-e.cfg.Confirmations {
+		return errors.Wrapf(domain.ErrInvalidBlockRange, "block %d is not confirmed yet", fromBlock)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.closed {
+		return domain.ErrListenerClosed
+	}
+
+	sub, ok := e.subscriptions[address]
+
+	switch {
+	case !ok || sub.IsStopped():
+		return domain.ErrNotSubscribed
+	case sub.Rescan != nil && sub.Rescan.Running():
+		return domain.ErrRescanInProgress
+	}
+
+	sub.Rescan = &domain.BackfillProgress{
+		FromBlock: fromBlock,
+		ToBlock:   head - e.cfg.Confirmations,
+		StartedAt: time.Now(),
+	}
+
+	e.running.Add(1)
+	go e.rescan(address, sub.Rescan.FromBlock, sub.Rescan.ToBlock)
+
+	return nil
+}
+
+// rescan re-indexes [fromBlock, toBlock] through the backfill engine, until done, failed or the listener closed.
+// A rescan interrupted by Close resumes from its checkpoint once the same rescan is started again.
+func (e *PoolingEventListener) rescan(address domain.Address, fromBlock, toBlock uint64) {
+	defer e.running.Done()
+
+	e.logger.Info("Rescanning", "address", address, "from_block", fromBlock, "to_block", toBlock)
+
+	job := backfill.Job{
+		ID:        rescanJobPrefix(e.cfg.ChainID) + address.String(),
+		Address:   address,
+		FromBlock: fromBlock,
+		ToBlock:   toBlock,
+		OnProgress: func(progress domain.BackfillProgress) {
+			e.rescanProgress(address, progress)
+		},
+	}
+
+	if err := e.backfill.Run(e.rescanCtx, job, rangeWriter{e}); err != nil {
+		e.logger.Error("Failed to rescan", "error", err, "address", address)
+		return
+	}
+
+	e.logger.Info("Rescanned", "address", address, "from_block", fromBlock, "to_block", toBlock)
+}
+
+// rescanJobPrefix and gapJobPrefix prefix the IDs of the backfill jobs of the chain, to resume them after a restart.
+func rescanJobPrefix(chain domain.ChainID) string {
+	return fmt.Sprintf("rescan/%d/", chain)
+}
+
+func gapJobPrefix(chain domain.ChainID) string {
+	return fmt.Sprintf("gap/%d/", chain)
+}
+
+// ResumeBackfills runs again, in the background, the rescans and gap repairs of the chain interrupted before their
+// end, e.g. by a restart, each one resuming from its checkpoint.
+func (e *PoolingEventListener) ResumeBackfills(ctx context.Context) error {
+	var jobs []backfill.Job
+
+	for _, prefix := range []string{rescanJobPrefix(e.cfg.ChainID), gapJobPrefix(e.cfg.ChainID)} {
+		unfinished, err := e.backfill.Unfinished(ctx, prefix)
+		if err != nil {
+			return errors.Wrap(err, "failed to get unfinished backfills")
+		}
+
+		jobs = append(jobs, unfinished...)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.closed {
+		return domain.ErrListenerClosed
+	}
+
+	for _, job := range jobs {
+		e.logger.Info("Resuming interrupted backfill", "job", job.ID, "address", job.Address)
+
+		e.running.Add(1)
+
+		go func(job backfill.Job) {
+			defer e.running.Done()
+
+			if err := e.backfill.Run(e.rescanCtx, job, rangeWriter{e}); err != nil {
+				e.logger.Error("Failed to resume backfill", "error", err, "job", job.ID, "address", job.Address)
+			}
+		}(job)
+	}
+
+	return nil
+}
+
+func (e *PoolingEventListener) rescanProgress(address domain.Address, progress domain.BackfillProgress) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	sub, ok := e.subscriptions[address]
+	if !ok || sub.Rescan == nil {
+		return
+	}
+
+	// keeps when the rescan was requested, e.g. before resuming from a checkpoint
+	progress.StartedAt = sub.Rescan.StartedAt
+
+	if e.closed && progress.Error != "" {
+		progress.Error = domain.ErrListenerClosed.Error()
+	}
+
+	*sub.Rescan = progress
+}
+
+// rangeWriter stores the records fetched again for a block range, stamped with the chain ID and enriched, recording
+// the range as covered. The records not stored yet are notified and published as live polling does, along with the
+// removal of the superseded ones.
+type rangeWriter struct {
+	e *PoolingEventListener
+}
+
+func (w rangeWriter) ReplaceBlockRange(
+	ctx context.Context,
+	address domain.Address,
+	fromBlock, toBlock uint64,
+	transactions []domain.Transaction,
+) error {
+	for i := range transactions {
+		transactions[i].ChainID = w.e.cfg.ChainID
+	}
+
+	enriched, err := w.e.enrich(ctx, transactions)
+	if err != nil {
+		return errors.Wrap(err, "failed to enrich transactions")
+	}
+
+	superseded, err := w.e.repo.ReplaceBlockRange(ctx, address, fromBlock, toBlock, enriched)
+	if err != nil {
+		return err
+	}
+
+	w.e.cover(ctx, address, domain.BlockRange{FromBlock: fromBlock, ToBlock: toBlock})
+
+	added, removed := changedRecords(superseded, enriched)
+
+	if len(removed) > 0 {
+		w.e.publish(ctx, domain.NewTransactionEvents(domain.EventTransactionRemoved, address, removed, time.Now()))
+	}
+
+	if len(added) > 0 {
+		w.e.deliver(ctx, address, added)
+	}
+
+	return nil
+}
+
+// changedRecords compares the records superseded with the ones replacing them, returning the records added and the
+// ones removed, a record moved to another block being both.
+func changedRecords(superseded, replacing []domain.Transaction) ([]domain.Transaction, []domain.Transaction) {
+	var (
+		before         = make(map[string]bool, len(superseded))
+		after          = make(map[string]bool, len(replacing))
+		added, removed []domain.Transaction
+	)
+
+	for _, v := range superseded {
+		before[v.Key()+v.BlockHash] = true
+	}
+
+	for _, v := range replacing {
+		after[v.Key()+v.BlockHash] = true
+
+		if !before[v.Key()+v.BlockHash] {
+			added = append(added, v)
+		}
+	}
+
+	for _, v := range superseded {
+		if !after[v.Key()+v.BlockHash] {
+			removed = append(removed, v)
+		}
+	}
+
+	return added, removed
+}
+
+// Reindex fetches the logs of [fromBlock, toBlock] again through the backfill engine, superseding the stored records
+// of those blocks, and returns once done. Unlike Rescan, the address doesn't have to be subscribed.
+func (e *PoolingEventListener) Reindex(ctx context.Context, address domain.Address, fromBlock, toBlock uint64) error {
+	job := backfill.Job{
+		Address:   address,
+		FromBlock: fromBlock,
+		ToBlock:   toBlock,
+	}
+
+	return e.backfill.Run(ctx, job, rangeWriter{e})
+}
@@ -0,0 +1,242 @@
+package eventlistener
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/infra/backfill"
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/infra/storage"
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/mocks"
+)
+
+func TestPoolingEventListener_Rescan(t *testing.T) {
+	var (
+		logger  = slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))
+		address = domain.Address("0x123")
+		first   = []domain.Transaction{{Hash: "0x1", DecimalBlockNumber: 96}, {Hash: "0x2", DecimalBlockNumber: 100}}
+		second  = []domain.Transaction{{Hash: "0x3", DecimalBlockNumber: 107}}
+
+		// the first chunk is blocked until the concurrent rescan is rejected
+		unblock = make(chan struct{})
+	)
+
+	api := mocks.NewEthJSONAPI(t)
+	api.EXPECT().NewFilter(mock.Anything, address).Return("0x1", nil).Once()
+	api.EXPECT().FetchTransactions(mock.Anything, "0x1").Return([]domain.Transaction{}, nil)
+	api.EXPECT().BlockNumber(mock.Anything).Return(113, nil)
+	api.EXPECT().GetLogs(mock.Anything, address, uint64(95), uint64(104)).
+		RunAndReturn(func(context.Context, domain.Address, uint64, uint64) ([]domain.Transaction, error) {
+			<-unblock
+			return first, nil
+		}).
+		Once()
+	api.EXPECT().GetLogs(mock.Anything, address, uint64(105), uint64(110)).Return(second, nil).Once()
+	api.EXPECT().RemoveFilter(mock.Anything, "0x1").Return(nil).Once()
+
+	repo := mocks.NewRepositoryWriter(t)
+	repo.EXPECT().ReplaceBlockRange(mock.Anything, address, uint64(95), uint64(104), mock.Anything).
+		RunAndReturn(func(
+			_ context.Context, _ domain.Address, _, _ uint64, got []domain.Transaction,
+		) ([]domain.Transaction, error) {
+			if len(got) != 2 || got[0].ChainID != 10 {
+				t.Errorf("ReplaceBlockRange() = %v, want the first chunk stamped with the chain ID", got)
+			}
+			// 0x2 was stored already, 0x9 isn't on the node anymore
+			return []domain.Transaction{got[1], {Hash: "0x9", DecimalBlockNumber: 99}}, nil
+		}).
+		Once()
+	repo.EXPECT().ReplaceBlockRange(mock.Anything, address, uint64(105), uint64(110), mock.Anything).
+		Return(nil, nil).
+		Once()
+
+	var (
+		mu     sync.Mutex
+		events = make(map[domain.EventType][]string)
+	)
+
+	publisher := mocks.NewEventPublisher(t)
+	publisher.EXPECT().Publish(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, published []domain.Event) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			for _, v := range published {
+				events[v.Type] = append(events[v.Type], v.Transaction.Hash)
+			}
+			return nil
+		})
+
+	e := NewPoolingEventListener(
+		context.Background(),
+		api,
+		repo,
+		WithLogger(logger),
+		WithConfig(&Config{PoolingTime: time.Millisecond * 10, ChainID: 10, Confirmations: 3}),
+		WithBackfill(backfill.NewEngine(api, backfill.WithConfig(&backfill.Config{Workers: 2, ChunkSize: 10}))),
+		WithPublishers(publisher),
+	)
+
+	if err := e.Rescan(context.Background(), address, 95); !errors.Is(err, domain.ErrNotSubscribed) {
+		t.Fatalf("Rescan() error = %v, want %v", err, domain.ErrNotSubscribed)
+	}
+
+	if err := e.Listen(context.Background(), address); err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+
+	if err := e.Rescan(context.Background(), address, 111); !errors.Is(err, domain.ErrInvalidBlockRange) {
+		t.Fatalf("Rescan() error = %v, want %v", err, domain.ErrInvalidBlockRange)
+	}
+
+	if err := e.Rescan(context.Background(), address, 95); err != nil {
+		t.Fatalf("Rescan() error = %v", err)
+	}
+
+	if err := e.Rescan(context.Background(), address, 95); !errors.Is(err, domain.ErrRescanInProgress) {
+		t.Fatalf("Rescan() error = %v, want %v", err, domain.ErrRescanInProgress)
+	}
+
+	close(unblock)
+
+	got := waitRescan(t, e, address)
+
+	if got.FromBlock != 95 || got.ToBlock != 110 || got.ScannedBlock != 110 || got.Records != 3 || got.Error != "" {
+		t.Errorf("Subscription() rescan = %+v, want 3 records rescanned from 95 to 110", got)
+	}
+
+	mu.Lock()
+	added, removed := events[domain.EventTransactionAdded], events[domain.EventTransactionRemoved]
+	mu.Unlock()
+
+	if !reflect.DeepEqual(added, []string{"0x1", "0x3"}) || !reflect.DeepEqual(removed, []string{"0x9"}) {
+		t.Errorf("published added %v and removed %v, want 0x1 and 0x3 added, 0x9 removed", added, removed)
+	}
+
+	if err := e.Close(context.Background()); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+}
+
+func TestPoolingEventListener_RescanFailure(t *testing.T) {
+	var (
+		logger  = slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))
+		address = domain.Address("0x123")
+	)
+
+	api := mocks.NewEthJSONAPI(t)
+	api.EXPECT().NewFilter(mock.Anything, address).Return("0x1", nil).Once()
+	api.EXPECT().FetchTransactions(mock.Anything, "0x1").Return([]domain.Transaction{}, nil)
+	api.EXPECT().BlockNumber(mock.Anything).Return(119, nil).Once()
+	api.EXPECT().GetLogs(mock.Anything, address, uint64(100), uint64(109)).Return([]domain.Transaction{}, nil).Once()
+	api.EXPECT().GetLogs(mock.Anything, address, uint64(110), uint64(119)).
+		Return(nil, errors.New("node unavailable")).
+		Once()
+	api.EXPECT().RemoveFilter(mock.Anything, "0x1").Return(nil).Once()
+
+	repo := mocks.NewRepositoryWriter(t)
+	repo.EXPECT().ReplaceBlockRange(mock.Anything, address, uint64(100), uint64(109), mock.Anything).
+		Return(nil, nil).
+		Once()
+
+	e := NewPoolingEventListener(
+		context.Background(),
+		api,
+		repo,
+		WithLogger(logger),
+		WithConfig(&Config{PoolingTime: time.Millisecond * 10}),
+		WithBackfill(backfill.NewEngine(api, backfill.WithConfig(&backfill.Config{Workers: 1, ChunkSize: 10}))),
+	)
+
+	if err := e.Listen(context.Background(), address); err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+
+	if err := e.Rescan(context.Background(), address, 100); err != nil {
+		t.Fatalf("Rescan() error = %v", err)
+	}
+
+	// the rescan stops on the failing chunk, the blocks re-indexed so far being kept
+	if got := waitRescan(t, e, address); got.Error == "" || got.ScannedBlock != 109 {
+		t.Errorf("Subscription() rescan = %+v, want failed after block 109", got)
+	}
+
+	if err := e.Close(context.Background()); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+}
+
+// waitRescan returns the progress of the rescan of the address once it's finished.
+func waitRescan(t *testing.T, e *PoolingEventListener, address domain.Address) domain.BackfillProgress {
+	t.Helper()
+
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		got, err := e.Subscription(address)
+		if err != nil {
+			t.Fatalf("Subscription() error = %v", err)
+		}
+
+		if got.Rescan != nil && !got.Rescan.Running() {
+			return *got.Rescan
+		}
+	}
+
+	t.Fatalf("rescan of %s not finished", address)
+
+	return domain.BackfillProgress{}
+}
+
+func TestPoolingEventListener_ResumeBackfills(t *testing.T) {
+	var (
+		ctx         = context.Background()
+		logger      = slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))
+		address     = domain.Address("0x123")
+		checkpoints = storage.NewInMemory()
+	)
+
+	// a rescan of the chain interrupted after the block 104, and a gap repair of another chain
+	_ = checkpoints.SaveBackfillCheckpoint(ctx, "rescan/10/0x123", domain.BackfillCheckpoint{
+		Address: address, FromBlock: 95, NextBlock: 105, ToBlock: 110,
+	})
+	_ = checkpoints.SaveBackfillCheckpoint(ctx, "gap/1/0x123/95", domain.BackfillCheckpoint{
+		Address: address, FromBlock: 95, NextBlock: 105, ToBlock: 110,
+	})
+
+	api := mocks.NewEthJSONAPI(t)
+	api.EXPECT().GetLogs(mock.Anything, address, uint64(105), uint64(110)).Return([]domain.Transaction{}, nil).Once()
+
+	repo := mocks.NewRepositoryWriter(t)
+	repo.EXPECT().ReplaceBlockRange(mock.Anything, address, uint64(105), uint64(110), mock.Anything).
+		Return(nil, nil).
+		Once()
+
+	e := NewPoolingEventListener(
+		ctx,
+		api,
+		repo,
+		WithLogger(logger),
+		WithConfig(&Config{ChainID: 10}),
+		WithBackfill(backfill.NewEngine(api, backfill.WithCheckpoints(checkpoints))),
+	)
+
+	if err := e.ResumeBackfills(ctx); err != nil {
+		t.Fatalf("ResumeBackfills() error = %v", err)
+	}
+
+	if err := e.Wait(ctx); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+
+	got, _ := checkpoints.GetBackfillCheckpoints(ctx)
+	if _, ok := got["gap/1/0x123/95"]; len(got) != 1 || !ok {
+		t.Errorf("checkpoints = %v, want only the one of the other chain left", got)
+	}
+}
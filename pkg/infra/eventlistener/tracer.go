@@ -0,0 +1,285 @@
+package eventlistener
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+// reorgWindow is the number of traced blocks remembered to detect the reorganisations of the chain.
+const reorgWindow = 64
+
+type TraceAPI interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+	GetBlockByNumber(ctx context.Context, number uint64) (domain.BlockHeader, error)
+	TraceBlock(ctx context.Context, number uint64) ([]domain.InternalCall, error)
+}
+
+type Subscriptions interface {
+	Addresses() []domain.Address
+}
+
+type TracerOptions func(*InternalTransactionTracer)
+
+func WithTracerConfig(cfg *Config) TracerOptions {
+	return func(t *InternalTransactionTracer) {
+		t.cfg = cfg
+	}
+}
+
+func WithTracerLogger(l *slog.Logger) TracerOptions {
+	return func(t *InternalTransactionTracer) {
+		t.logger = l
+	}
+}
+
+// WithTracerCoverage records the blocks traced for the subscribed addresses into the given store.
+func WithTracerCoverage(store CoverageStore) TracerOptions {
+	return func(t *InternalTransactionTracer) {
+		t.coverage = store
+	}
+}
+
+// WithTracerPublishers registers publishers of the events of the internal transactions stored and removed.
+func WithTracerPublishers(publishers ...EventPublisher) TracerOptions {
+	return func(t *InternalTransactionTracer) {
+		t.publishers = append(t.publishers, publishers...)
+	}
+}
+
+// InternalTransactionTracer follows the chain head, tracing every confirmed block containing transactions to find
+// value transferring internal calls touching the subscribed addresses, storing them as internal transactions.
+// The records of the blocks reorganised away are removed, and their blocks traced again.
+type InternalTransactionTracer struct {
+	logger        *slog.Logger
+	cfg           *Config
+	api           TraceAPI
+	repo          RepositoryWriter
+	subscriptions Subscriptions
+	coverage      CoverageStore
+	publishers    []EventPublisher
+	lastBlock     uint64
+	// traced holds the last blocks traced, by number
+	traced map[uint64]tracedBlock
+}
+
+// tracedBlock is a block traced, along with the records stored from it.
+type tracedBlock struct {
+	hash    string
+	records map[domain.Address][]domain.Transaction
+}
+
+func NewInternalTransactionTracer(
+	api TraceAPI,
+	storage RepositoryWriter,
+	subscriptions Subscriptions,
+	opts ...TracerOptions,
+) *InternalTransactionTracer {
+	t := &InternalTransactionTracer{
+		logger:        slog.Default(),
+		cfg:           &Config{PoolingTime: defaultPoolingTime},
+		api:           api,
+		repo:          storage,
+		subscriptions: subscriptions,
+		traced:        make(map[uint64]tracedBlock),
+	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t
+}
+
+// Run traces new blocks until the context is done.
+// It returns domain.ErrTracingNotSupported as soon as the node is detected not to support tracing.
+func (t *InternalTransactionTracer) Run(ctx context.Context) error {
+	ticker := time.NewTicker(t.cfg.PoolingTime)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case <-ticker.C:
+			err := t.traceNewBlocks(ctx)
+			if errors.Is(err, domain.ErrTracingNotSupported) {
+				return err
+			}
+			if err != nil {
+				t.logger.Error("Failed to trace blocks", "error", err)
+			}
+		}
+	}
+}
+
+func (t *InternalTransactionTracer) traceNewBlocks(ctx context.Context) error {
+	head, err := t.api.BlockNumber(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch block number")
+	}
+
+	if head < t.cfg.Confirmations {
+		return nil
+	}
+
+	confirmed := head - t.cfg.Confirmations
+
+	// starts from the current confirmed block, history is not traced
+	if t.lastBlock == 0 && confirmed > 0 {
+		t.lastBlock = confirmed - 1
+	}
+
+	if err = t.unwind(ctx); err != nil {
+		return err
+	}
+
+	if t.lastBlock >= confirmed {
+		return nil
+	}
+
+	var (
+		addresses = t.subscriptions.Addresses()
+		from      = t.lastBlock + 1
+	)
+
+	for number := from; number <= confirmed; number++ {
+		if err = t.traceBlock(ctx, number, addresses); err != nil {
+			break
+		}
+
+		t.lastBlock = number
+	}
+
+	if t.lastBlock >= from {
+		t.cover(ctx, addresses, domain.BlockRange{FromBlock: from, ToBlock: t.lastBlock})
+	}
+
+	return err
+}
+
+// unwind removes the records of the traced blocks which have been reorganised away, publishing their removal, and
+// moves the last block traced back to the common ancestor.
+func (t *InternalTransactionTracer) unwind(ctx context.Context) error {
+	for {
+		block, ok := t.traced[t.lastBlock]
+		if !ok {
+			return nil
+		}
+
+		header, err := t.api.GetBlockByNumber(ctx, t.lastBlock)
+		if err != nil {
+			return errors.Wrapf(err, "failed to fetch block %d", t.lastBlock)
+		}
+
+		if header.Hash == block.hash {
+			return nil
+		}
+
+		t.logger.Warn("Block reorganised, removing its internal transactions", "block", t.lastBlock)
+
+		for address, records := range block.records {
+			if err = t.repo.Remove(ctx, address, records); err != nil {
+				return errors.Wrap(err, "failed to remove internal transactions")
+			}
+
+			for i := range records {
+				records[i].Removed = true
+			}
+
+			t.publish(ctx, domain.NewTransactionEvents(domain.EventTransactionRemoved, address, records, time.Now()))
+		}
+
+		delete(t.traced, t.lastBlock)
+		t.lastBlock--
+	}
+}
+
+func (t *InternalTransactionTracer) traceBlock(ctx context.Context, number uint64, addresses []domain.Address) error {
+	if len(addresses) == 0 {
+		return nil
+	}
+
+	header, err := t.api.GetBlockByNumber(ctx, number)
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch block %d", number)
+	}
+
+	if len(header.Transactions) == 0 {
+		t.remember(number, tracedBlock{hash: header.Hash})
+		return nil
+	}
+
+	calls, err := t.api.TraceBlock(ctx, number)
+	if err != nil {
+		return errors.Wrapf(err, "failed to trace block %d", number)
+	}
+
+	var records = make(map[domain.Address][]domain.Transaction)
+
+	for _, call := range calls {
+		if !call.TransfersValue() {
+			continue
+		}
+
+		call.BlockHash = header.Hash
+
+		for _, address := range addresses {
+			if call.Touches(address) {
+				record := domain.NewInternalTransaction(address, call).WithBlockHeader(header)
+				record.ChainID = t.cfg.ChainID
+
+				records[address] = append(records[address], record)
+			}
+		}
+	}
+
+	for address, transactions := range records {
+		t.logger.Debug("Storing internal transactions", "address", address, "count", len(transactions))
+
+		if err = t.repo.Add(ctx, address, transactions); err != nil {
+			return errors.Wrap(err, "failed to store internal transactions")
+		}
+
+		t.publish(ctx, domain.NewTransactionEvents(domain.EventTransactionAdded, address, transactions, time.Now()))
+	}
+
+	t.remember(number, tracedBlock{hash: header.Hash, records: records})
+
+	return nil
+}
+
+// remember keeps the block traced to detect its reorganisation, forgetting the ones out of the reorg window.
+func (t *InternalTransactionTracer) remember(number uint64, block tracedBlock) {
+	t.traced[number] = block
+
+	if number > reorgWindow {
+		delete(t.traced, number-reorgWindow)
+	}
+}
+
+// cover records the blocks traced for every address subscribed while tracing them.
+func (t *InternalTransactionTracer) cover(ctx context.Context, addresses []domain.Address, r domain.BlockRange) {
+	if t.coverage == nil {
+		return
+	}
+
+	for _, address := range addresses {
+		if err := t.coverage.AddCoveredRange(ctx, address, r); err != nil {
+			t.logger.Warn("Failed to record traced blocks", "error", err, "address", address)
+		}
+	}
+}
+
+func (t *InternalTransactionTracer) publish(ctx context.Context, events []domain.Event) {
+	for _, publisher := range t.publishers {
+		if err := publisher.Publish(ctx, events); err != nil {
+			t.logger.Error("Failed to publish events", "error", err)
+		}
+	}
+}
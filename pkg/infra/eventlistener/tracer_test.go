@@ -0,0 +1,212 @@
+package eventlistener
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/mocks"
+)
+
+func TestInternalTransactionTracer_traceNewBlocks(t *testing.T) {
+	var (
+		logger     = slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))
+		subscribed = domain.Address("0x35fa164735182de50811e8e2e824cfb9b6118ac2")
+		header     = domain.BlockHeader{Number: 10, Hash: "0xb10", Timestamp: 1024, Transactions: []string{"0x1"}}
+
+		incoming = domain.InternalCall{
+			TransactionHash: "0x1",
+			BlockNumber:     10,
+			CallType:        "call",
+			From:            "0xcontract",
+			To:              "0x35fA164735182de50811E8e2E824cFb9B6118ac2",
+			Value:           domain.QuantityFromUint64(0x400),
+			TraceAddress:    []int{0, 1},
+		}
+		delegated = domain.InternalCall{
+			TransactionHash: "0x1",
+			BlockNumber:     10,
+			CallType:        "delegatecall",
+			From:            "0xcontract",
+			To:              subscribed.String(),
+			Value:           domain.QuantityFromUint64(0x400),
+			TraceAddress:    []int{0, 2},
+		}
+		zeroValue = domain.InternalCall{
+			TransactionHash: "0x1",
+			BlockNumber:     10,
+			CallType:        "call",
+			From:            subscribed.String(),
+			To:              "0xcontract",
+			Value:           domain.QuantityFromUint64(0x0),
+			TraceAddress:    []int{1},
+		}
+	)
+
+	tests := []struct {
+		name          string
+		api           func(*testing.T) TraceAPI
+		repo          func(*testing.T) RepositoryWriter
+		subscriptions func(*testing.T) Subscriptions
+		wantErr       bool
+	}{
+		{
+			name: "should store value transferring internal calls touching subscribed addresses",
+			api: func(t *testing.T) TraceAPI {
+				api := mocks.NewTraceAPI(t)
+				api.EXPECT().BlockNumber(mock.Anything).Return(uint64(10), nil).Once()
+				api.EXPECT().GetBlockByNumber(mock.Anything, uint64(10)).Return(header, nil).Once()
+				api.EXPECT().TraceBlock(mock.Anything, uint64(10)).
+					Return([]domain.InternalCall{incoming, delegated, zeroValue}, nil).
+					Once()
+				return api
+			},
+			repo: func(t *testing.T) RepositoryWriter {
+				call := incoming
+				call.BlockHash = header.Hash
+
+				repo := mocks.NewRepositoryWriter(t)
+				repo.EXPECT().Add(mock.Anything, subscribed, []domain.Transaction{
+					domain.NewInternalTransaction(subscribed, call).WithBlockHeader(header),
+				}).Return(nil).Once()
+				return repo
+			},
+			subscriptions: func(t *testing.T) Subscriptions {
+				s := mocks.NewSubscriptions(t)
+				s.EXPECT().Addresses().Return([]domain.Address{subscribed}).Once()
+				return s
+			},
+			wantErr: false,
+		},
+		{
+			name: "should not trace blocks without transactions",
+			api: func(t *testing.T) TraceAPI {
+				api := mocks.NewTraceAPI(t)
+				api.EXPECT().BlockNumber(mock.Anything).Return(uint64(10), nil).Once()
+				api.EXPECT().GetBlockByNumber(mock.Anything, uint64(10)).
+					Return(domain.BlockHeader{Number: 10, Hash: "0xb10"}, nil).
+					Once()
+				return api
+			},
+			repo: func(t *testing.T) RepositoryWriter {
+				return mocks.NewRepositoryWriter(t)
+			},
+			subscriptions: func(t *testing.T) Subscriptions {
+				s := mocks.NewSubscriptions(t)
+				s.EXPECT().Addresses().Return([]domain.Address{subscribed}).Once()
+				return s
+			},
+			wantErr: false,
+		},
+		{
+			name: "should return tracing not supported error",
+			api: func(t *testing.T) TraceAPI {
+				api := mocks.NewTraceAPI(t)
+				api.EXPECT().BlockNumber(mock.Anything).Return(uint64(10), nil).Once()
+				api.EXPECT().GetBlockByNumber(mock.Anything, uint64(10)).Return(header, nil).Once()
+				api.EXPECT().TraceBlock(mock.Anything, uint64(10)).Return(nil, domain.ErrTracingNotSupported).Once()
+				return api
+			},
+			repo: func(t *testing.T) RepositoryWriter {
+				return mocks.NewRepositoryWriter(t)
+			},
+			subscriptions: func(t *testing.T) Subscriptions {
+				s := mocks.NewSubscriptions(t)
+				s.EXPECT().Addresses().Return([]domain.Address{subscribed}).Once()
+				return s
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracer := NewInternalTransactionTracer(tt.api(t), tt.repo(t), tt.subscriptions(t), WithTracerLogger(logger))
+
+			if err := tracer.traceNewBlocks(context.Background()); (err != nil) != tt.wantErr {
+				t.Errorf("traceNewBlocks() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestInternalTransactionTracer_traceNewBlocksReorganised(t *testing.T) {
+	var (
+		logger     = slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))
+		subscribed = domain.Address("0x35fa164735182de50811e8e2e824cfb9b6118ac2")
+		header     = domain.BlockHeader{Number: 10, Hash: "0xb10", Timestamp: 1024, Transactions: []string{"0x1"}}
+		reorged    = domain.BlockHeader{Number: 10, Hash: "0xc10", Timestamp: 1024, Transactions: []string{"0x2"}}
+		created    = domain.InternalCall{
+			TransactionHash: "0x1",
+			BlockNumber:     10,
+			CallType:        "create",
+			From:            "0xcontract",
+			To:              subscribed.String(),
+			Value:           domain.QuantityFromUint64(0x400),
+			TraceAddress:    []int{0},
+		}
+	)
+
+	call := created
+	call.BlockHash = header.Hash
+	record := domain.NewInternalTransaction(subscribed, call).WithBlockHeader(header)
+
+	api := mocks.NewTraceAPI(t)
+	// the first pass traces the block 10, only confirmed by the head 12
+	api.EXPECT().BlockNumber(mock.Anything).Return(uint64(12), nil).Once()
+	api.EXPECT().GetBlockByNumber(mock.Anything, uint64(10)).Return(header, nil).Once()
+	api.EXPECT().TraceBlock(mock.Anything, uint64(10)).Return([]domain.InternalCall{created}, nil).Once()
+	// the second pass finds the block 10 reorganised away, tracing it again along with the block 11
+	api.EXPECT().BlockNumber(mock.Anything).Return(uint64(13), nil).Once()
+	api.EXPECT().GetBlockByNumber(mock.Anything, uint64(10)).Return(reorged, nil).Twice()
+	api.EXPECT().TraceBlock(mock.Anything, uint64(10)).Return([]domain.InternalCall{}, nil).Once()
+	api.EXPECT().GetBlockByNumber(mock.Anything, uint64(11)).
+		Return(domain.BlockHeader{Number: 11, Hash: "0xb11"}, nil).
+		Once()
+
+	repo := mocks.NewRepositoryWriter(t)
+	repo.EXPECT().Add(mock.Anything, subscribed, []domain.Transaction{record}).Return(nil).Once()
+	repo.EXPECT().Remove(mock.Anything, subscribed, []domain.Transaction{record}).Return(nil).Once()
+
+	publisher := mocks.NewEventPublisher(t)
+	publisher.EXPECT().Publish(mock.Anything, mock.MatchedBy(func(events []domain.Event) bool {
+		return len(events) == 1 && events[0].Type == domain.EventTransactionAdded
+	})).Return(nil).Once()
+	publisher.EXPECT().Publish(mock.Anything, mock.MatchedBy(func(events []domain.Event) bool {
+		return len(events) == 1 && events[0].Type == domain.EventTransactionRemoved && events[0].Transaction.Removed
+	})).Return(nil).Once()
+
+	coverage := mocks.NewCoverageStore(t)
+	coverage.EXPECT().AddCoveredRange(mock.Anything, subscribed, domain.BlockRange{FromBlock: 10, ToBlock: 10}).
+		Return(nil).
+		Once()
+	coverage.EXPECT().AddCoveredRange(mock.Anything, subscribed, domain.BlockRange{FromBlock: 10, ToBlock: 11}).
+		Return(nil).
+		Once()
+
+	subscriptions := mocks.NewSubscriptions(t)
+	subscriptions.EXPECT().Addresses().Return([]domain.Address{subscribed}).Twice()
+
+	tracer := NewInternalTransactionTracer(
+		api,
+		repo,
+		subscriptions,
+		WithTracerLogger(logger),
+		WithTracerPublishers(publisher),
+		WithTracerCoverage(coverage),
+		WithTracerConfig(&Config{PoolingTime: defaultPoolingTime, Confirmations: 2}),
+	)
+
+	for i := 0; i < 2; i++ {
+		if err := tracer.traceNewBlocks(context.Background()); err != nil {
+			t.Fatalf("traceNewBlocks() #%d error = %v", i, err)
+		}
+	}
+
+	if tracer.lastBlock != 11 {
+		t.Errorf("last block traced = %d, want the last confirmed block", tracer.lastBlock)
+	}
+}
@@ -0,0 +1,191 @@
+package sink
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+const (
+	defaultBatchSize    = 500
+	defaultPollInterval = 1 * time.Second
+	defaultRetryBackoff = 5 * time.Second
+)
+
+type EventLog interface {
+	GetEventsSince(ctx context.Context, afterID uint64, limit int) ([]domain.Event, error)
+}
+
+// CursorStore persists the ID of the last event written by each sink.
+type CursorStore interface {
+	GetSinkCursor(ctx context.Context, sink string) (uint64, error)
+	SaveSinkCursor(ctx context.Context, sink string, eventID uint64) error
+}
+
+type Options func(*FanOut)
+
+func WithConfig(cfg *Config) Options {
+	return func(f *FanOut) {
+		f.cfg = cfg
+	}
+}
+
+func WithLogger(l *slog.Logger) Options {
+	return func(f *FanOut) {
+		f.logger = l
+	}
+}
+
+// WithEventSource wakes the sinks up as soon as events are published, instead of waiting for the next poll.
+func WithEventSource(source domain.EventSource) Options {
+	return func(f *FanOut) {
+		f.source = source
+	}
+}
+
+type Config struct {
+	BatchSize    int
+	PollInterval time.Duration
+	// RetryBackoff is the wait before retrying a failed write
+	RetryBackoff time.Duration
+}
+
+// FanOut feeds every sink with the stored events, from its own cursor. A cursor only moves once the sink has
+// written the events, so a failing sink resumes where it stopped: events are delivered at least once.
+type FanOut struct {
+	logger  *slog.Logger
+	cfg     *Config
+	log     EventLog
+	cursors CursorStore
+	source  domain.EventSource
+	sinks   []EventSink
+}
+
+func NewFanOut(log EventLog, cursors CursorStore, sinks []EventSink, opts ...Options) *FanOut {
+	f := &FanOut{
+		logger: slog.Default(),
+		cfg: &Config{
+			BatchSize:    defaultBatchSize,
+			PollInterval: defaultPollInterval,
+			RetryBackoff: defaultRetryBackoff,
+		},
+		log:     log,
+		cursors: cursors,
+		sinks:   sinks,
+	}
+
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	return f
+}
+
+// Run feeds the sinks until the context is done.
+func (f *FanOut) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+
+	for _, s := range f.sinks {
+		wg.Add(1)
+
+		go func(s EventSink) {
+			defer wg.Done()
+			f.run(ctx, s)
+		}(s)
+	}
+
+	wg.Wait()
+
+	return nil
+}
+
+func (f *FanOut) run(ctx context.Context, s EventSink) {
+	var (
+		logger = f.logger.With("sink", s.Name())
+		ticker = time.NewTicker(f.cfg.PollInterval)
+		wake   <-chan domain.Event
+	)
+
+	defer ticker.Stop()
+
+	if f.source != nil {
+		// a single buffered event is enough to be woken up
+		wake = f.source.Subscribe(
+			ctx,
+			domain.EventFilter{},
+			domain.WithBufferSize(1),
+			domain.WithSlowConsumerPolicy(domain.SlowConsumerDropOldest),
+		)
+	}
+
+	for {
+		wait := ticker.C
+
+		if err := f.drain(ctx, s); err != nil {
+			logger.Error("Failed to feed sink", "error", err)
+			wait = time.After(f.cfg.RetryBackoff)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-wait:
+		case _, ok := <-wake:
+			if !ok {
+				wake = nil
+			}
+		}
+	}
+}
+
+// drain writes the events stored after the sink cursor, moving it forward after each batch.
+func (f *FanOut) drain(ctx context.Context, s EventSink) error {
+	cursor, err := f.cursors.GetSinkCursor(ctx, s.Name())
+	if err != nil {
+		return errors.Wrap(err, "failed to get cursor")
+	}
+
+	for {
+		events, err := f.log.GetEventsSince(ctx, cursor, f.cfg.BatchSize)
+		if err != nil {
+			return errors.Wrap(err, "failed to read events")
+		}
+
+		if len(events) == 0 {
+			return nil
+		}
+
+		var last = len(events) < f.cfg.BatchSize
+
+		// the events discarded from the log are written as a gap, for the sink to know they're missing
+		if events[0].ID > cursor+1 {
+			f.logger.Error(
+				"Events discarded before being written to sink",
+				"sink", s.Name(),
+				"from", cursor+1,
+				"to", events[0].ID-1,
+			)
+
+			events = append([]domain.Event{domain.NewDiscardedEvent(cursor+1, events[0].ID-1, time.Now())}, events...)
+		}
+
+		if err = s.Write(ctx, events); err != nil {
+			return errors.Wrap(err, "failed to write events")
+		}
+
+		cursor = events[len(events)-1].ID
+
+		if err = f.cursors.SaveSinkCursor(ctx, s.Name(), cursor); err != nil {
+			return errors.Wrap(err, "failed to save cursor")
+		}
+
+		if last {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/infra/storage"
+)
+
+type recordingSink struct {
+	mu       sync.Mutex
+	name     string
+	failures int
+	events   []domain.Event
+}
+
+func (s *recordingSink) Name() string {
+	return s.name
+}
+
+func (s *recordingSink) Write(_ context.Context, events []domain.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("downstream unavailable")
+	}
+
+	s.events = append(s.events, events...)
+
+	return nil
+}
+
+func (s *recordingSink) received() []uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var ids = make([]uint64, len(s.events))
+
+	for i, v := range s.events {
+		ids[i] = v.ID
+	}
+
+	return ids
+}
+
+func TestFanOut_Run(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		store = storage.NewInMemory()
+
+		flaky   = &recordingSink{name: "flaky", failures: 2}
+		healthy = &recordingSink{name: "healthy"}
+	)
+
+	appendEvents(t, store, 3)
+
+	runFanOut(t, store, []EventSink{flaky, healthy}, func() bool {
+		return len(flaky.received()) == 3 && len(healthy.received()) == 3
+	})
+
+	// the failing sink doesn't hold back the healthy one, and resumes once the downstream recovers
+	for _, s := range []*recordingSink{flaky, healthy} {
+		if got := s.received(); !equalIDs(got, []uint64{1, 2, 3}) {
+			t.Errorf("sink %s received %v, want [1 2 3]", s.name, got)
+		}
+
+		if cursor, _ := store.GetSinkCursor(ctx, s.name); cursor != 3 {
+			t.Errorf("sink %s cursor = %v, want 3", s.name, cursor)
+		}
+	}
+
+	appendEvents(t, store, 2)
+
+	// a restarted sink resumes from its persisted cursor
+	restarted := &recordingSink{name: "flaky"}
+
+	runFanOut(t, store, []EventSink{restarted}, func() bool {
+		return len(restarted.received()) == 2
+	})
+
+	if got := restarted.received(); !equalIDs(got, []uint64{4, 5}) {
+		t.Errorf("restarted sink received %v, want [4 5]", got)
+	}
+}
+
+// discardingLog is an event log having discarded its events up to the given ID.
+type discardingLog struct {
+	*storage.InMemory
+	discarded uint64
+}
+
+func (l *discardingLog) GetEventsSince(ctx context.Context, afterID uint64, limit int) ([]domain.Event, error) {
+	return l.InMemory.GetEventsSince(ctx, max(afterID, l.discarded), limit)
+}
+
+func TestFanOut_DiscardedEvents(t *testing.T) {
+	var (
+		store = &discardingLog{InMemory: storage.NewInMemory(), discarded: 3}
+		file  = &recordingSink{name: "file"}
+	)
+
+	appendEvents(t, store, 5)
+
+	runFanOut(t, store, []EventSink{file}, func() bool {
+		return len(file.received()) == 3
+	})
+
+	// the discarded events are written as a gap taking the ID of the last one, the sink resuming after it
+	if got := file.received(); !equalIDs(got, []uint64{3, 4, 5}) {
+		t.Fatalf("sink received %v, want [3 4 5]", got)
+	}
+
+	gap := file.events[0]
+
+	if gap.Type != domain.EventDiscarded || gap.Error != "events 1 to 3 discarded before being read" {
+		t.Errorf("sink received %+v, want the events 1 to 3 discarded", gap)
+	}
+
+	if cursor, _ := store.GetSinkCursor(context.Background(), file.name); cursor != 5 {
+		t.Errorf("sink cursor = %v, want 5", cursor)
+	}
+}
+
+// eventStore is the event log the sinks are fed from, along with their cursors.
+type eventStore interface {
+	EventLog
+	CursorStore
+	AppendEvents(ctx context.Context, events []domain.Event) ([]domain.Event, error)
+}
+
+func TestFanOut_ResumesAfterRestart(t *testing.T) {
+	var (
+		path  = filepath.Join(t.TempDir(), "events.ndjson")
+		store = storage.NewFileEvents(path)
+		first = &recordingSink{name: "file"}
+	)
+
+	appendEvents(t, store, 3)
+
+	runFanOut(t, store, []EventSink{first}, func() bool {
+		return len(first.received()) == 3
+	})
+
+	// events published right before the crash, never written to the sink
+	appendEvents(t, store, 2)
+	_ = store.Close()
+
+	var (
+		restarted = storage.NewFileEvents(path)
+		resumed   = &recordingSink{name: "file"}
+	)
+	defer restarted.Close()
+
+	appendEvents(t, restarted, 1)
+
+	runFanOut(t, restarted, []EventSink{resumed}, func() bool {
+		return len(resumed.received()) == 3
+	})
+
+	// the sink resumes after the last event it acknowledged, the IDs carrying on
+	if got := resumed.received(); !equalIDs(got, []uint64{4, 5, 6}) {
+		t.Errorf("restarted sink received %v, want [4 5 6]", got)
+	}
+}
+
+func runFanOut(t *testing.T, store eventStore, sinks []EventSink, done func() bool) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	f := NewFanOut(
+		store,
+		store,
+		sinks,
+		WithLogger(slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))),
+		WithConfig(&Config{BatchSize: 2, PollInterval: time.Millisecond * 5, RetryBackoff: time.Millisecond * 5}),
+	)
+
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+		_ = f.Run(ctx)
+	}()
+
+	for deadline := time.Now().Add(time.Second * 2); !done(); time.Sleep(time.Millisecond * 5) {
+		if time.Now().After(deadline) {
+			t.Fatalf("sinks were not fed in time")
+		}
+	}
+
+	cancel()
+	<-stopped
+}
+
+func appendEvents(t *testing.T, store eventStore, n int) {
+	t.Helper()
+
+	var events = make([]domain.Event, n)
+
+	for i := range events {
+		events[i] = domain.Event{Type: domain.EventNewHead, BlockNumber: uint64(i)}
+	}
+
+	if _, err := store.AppendEvents(context.Background(), events); err != nil {
+		t.Fatalf("AppendEvents() error = %v", err)
+	}
+}
@@ -0,0 +1,162 @@
+package sink
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+const (
+	defaultFileMaxSize  = 100 * 1024 * 1024
+	defaultFileMaxFiles = 5
+)
+
+type FileOptions func(*FileSink)
+
+// WithRotation rotates the file once it would exceed maxSize bytes, keeping up to maxFiles rotated files
+// named <path>.1 (the newest) to <path>.<maxFiles>.
+func WithRotation(maxSize int64, maxFiles int) FileOptions {
+	return func(s *FileSink) {
+		s.maxSize = maxSize
+		s.maxFiles = maxFiles
+	}
+}
+
+// FileSink appends events to a file as newline-delimited JSON, rotating it by size.
+type FileSink struct {
+	mu       sync.Mutex
+	name     string
+	path     string
+	maxSize  int64
+	maxFiles int
+	file     *os.File
+	size     int64
+}
+
+func NewFileSink(name, path string, opts ...FileOptions) *FileSink {
+	s := &FileSink{
+		name:     name,
+		path:     path,
+		maxSize:  defaultFileMaxSize,
+		maxFiles: defaultFileMaxFiles,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+func (s *FileSink) Name() string {
+	return s.name
+}
+
+// Write appends the events and syncs the file, so they're durable once it returns.
+func (s *FileSink) Write(_ context.Context, events []domain.Event) error {
+	data, err := marshalNDJSON(events)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err = s.open(); err != nil {
+		return err
+	}
+
+	if s.size > 0 && s.size+int64(len(data)) > s.maxSize {
+		if err = s.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := s.file.Write(data)
+	s.size += int64(n)
+
+	if err != nil {
+		return errors.Wrap(err, "failed to write events")
+	}
+
+	if err = s.file.Sync(); err != nil {
+		return errors.Wrap(err, "failed to sync file")
+	}
+
+	return nil
+}
+
+// Close closes the current file, the next write reopens it.
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+
+	err := s.file.Close()
+	s.file = nil
+
+	return err
+}
+
+func (s *FileSink) open() error {
+	if s.file != nil {
+		return nil
+	}
+
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return errors.Wrap(err, "failed to open file")
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return errors.Wrap(err, "failed to stat file")
+	}
+
+	s.file = file
+	s.size = info.Size()
+
+	return nil
+}
+
+// rotate shifts the rotated files, dropping the oldest one, and starts a new file.
+func (s *FileSink) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return errors.Wrap(err, "failed to close file")
+	}
+
+	s.file = nil
+
+	if err := os.Remove(s.rotatedPath(s.maxFiles)); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "failed to remove oldest file")
+	}
+
+	for i := s.maxFiles - 1; i >= 1; i-- {
+		if err := os.Rename(s.rotatedPath(i), s.rotatedPath(i+1)); err != nil && !os.IsNotExist(err) {
+			return errors.Wrap(err, "failed to rotate file")
+		}
+	}
+
+	if s.maxFiles > 0 {
+		if err := os.Rename(s.path, s.rotatedPath(1)); err != nil {
+			return errors.Wrap(err, "failed to rotate file")
+		}
+	} else if err := os.Remove(s.path); err != nil {
+		return errors.Wrap(err, "failed to remove file")
+	}
+
+	return s.open()
+}
+
+func (s *FileSink) rotatedPath(i int) string {
+	return fmt.Sprintf("%s.%d", s.path, i)
+}
@@ -0,0 +1,113 @@
+package sink
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+func TestFileSink_WriteWithRotation(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		path = filepath.Join(t.TempDir(), "events.ndjson")
+	)
+
+	// every event line is around 90 bytes, so each file holds a single batch of two events
+	s := NewFileSink("file", path, WithRotation(200, 2))
+
+	for id := uint64(1); id <= 8; id += 2 {
+		err := s.Write(ctx, []domain.Event{
+			{ID: id, Type: domain.EventNewHead, BlockNumber: id},
+			{ID: id + 1, Type: domain.EventNewHead, BlockNumber: id + 1},
+		})
+		if err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	tests := []struct {
+		path string
+		want []uint64
+	}{
+		{path: path, want: []uint64{7, 8}},
+		{path: path + ".1", want: []uint64{5, 6}},
+		{path: path + ".2", want: []uint64{3, 4}},
+	}
+	for _, tt := range tests {
+		if got := readEventIDs(t, tt.path); !equalIDs(got, tt.want) {
+			t.Errorf("%s holds events %v, want %v", filepath.Base(tt.path), got, tt.want)
+		}
+	}
+
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("expected the oldest file to be removed, got: %v", err)
+	}
+}
+
+func TestFileSink_WriteAppends(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		path = filepath.Join(t.TempDir(), "events.ndjson")
+	)
+
+	s := NewFileSink("file", path)
+	_ = s.Write(ctx, []domain.Event{{ID: 1}})
+	_ = s.Close()
+
+	// a restarted sink appends to the existing file
+	s = NewFileSink("file", path)
+	_ = s.Write(ctx, []domain.Event{{ID: 2}})
+	_ = s.Close()
+
+	if got := readEventIDs(t, path); !equalIDs(got, []uint64{1, 2}) {
+		t.Errorf("file holds events %v, want [1 2]", got)
+	}
+}
+
+func readEventIDs(t *testing.T, path string) []uint64 {
+	t.Helper()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open %s: %v", path, err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	var ids []uint64
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var event domain.Event
+		if err = json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("invalid line %s: %v", scanner.Text(), err)
+		}
+		ids = append(ids, event.ID)
+	}
+
+	return ids
+}
+
+func equalIDs(a, b []uint64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,73 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+// EventSink pushes events to a downstream system. Events may be written more than once, e.g. after a failure,
+// so sinks should be idempotent or consumers deduplicate by event ID.
+type EventSink interface {
+	// Name identifies the sink, it keys its cursor so it must be stable across restarts
+	Name() string
+	Write(ctx context.Context, events []domain.Event) error
+}
+
+// WriterSink writes events as newline-delimited JSON.
+type WriterSink struct {
+	mu   sync.Mutex
+	name string
+	w    io.Writer
+}
+
+func NewWriterSink(name string, w io.Writer) *WriterSink {
+	return &WriterSink{name: name, w: w}
+}
+
+// NewStdoutSink writes events to the standard output, as newline-delimited JSON.
+func NewStdoutSink() *WriterSink {
+	return NewWriterSink("stdout", os.Stdout)
+}
+
+func (s *WriterSink) Name() string {
+	return s.name
+}
+
+func (s *WriterSink) Write(_ context.Context, events []domain.Event) error {
+	data, err := marshalNDJSON(events)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err = s.w.Write(data); err != nil {
+		return errors.Wrap(err, "failed to write events")
+	}
+
+	return nil
+}
+
+func marshalNDJSON(events []domain.Event) ([]byte, error) {
+	var data []byte
+
+	for _, v := range events {
+		line, err := json.Marshal(v)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to marshal event %d", v.ID)
+		}
+
+		data = append(data, line...)
+		data = append(data, '\n')
+	}
+
+	return data, nil
+}
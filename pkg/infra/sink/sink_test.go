@@ -0,0 +1,30 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+func TestWriterSink_Write(t *testing.T) {
+	var buf bytes.Buffer
+
+	s := NewWriterSink("buffer", &buf)
+
+	err := s.Write(context.Background(), []domain.Event{
+		{ID: 1, Type: domain.EventTransactionAdded, Address: "0x35fa164735182de50811e8e2e824cfb9b6118ac2"},
+		{ID: 2, Type: domain.EventNewHead, ChainID: 1, BlockNumber: 65},
+	})
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	want := `{"id":1,"type":"transaction.added","chainId":0,"address":"0x35fA164735182de50811E8e2E824cFb9B6118ac2","createdAt":"0001-01-01T00:00:00Z"}` + "\n" +
+		`{"id":2,"type":"head.new","chainId":1,"blockNumber":65,"createdAt":"0001-01-01T00:00:00Z"}` + "\n"
+
+	if buf.String() != want {
+		t.Errorf("Write() = %v, want %v", buf.String(), want)
+	}
+}
@@ -0,0 +1,125 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+// FileCheckpoints persists the backfill checkpoints into a JSON file, so that the unfinished jobs resume after a
+// restart. The file is read on first use, and written again on every change through a temporary file renamed over it.
+type FileCheckpoints struct {
+	mu          sync.Mutex
+	path        string
+	checkpoints map[string]domain.BackfillCheckpoint
+}
+
+func NewFileCheckpoints(path string) *FileCheckpoints {
+	return &FileCheckpoints{path: path}
+}
+
+// GetBackfillCheckpoint returns the checkpoint of the given backfill job, a zero one when it has none.
+func (f *FileCheckpoints) GetBackfillCheckpoint(_ context.Context, job string) (domain.BackfillCheckpoint, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.load(); err != nil {
+		return domain.BackfillCheckpoint{}, err
+	}
+
+	return f.checkpoints[job], nil
+}
+
+// GetBackfillCheckpoints returns the checkpoints of every unfinished job, keyed by job.
+func (f *FileCheckpoints) GetBackfillCheckpoints(_ context.Context) (map[string]domain.BackfillCheckpoint, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.load(); err != nil {
+		return nil, err
+	}
+
+	var checkpoints = make(map[string]domain.BackfillCheckpoint, len(f.checkpoints))
+
+	for job, checkpoint := range f.checkpoints {
+		checkpoints[job] = checkpoint
+	}
+
+	return checkpoints, nil
+}
+
+func (f *FileCheckpoints) SaveBackfillCheckpoint(
+	_ context.Context,
+	job string,
+	checkpoint domain.BackfillCheckpoint,
+) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.load(); err != nil {
+		return err
+	}
+
+	f.checkpoints[job] = checkpoint
+
+	return f.write()
+}
+
+func (f *FileCheckpoints) DeleteBackfillCheckpoint(_ context.Context, job string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.load(); err != nil {
+		return err
+	}
+
+	if _, ok := f.checkpoints[job]; !ok {
+		return nil
+	}
+
+	delete(f.checkpoints, job)
+
+	return f.write()
+}
+
+// load reads the checkpoints from the file once, a missing file holding none.
+func (f *FileCheckpoints) load() error {
+	if f.checkpoints != nil {
+		return nil
+	}
+
+	data, err := os.ReadFile(f.path)
+
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		f.checkpoints = make(map[string]domain.BackfillCheckpoint)
+		return nil
+	case err != nil:
+		return errors.Wrap(err, "failed to read checkpoints file")
+	}
+
+	var checkpoints = make(map[string]domain.BackfillCheckpoint)
+
+	if err = json.Unmarshal(data, &checkpoints); err != nil {
+		return errors.Wrap(err, "failed to decode checkpoints file")
+	}
+
+	f.checkpoints = checkpoints
+
+	return nil
+}
+
+// write replaces the file with the checkpoints, a crash in between leaving the previous file untouched.
+func (f *FileCheckpoints) write() error {
+	data, err := json.Marshal(f.checkpoints)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode checkpoints")
+	}
+
+	return replaceFile(f.path, data)
+}
@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+func TestFileCheckpoints(t *testing.T) {
+	var (
+		ctx        = context.Background()
+		path       = filepath.Join(t.TempDir(), "checkpoints.json")
+		address    = domain.Address("0x35fa164735182de50811e8e2e824cfb9b6118ac2")
+		checkpoint = domain.BackfillCheckpoint{Address: address, FromBlock: 100, NextBlock: 151, ToBlock: 200}
+	)
+
+	store := NewFileCheckpoints(path)
+
+	if got, err := store.GetBackfillCheckpoint(ctx, "rescan"); err != nil || got != (domain.BackfillCheckpoint{}) {
+		t.Fatalf("expected no checkpoint, got: %v, %v", got, err)
+	}
+
+	if err := store.SaveBackfillCheckpoint(ctx, "rescan", checkpoint); err != nil {
+		t.Fatalf("SaveBackfillCheckpoint() error = %v", err)
+	}
+
+	if err := store.SaveBackfillCheckpoint(ctx, "gap", checkpoint); err != nil {
+		t.Fatalf("SaveBackfillCheckpoint() error = %v", err)
+	}
+
+	if err := store.DeleteBackfillCheckpoint(ctx, "gap"); err != nil {
+		t.Fatalf("DeleteBackfillCheckpoint() error = %v", err)
+	}
+
+	// the checkpoints outlive the store, as after a restart
+	got, err := NewFileCheckpoints(path).GetBackfillCheckpoints(ctx)
+	if err != nil {
+		t.Fatalf("GetBackfillCheckpoints() error = %v", err)
+	}
+
+	if want := map[string]domain.BackfillCheckpoint{"rescan": checkpoint}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetBackfillCheckpoints() = %v, want %v", got, want)
+	}
+
+	if err = os.WriteFile(path, []byte("{"), 0o600); err != nil {
+		t.Fatalf("failed to corrupt the file: %v", err)
+	}
+
+	if _, err = NewFileCheckpoints(path).GetBackfillCheckpoint(ctx, "rescan"); err == nil {
+		t.Errorf("GetBackfillCheckpoint() error = nil, want the corrupted file reported")
+	}
+}
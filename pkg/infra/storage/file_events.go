@@ -0,0 +1,271 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+// FileEvents persists the event log and the sink cursors, so that event IDs carry on after a restart and every sink
+// resumes after the last event it wrote. Events are appended to a NDJSON file, compacted down to the last events kept
+// once it holds twice as many, and the cursors are kept in a JSON file next to it, replaced on every change.
+// The files are read on first use.
+type FileEvents struct {
+	mu          sync.Mutex
+	path        string
+	loaded      bool
+	file        *os.File
+	lines       int
+	events      []domain.Event
+	lastEventID uint64
+	cursors     map[string]uint64
+}
+
+func NewFileEvents(path string) *FileEvents {
+	return &FileEvents{path: path}
+}
+
+// AppendEvents stores the given events, assigning them increasing IDs.
+func (f *FileEvents) AppendEvents(_ context.Context, events []domain.Event) ([]domain.Event, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.load(); err != nil {
+		return nil, err
+	}
+
+	var (
+		stored = make([]domain.Event, len(events))
+		data   []byte
+	)
+
+	for i, v := range events {
+		v.ID = f.lastEventID + uint64(i) + 1
+		stored[i] = v
+
+		line, err := json.Marshal(v)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to encode event")
+		}
+
+		data = append(append(data, line...), '\n')
+	}
+
+	if err := f.appendLines(data, len(stored)); err != nil {
+		return nil, err
+	}
+
+	f.lastEventID += uint64(len(stored))
+	f.events = trimEvents(append(f.events, stored...))
+
+	if f.lines >= 2*maxEvents {
+		if err := f.compact(); err != nil {
+			return nil, err
+		}
+	}
+
+	return stored, nil
+}
+
+// GetEventsSince returns, oldest first, up to limit events stored after the given event ID.
+func (f *FileEvents) GetEventsSince(_ context.Context, afterID uint64, limit int) ([]domain.Event, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.load(); err != nil {
+		return nil, err
+	}
+
+	start := sort.Search(len(f.events), func(i int) bool {
+		return f.events[i].ID > afterID
+	})
+
+	end := len(f.events)
+	if limit > 0 && start+limit < end {
+		end = start + limit
+	}
+
+	return append([]domain.Event(nil), f.events[start:end]...), nil
+}
+
+// GetSinkCursor returns the ID of the last event written by the given sink, zero when it has written none.
+func (f *FileEvents) GetSinkCursor(_ context.Context, sink string) (uint64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.load(); err != nil {
+		return 0, err
+	}
+
+	return f.cursors[sink], nil
+}
+
+func (f *FileEvents) SaveSinkCursor(_ context.Context, sink string, eventID uint64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.load(); err != nil {
+		return err
+	}
+
+	f.cursors[sink] = eventID
+
+	data, err := json.Marshal(f.cursors)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode sink cursors")
+	}
+
+	return replaceFile(f.cursorsPath(), data)
+}
+
+// Close closes the events file.
+func (f *FileEvents) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+
+	err := f.file.Close()
+	f.file = nil
+
+	return err
+}
+
+func (f *FileEvents) cursorsPath() string {
+	return f.path + ".cursors"
+}
+
+// load reads the events and the cursors from their files once, missing files holding none.
+func (f *FileEvents) load() error {
+	if f.loaded {
+		return nil
+	}
+
+	if err := f.loadEvents(); err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(f.cursorsPath())
+
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		f.cursors = make(map[string]uint64)
+	case err != nil:
+		return errors.Wrap(err, "failed to read sink cursors file")
+	default:
+		if err = json.Unmarshal(data, &f.cursors); err != nil {
+			return errors.Wrap(err, "failed to decode sink cursors file")
+		}
+	}
+
+	f.loaded = true
+
+	return nil
+}
+
+func (f *FileEvents) loadEvents() error {
+	data, err := os.ReadFile(f.path)
+
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return nil
+	case err != nil:
+		return errors.Wrap(err, "failed to read events file")
+	}
+
+	// the last line may have been cut by a crash, its events were never acknowledged and it's dropped not to be
+	// followed by the next ones
+	if complete := bytes.LastIndexByte(data, '\n') + 1; complete < len(data) {
+		if err = os.Truncate(f.path, int64(complete)); err != nil {
+			return errors.Wrap(err, "failed to truncate events file")
+		}
+
+		data = data[:complete]
+	}
+
+	for _, line := range bytes.Split(data, []byte{'\n'}) {
+		var event domain.Event
+
+		if len(line) == 0 || json.Unmarshal(line, &event) != nil {
+			continue
+		}
+
+		f.lines++
+		f.lastEventID = event.ID
+		f.events = trimEvents(append(f.events, event))
+	}
+
+	return nil
+}
+
+// appendLines writes the encoded events at the end of the file.
+func (f *FileEvents) appendLines(data []byte, lines int) error {
+	if f.file == nil {
+		file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			return errors.Wrap(err, "failed to open events file")
+		}
+
+		f.file = file
+	}
+
+	if _, err := f.file.Write(data); err != nil {
+		return errors.Wrap(err, "failed to write events file")
+	}
+
+	f.lines += lines
+
+	return nil
+}
+
+// compact replaces the events file with the events kept.
+func (f *FileEvents) compact() error {
+	var data []byte
+
+	for _, v := range f.events {
+		line, err := json.Marshal(v)
+		if err != nil {
+			return errors.Wrap(err, "failed to encode event")
+		}
+
+		data = append(append(data, line...), '\n')
+	}
+
+	if f.file != nil {
+		_ = f.file.Close()
+		f.file = nil
+	}
+
+	if err := replaceFile(f.path, data); err != nil {
+		return err
+	}
+
+	f.lines = len(f.events)
+
+	return nil
+}
+
+// replaceFile replaces the file with the given data through a temporary file renamed over it, a crash in between
+// leaving the previous file untouched.
+func replaceFile(path string, data []byte) error {
+	tmp := path + ".tmp"
+
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return errors.Wrapf(err, "failed to write %s", tmp)
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return errors.Wrapf(err, "failed to replace %s", path)
+	}
+
+	return nil
+}
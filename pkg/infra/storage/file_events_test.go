@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+func TestFileEvents(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		path = filepath.Join(t.TempDir(), "events.ndjson")
+	)
+
+	store := NewFileEvents(path)
+
+	heads := []domain.Event{{Type: domain.EventNewHead}, {Type: domain.EventNewHead}}
+
+	if _, err := store.AppendEvents(ctx, heads); err != nil {
+		t.Fatalf("AppendEvents() error = %v", err)
+	}
+
+	if err := store.SaveSinkCursor(ctx, "file", 1); err != nil {
+		t.Fatalf("SaveSinkCursor() error = %v", err)
+	}
+
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	// a crash cut the last line being written
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatalf("failed to open the events file: %v", err)
+	}
+
+	_, _ = file.WriteString(`{"id":3,"ty`)
+	_ = file.Close()
+
+	// the events and the cursors outlive the store, as after a restart
+	restarted := NewFileEvents(path)
+	defer restarted.Close()
+
+	if cursor, err := restarted.GetSinkCursor(ctx, "file"); err != nil || cursor != 1 {
+		t.Fatalf("GetSinkCursor() = %d, %v, want 1", cursor, err)
+	}
+
+	events, err := restarted.GetEventsSince(ctx, 1, 0)
+	if err != nil || len(events) != 1 || events[0].ID != 2 {
+		t.Fatalf("GetEventsSince() = %v, %v, want the event 2", events, err)
+	}
+
+	stored, err := restarted.AppendEvents(ctx, []domain.Event{{Type: domain.EventNewHead}})
+	if err != nil || stored[0].ID != 3 {
+		t.Fatalf("AppendEvents() = %v, %v, want the IDs carrying on from 3", stored, err)
+	}
+
+	if events, err = NewFileEvents(path).GetEventsSince(ctx, 2, 0); err != nil || len(events) != 1 {
+		t.Fatalf("GetEventsSince() = %v, %v, want the event appended after the cut line", events, err)
+	}
+
+	if err = os.WriteFile(path+".cursors", []byte("{"), 0o600); err != nil {
+		t.Fatalf("failed to corrupt the cursors file: %v", err)
+	}
+
+	if _, err = NewFileEvents(path).GetSinkCursor(ctx, "file"); err == nil {
+		t.Errorf("GetSinkCursor() error = nil, want the corrupted file reported")
+	}
+}
@@ -3,26 +3,50 @@ package storage
 import (
 	"context"
 	"errors"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
 )
 
 type InMemory struct {
-	mu           sync.RWMutex
-	lastBlock    map[string]int64
-	transactions map[string]map[string]domain.Transaction
+	mu              sync.RWMutex
+	lastBlock       map[domain.Address]uint64
+	transactions    map[domain.Address]map[string]domain.Transaction
+	byHash          map[string]map[recordRef]struct{}
+	byBlock         map[uint64]map[recordRef]struct{}
+	blockTimestamps map[uint64]int64
+	pending         map[domain.Address]map[string]domain.PendingTransaction
+	webhooks        map[webhookKey]domain.Webhook
+	deliveries      map[string]domain.WebhookDelivery
+	events          []domain.Event
+	lastEventID     uint64
+	sinkCursors     map[string]uint64
+	checkpoints     map[string]domain.BackfillCheckpoint
+	coverage        map[domain.Address][]domain.BlockRange
+	gaps            map[domain.Address]map[domain.BlockRange]domain.Gap
 }
 
 func NewInMemory() *InMemory {
 	return &InMemory{
-		mu:           sync.RWMutex{},
-		lastBlock:    make(map[string]int64),
-		transactions: make(map[string]map[string]domain.Transaction),
+		mu:              sync.RWMutex{},
+		lastBlock:       make(map[domain.Address]uint64),
+		transactions:    make(map[domain.Address]map[string]domain.Transaction),
+		byHash:          make(map[string]map[recordRef]struct{}),
+		byBlock:         make(map[uint64]map[recordRef]struct{}),
+		blockTimestamps: make(map[uint64]int64),
+		pending:         make(map[domain.Address]map[string]domain.PendingTransaction),
+		webhooks:        make(map[webhookKey]domain.Webhook),
+		deliveries:      make(map[string]domain.WebhookDelivery),
+		sinkCursors:     make(map[string]uint64),
+		checkpoints:     make(map[string]domain.BackfillCheckpoint),
+		coverage:        make(map[domain.Address][]domain.BlockRange),
+		gaps:            make(map[domain.Address]map[domain.BlockRange]domain.Gap),
 	}
 }
 
-func (s *InMemory) Add(_ context.Context, address string, transactions []domain.Transaction) error {
+func (s *InMemory) Add(_ context.Context, address domain.Address, transactions []domain.Transaction) error {
 	if len(transactions) == 0 {
 		return nil
 	}
@@ -35,14 +59,80 @@ func (s *InMemory) Add(_ context.Context, address string, transactions []domain.
 	}
 
 	for _, v := range transactions {
-		s.transactions[address][v.Hash] = v
+		s.index(address, v)
+		s.transactions[address][v.Key()] = v
+
+		if v.Timestamp > 0 {
+			s.blockTimestamps[v.DecimalBlockNumber] = v.Timestamp
+		}
+	}
+
+	return nil
+}
+
+// Remove deletes the given transactions, e.g. logs removed by a reorg, as long as they're still on the same block.
+func (s *InMemory) Remove(_ context.Context, address domain.Address, transactions []domain.Transaction) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.transactions[address]
+	if !ok {
+		return nil
+	}
+
+	for _, v := range transactions {
+		if existing, ok := stored[v.Key()]; ok && existing.BlockHash == v.BlockHash {
+			s.unindex(address, existing)
+			delete(stored, v.Key())
+		}
 	}
 
 	return nil
 }
 
-func (s *InMemory) UpdateLastBlock(_ context.Context, address string, blockNumber int64) error {
-	if blockNumber <= 0 {
+// ReplaceBlockRange supersedes the stored logs of the address within [fromBlock, toBlock] with the given ones, e.g.
+// when the range is re-indexed, returning the logs superseded. Internal transactions aren't returned by eth_getLogs,
+// so they are kept. Readers never observe the range partially replaced.
+func (s *InMemory) ReplaceBlockRange(
+	_ context.Context,
+	address domain.Address,
+	fromBlock, toBlock uint64,
+	transactions []domain.Transaction,
+) ([]domain.Transaction, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.transactions[address]; !ok {
+		s.transactions[address] = make(map[string]domain.Transaction)
+	}
+
+	var (
+		stored     = s.transactions[address]
+		superseded []domain.Transaction
+	)
+
+	for key, v := range stored {
+		if v.Type != domain.TransactionTypeInternal && v.DecimalBlockNumber >= fromBlock && v.DecimalBlockNumber <= toBlock {
+			s.unindex(address, v)
+			delete(stored, key)
+			superseded = append(superseded, v)
+		}
+	}
+
+	for _, v := range transactions {
+		s.index(address, v)
+		stored[v.Key()] = v
+
+		if v.Timestamp > 0 {
+			s.blockTimestamps[v.DecimalBlockNumber] = v.Timestamp
+		}
+	}
+
+	return superseded, nil
+}
+
+func (s *InMemory) UpdateLastBlock(_ context.Context, address domain.Address, blockNumber uint64) error {
+	if blockNumber == 0 {
 		return errors.New("block number must be greater than zero")
 	}
 
@@ -54,7 +144,7 @@ func (s *InMemory) UpdateLastBlock(_ context.Context, address string, blockNumbe
 	return nil
 }
 
-func (s *InMemory) GetTransactions(_ context.Context, address string) ([]domain.Transaction, error) {
+func (s *InMemory) GetTransactions(_ context.Context, address domain.Address) ([]domain.Transaction, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -65,22 +155,183 @@ func (s *InMemory) GetTransactions(_ context.Context, address string) ([]domain.
 	return mapTransactionToSlice(s.transactions[address]), nil
 }
 
-func (s *InMemory) GetLatestBlock(_ context.Context) (int64, error) {
+// QueryTransactions returns a page of the records of the query address, from the query cursor.
+func (s *InMemory) QueryTransactions(_ context.Context, query domain.TransactionQuery) (domain.TransactionPage, error) {
+	var cursor *domain.Cursor
+
+	if query.Cursor != "" {
+		c, err := domain.DecodeCursor(query.Cursor)
+		if err != nil {
+			return domain.TransactionPage{}, err
+		}
+
+		cursor = &c
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stored, ok := s.transactions[query.Address]
+	if !ok {
+		return domain.TransactionPage{Transactions: []domain.Transaction{}}, domain.ErrAddressNotFound
+	}
+
+	var matched = make([]domain.Transaction, 0)
+
+	for _, v := range stored {
+		if !query.Matches(v) || (cursor != nil && !query.After(v, *cursor)) {
+			continue
+		}
+
+		matched = append(matched, v)
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		if query.Order == domain.SortDescending {
+			return domain.LessTransaction(matched[j], matched[i])
+		}
+
+		return domain.LessTransaction(matched[i], matched[j])
+	})
+
+	if query.Limit <= 0 || len(matched) <= query.Limit {
+		return domain.TransactionPage{Transactions: matched}, nil
+	}
+
+	matched = matched[:query.Limit]
+
+	return domain.TransactionPage{
+		Transactions: matched,
+		NextCursor:   domain.CursorOf(matched[len(matched)-1]).Encode(),
+	}, nil
+}
+
+// GetTransactionsByBlockRange returns the transactions of the given address within [fromBlock, toBlock].
+func (s *InMemory) GetTransactionsByBlockRange(
+	_ context.Context,
+	address domain.Address,
+	fromBlock, toBlock uint64,
+) ([]domain.Transaction, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.transactions[address]; !ok {
+		return []domain.Transaction{}, domain.ErrAddressNotFound
+	}
+
+	var slice = make([]domain.Transaction, 0)
+
+	for _, v := range s.transactions[address] {
+		if v.DecimalBlockNumber >= fromBlock && v.DecimalBlockNumber <= toBlock {
+			slice = append(slice, v)
+		}
+	}
+
+	return slice, nil
+}
+
+// GetBlockRange resolves a time range into the range of known blocks produced within it.
+// A zero from or to means the range is unbounded on that side.
+func (s *InMemory) GetBlockRange(_ context.Context, from, to time.Time) (uint64, uint64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var (
+		fromBlock, toBlock uint64
+		found              bool
+	)
+
+	for number, timestamp := range s.blockTimestamps {
+		if !from.IsZero() && timestamp < from.Unix() {
+			continue
+		}
+
+		if !to.IsZero() && timestamp > to.Unix() {
+			continue
+		}
+
+		if !found || number < fromBlock {
+			fromBlock = number
+		}
+
+		if !found || number > toBlock {
+			toBlock = number
+		}
+
+		found = true
+	}
+
+	if !found {
+		return 0, 0, domain.ErrBlockNotFound
+	}
+
+	return fromBlock, toBlock, nil
+}
+
+// SavePendingTransactions inserts or updates the given pending transactions, keyed by address and hash.
+func (s *InMemory) SavePendingTransactions(_ context.Context, transactions []domain.PendingTransaction) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, v := range transactions {
+		if _, ok := s.pending[v.Address]; !ok {
+			s.pending[v.Address] = make(map[string]domain.PendingTransaction)
+		}
+
+		// keeps when the transaction was seen for the first time
+		if existing, ok := s.pending[v.Address][v.Hash]; ok {
+			v.FirstSeenAt = existing.FirstSeenAt
+		}
+
+		s.pending[v.Address][v.Hash] = v
+	}
+
+	return nil
+}
+
+func (s *InMemory) GetPendingTransactions(_ context.Context, address domain.Address) ([]domain.PendingTransaction, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	// returns first element, if present
+	if _, ok := s.pending[address]; !ok {
+		return []domain.PendingTransaction{}, domain.ErrAddressNotFound
+	}
+
+	var slice = make([]domain.PendingTransaction, 0, len(s.pending[address]))
+
+	for _, v := range s.pending[address] {
+		slice = append(slice, v)
+	}
+
+	return slice, nil
+}
+
+// GetLatestBlock returns the highest block indexed across every address.
+func (s *InMemory) GetLatestBlock(_ context.Context) (uint64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.lastBlock) == 0 {
+		return 0, domain.ErrBlockNotFound
+	}
+
+	var latest uint64
+
 	for _, block := range s.lastBlock {
-		return block, nil
+		latest = max(latest, block)
 	}
 
-	return 0, domain.ErrBlockNotFound
+	return latest, nil
 }
 
+// mapTransactionToSlice returns the records ordered by block number and log index.
 func mapTransactionToSlice(m map[string]domain.Transaction) []domain.Transaction {
 	var slice []domain.Transaction
 	for _, v := range m {
 		slice = append(slice, v)
 	}
+	sort.Slice(slice, func(i, j int) bool {
+		return domain.LessTransaction(slice[i], slice[j])
+	})
 	return slice
 }
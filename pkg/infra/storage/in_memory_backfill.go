@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+// GetBackfillCheckpoint returns the checkpoint of the given backfill job, a zero one when it has none.
+func (s *InMemory) GetBackfillCheckpoint(_ context.Context, job string) (domain.BackfillCheckpoint, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.checkpoints[job], nil
+}
+
+// GetBackfillCheckpoints returns the checkpoints of every unfinished job, keyed by job.
+func (s *InMemory) GetBackfillCheckpoints(_ context.Context) (map[string]domain.BackfillCheckpoint, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var checkpoints = make(map[string]domain.BackfillCheckpoint, len(s.checkpoints))
+
+	for job, checkpoint := range s.checkpoints {
+		checkpoints[job] = checkpoint
+	}
+
+	return checkpoints, nil
+}
+
+func (s *InMemory) SaveBackfillCheckpoint(_ context.Context, job string, checkpoint domain.BackfillCheckpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.checkpoints[job] = checkpoint
+
+	return nil
+}
+
+func (s *InMemory) DeleteBackfillCheckpoint(_ context.Context, job string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.checkpoints, job)
+
+	return nil
+}
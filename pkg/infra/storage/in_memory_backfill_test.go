@@ -0,0 +1,37 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+func TestInMemory_BackfillCheckpoints(t *testing.T) {
+	var (
+		ctx        = context.Background()
+		checkpoint = domain.BackfillCheckpoint{FromBlock: 100, NextBlock: 151}
+	)
+
+	storage := NewInMemory()
+
+	if got, err := storage.GetBackfillCheckpoint(ctx, "rescan"); err != nil || got != (domain.BackfillCheckpoint{}) {
+		t.Fatalf("expected no checkpoint, got: %v, %v", got, err)
+	}
+
+	_ = storage.SaveBackfillCheckpoint(ctx, "rescan", checkpoint)
+
+	if got, _ := storage.GetBackfillCheckpoint(ctx, "rescan"); got != checkpoint {
+		t.Fatalf("expected checkpoint %v, got: %v", checkpoint, got)
+	}
+
+	if got, _ := storage.GetBackfillCheckpoints(ctx); len(got) != 1 || got["rescan"] != checkpoint {
+		t.Fatalf("expected the checkpoint listed, got: %v", got)
+	}
+
+	_ = storage.DeleteBackfillCheckpoint(ctx, "rescan")
+
+	if got, _ := storage.GetBackfillCheckpoint(ctx, "rescan"); got != (domain.BackfillCheckpoint{}) {
+		t.Fatalf("expected checkpoint to be deleted, got: %v", got)
+	}
+}
@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"context"
+	"sort"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+// AddCoveredRange records the blocks of the range as indexed for the address, merging it with the covered ones.
+func (s *InMemory) AddCoveredRange(_ context.Context, address domain.Address, r domain.BlockRange) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.coverage[address] = domain.MergeBlockRanges(append(s.coverage[address], r))
+
+	return nil
+}
+
+// GetCoveredRanges returns the merged block ranges indexed for the address, sorted by block.
+func (s *InMemory) GetCoveredRanges(_ context.Context, address domain.Address) ([]domain.BlockRange, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var ranges = make([]domain.BlockRange, len(s.coverage[address]))
+	copy(ranges, s.coverage[address])
+
+	return ranges, nil
+}
+
+// SaveGap stores the gap, superseding the one of the same range.
+func (s *InMemory) SaveGap(_ context.Context, gap domain.Gap) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.gaps[gap.Address]; !ok {
+		s.gaps[gap.Address] = make(map[domain.BlockRange]domain.Gap)
+	}
+
+	s.gaps[gap.Address][gap.Range()] = gap
+
+	return nil
+}
+
+// GetGaps returns the gaps detected for the address, sorted by block.
+func (s *InMemory) GetGaps(_ context.Context, address domain.Address) ([]domain.Gap, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var gaps = make([]domain.Gap, 0, len(s.gaps[address]))
+
+	for _, gap := range s.gaps[address] {
+		gaps = append(gaps, gap)
+	}
+
+	sort.Slice(gaps, func(i, j int) bool {
+		return gaps[i].FromBlock < gaps[j].FromBlock
+	})
+
+	return gaps, nil
+}
@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+func TestInMemory_CoveredRanges(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		address = domain.Address("0x123")
+	)
+
+	storage := NewInMemory()
+
+	if got, err := storage.GetCoveredRanges(ctx, address); err != nil || len(got) != 0 {
+		t.Fatalf("expected no covered range, got: %v, %v", got, err)
+	}
+
+	_ = storage.AddCoveredRange(ctx, address, domain.BlockRange{FromBlock: 100, ToBlock: 110})
+	_ = storage.AddCoveredRange(ctx, address, domain.BlockRange{FromBlock: 150, ToBlock: 160})
+	_ = storage.AddCoveredRange(ctx, address, domain.BlockRange{FromBlock: 111, ToBlock: 120})
+
+	want := []domain.BlockRange{{FromBlock: 100, ToBlock: 120}, {FromBlock: 150, ToBlock: 160}}
+
+	if got, _ := storage.GetCoveredRanges(ctx, address); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected covered ranges %v, got: %v", want, got)
+	}
+}
+
+func TestInMemory_Gaps(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		address = domain.Address("0x123")
+	)
+
+	storage := NewInMemory()
+
+	_ = storage.SaveGap(ctx, domain.Gap{Address: address, FromBlock: 150, ToBlock: 160, State: domain.GapDetected})
+	_ = storage.SaveGap(ctx, domain.Gap{Address: address, FromBlock: 121, ToBlock: 130, State: domain.GapDetected})
+	_ = storage.SaveGap(ctx, domain.Gap{Address: address, FromBlock: 150, ToBlock: 160, State: domain.GapRepaired})
+
+	got, err := storage.GetGaps(ctx, address)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(got) != 2 || got[0].FromBlock != 121 || got[1].State != domain.GapRepaired {
+		t.Fatalf("expected the gaps sorted by block, the last state of each, got: %v", got)
+	}
+
+	if got, _ := storage.GetGaps(ctx, "0x456"); len(got) != 0 {
+		t.Fatalf("expected no gap of another address, got: %v", got)
+	}
+}
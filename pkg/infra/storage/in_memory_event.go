@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"context"
+	"sort"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+const (
+	// maxEvents bounds the events kept in memory, the oldest ones are discarded first.
+	maxEvents = 100_000
+	// trimmedEvents are discarded at once when the bound is reached, not to copy the events on every append
+	trimmedEvents = 10_000
+)
+
+// AppendEvents stores the given events, assigning them increasing IDs.
+func (s *InMemory) AppendEvents(_ context.Context, events []domain.Event) ([]domain.Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var stored = make([]domain.Event, len(events))
+
+	for i, v := range events {
+		s.lastEventID++
+		v.ID = s.lastEventID
+		stored[i] = v
+	}
+
+	s.events = trimEvents(append(s.events, stored...))
+
+	return stored, nil
+}
+
+// trimEvents discards the oldest events once there are more than maxEvents, keeping trimmedEvents less so that the
+// following appends don't trim again.
+func trimEvents(events []domain.Event) []domain.Event {
+	if len(events) <= maxEvents {
+		return events
+	}
+
+	return append(make([]domain.Event, 0, maxEvents), events[len(events)-maxEvents+trimmedEvents:]...)
+}
+
+// GetEventsSince returns, oldest first, up to limit events stored after the given event ID.
+func (s *InMemory) GetEventsSince(_ context.Context, afterID uint64, limit int) ([]domain.Event, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	start := sort.Search(len(s.events), func(i int) bool {
+		return s.events[i].ID > afterID
+	})
+
+	end := len(s.events)
+	if limit > 0 && start+limit < end {
+		end = start + limit
+	}
+
+	return append([]domain.Event(nil), s.events[start:end]...), nil
+}
+
+// GetSinkCursor returns the ID of the last event written by the given sink, zero when it has written none.
+func (s *InMemory) GetSinkCursor(_ context.Context, sink string) (uint64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.sinkCursors[sink], nil
+}
+
+func (s *InMemory) SaveSinkCursor(_ context.Context, sink string, eventID uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sinkCursors[sink] = eventID
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+func TestInMemory_Events(t *testing.T) {
+	var ctx = context.Background()
+
+	storage := NewInMemory()
+
+	events, err := storage.GetEventsSince(ctx, 0, 0)
+	if err != nil || len(events) != 0 {
+		t.Fatalf("expected no events, got: %v, %v", events, err)
+	}
+
+	stored, err := storage.AppendEvents(ctx, []domain.Event{
+		{Type: domain.EventTransactionAdded, Address: "0x1"},
+		{Type: domain.EventTransactionAdded, Address: "0x2"},
+	})
+	if err != nil {
+		t.Fatalf("expected error to be nil")
+	}
+	if stored[0].ID != 1 || stored[1].ID != 2 {
+		t.Fatalf("expected IDs 1 and 2, got: %v and %v", stored[0].ID, stored[1].ID)
+	}
+
+	_, _ = storage.AppendEvents(ctx, []domain.Event{{Type: domain.EventTransactionRemoved, Address: "0x1"}})
+
+	events, _ = storage.GetEventsSince(ctx, 1, 0)
+	if len(events) != 2 || events[0].ID != 2 || events[1].ID != 3 {
+		t.Fatalf("expected events 2 and 3, got: %v", events)
+	}
+
+	events, _ = storage.GetEventsSince(ctx, 0, 1)
+	if len(events) != 1 || events[0].ID != 1 {
+		t.Fatalf("expected event 1, got: %v", events)
+	}
+
+	events, _ = storage.GetEventsSince(ctx, 3, 0)
+	if len(events) != 0 {
+		t.Fatalf("expected no events after the last one, got: %v", events)
+	}
+}
+
+func TestInMemory_EventsTrimmed(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		events = make([]domain.Event, maxEvents)
+	)
+
+	storage := NewInMemory()
+
+	if _, err := storage.AppendEvents(ctx, events); err != nil {
+		t.Fatalf("expected error to be nil")
+	}
+
+	if got, _ := storage.GetEventsSince(ctx, 0, 1); len(got) != 1 || got[0].ID != 1 {
+		t.Fatalf("expected the events to be kept up to the bound, got: %v", got)
+	}
+
+	// the oldest events are discarded in a batch once over the bound
+	_, _ = storage.AppendEvents(ctx, []domain.Event{{Type: domain.EventNewHead}})
+
+	got, _ := storage.GetEventsSince(ctx, 0, 1)
+	if want := uint64(trimmedEvents + 2); len(got) != 1 || got[0].ID != want {
+		t.Fatalf("expected the oldest event to be %d, got: %v", want, got)
+	}
+
+	if len(storage.events) != maxEvents-trimmedEvents {
+		t.Fatalf("expected %d events kept, got: %d", maxEvents-trimmedEvents, len(storage.events))
+	}
+}
+
+func TestInMemory_SinkCursors(t *testing.T) {
+	var ctx = context.Background()
+
+	storage := NewInMemory()
+
+	if cursor, err := storage.GetSinkCursor(ctx, "file"); err != nil || cursor != 0 {
+		t.Fatalf("expected cursor to start at zero, got: %v, %v", cursor, err)
+	}
+
+	_ = storage.SaveSinkCursor(ctx, "file", 5)
+	_ = storage.SaveSinkCursor(ctx, "stdout", 2)
+
+	if cursor, _ := storage.GetSinkCursor(ctx, "file"); cursor != 5 {
+		t.Fatalf("expected cursor 5, got: %v", cursor)
+	}
+
+	if cursor, _ := storage.GetSinkCursor(ctx, "stdout"); cursor != 2 {
+		t.Fatalf("expected cursor 2, got: %v", cursor)
+	}
+}
@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+// recordRef locates a stored record, the same transaction being recorded once per subscribed address.
+type recordRef struct {
+	address domain.Address
+	key     string
+}
+
+// GetTransactionsByHash returns the records of the given transaction across every subscribed address.
+func (s *InMemory) GetTransactionsByHash(_ context.Context, hash string) ([]domain.Transaction, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	refs, ok := s.byHash[strings.ToLower(hash)]
+	if !ok {
+		return []domain.Transaction{}, domain.ErrTransactionNotFound
+	}
+
+	return s.resolve(refs), nil
+}
+
+// GetTransactionsByBlock returns the records of the given block across every subscribed address.
+func (s *InMemory) GetTransactionsByBlock(_ context.Context, blockNumber uint64) ([]domain.Transaction, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.resolve(s.byBlock[blockNumber]), nil
+}
+
+// index adds the record to the hash and block indexes, replacing the entries of a previous version of it.
+func (s *InMemory) index(address domain.Address, t domain.Transaction) {
+	if existing, ok := s.transactions[address][t.Key()]; ok {
+		s.unindex(address, existing)
+	}
+
+	var (
+		ref  = recordRef{address: address, key: t.Key()}
+		hash = strings.ToLower(t.Hash)
+	)
+
+	if _, ok := s.byHash[hash]; !ok {
+		s.byHash[hash] = make(map[recordRef]struct{})
+	}
+
+	if _, ok := s.byBlock[t.DecimalBlockNumber]; !ok {
+		s.byBlock[t.DecimalBlockNumber] = make(map[recordRef]struct{})
+	}
+
+	s.byHash[hash][ref] = struct{}{}
+	s.byBlock[t.DecimalBlockNumber][ref] = struct{}{}
+}
+
+func (s *InMemory) unindex(address domain.Address, t domain.Transaction) {
+	var (
+		ref  = recordRef{address: address, key: t.Key()}
+		hash = strings.ToLower(t.Hash)
+	)
+
+	delete(s.byHash[hash], ref)
+	if len(s.byHash[hash]) == 0 {
+		delete(s.byHash, hash)
+	}
+
+	delete(s.byBlock[t.DecimalBlockNumber], ref)
+	if len(s.byBlock[t.DecimalBlockNumber]) == 0 {
+		delete(s.byBlock, t.DecimalBlockNumber)
+	}
+}
+
+// resolve returns the referenced records, ordered by block number, log index and address.
+func (s *InMemory) resolve(refs map[recordRef]struct{}) []domain.Transaction {
+	var slice = make([]domain.Transaction, 0, len(refs))
+
+	for ref := range refs {
+		if v, ok := s.transactions[ref.address][ref.key]; ok {
+			slice = append(slice, v)
+		}
+	}
+
+	sort.Slice(slice, func(i, j int) bool {
+		if domain.CursorOf(slice[i]) == domain.CursorOf(slice[j]) {
+			return slice[i].Address < slice[j].Address
+		}
+
+		return domain.LessTransaction(slice[i], slice[j])
+	})
+
+	return slice
+}
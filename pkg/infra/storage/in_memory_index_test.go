@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+func TestInMemory_GetTransactionsByHashAndBlock(t *testing.T) {
+	const hash = "0xabc"
+
+	var (
+		ctx      = context.Background()
+		sender   = domain.Address("0x1")
+		receiver = domain.Address("0x2")
+
+		sent     = domain.Transaction{Hash: hash, Address: sender, DecimalBlockNumber: 10, BlockHash: "0xb10"}
+		received = domain.Transaction{Hash: hash, Address: receiver, DecimalBlockNumber: 10, BlockHash: "0xb10"}
+		other    = domain.Transaction{Hash: "0xdef", Address: sender, DecimalBlockNumber: 10, LogIndex: 1, BlockHash: "0xb10"}
+	)
+	storage := NewInMemory()
+
+	_, err := storage.GetTransactionsByHash(ctx, hash)
+	if err != domain.ErrTransactionNotFound {
+		t.Fatalf("expected error due to transaction not found, got: %v", err)
+	}
+
+	if err = storage.Add(ctx, sender, []domain.Transaction{sent, other}); err != nil {
+		t.Fatalf("expected error to be nil")
+	}
+
+	if err = storage.Add(ctx, receiver, []domain.Transaction{received}); err != nil {
+		t.Fatalf("expected error to be nil")
+	}
+
+	byHash, err := storage.GetTransactionsByHash(ctx, "0xABC")
+	if err != nil {
+		t.Fatalf("expected error to be nil, got: %v", err)
+	}
+	if !reflect.DeepEqual(byHash, []domain.Transaction{sent, received}) {
+		t.Fatalf("expected the records of both addresses, got: %v", byHash)
+	}
+
+	byBlock, _ := storage.GetTransactionsByBlock(ctx, 10)
+	if !reflect.DeepEqual(byBlock, []domain.Transaction{sent, received, other}) {
+		t.Fatalf("expected every record of block 10, got: %v", byBlock)
+	}
+
+	// the transaction is reorged into block 11
+	if err = storage.Remove(ctx, receiver, []domain.Transaction{received}); err != nil {
+		t.Fatalf("expected error to be nil")
+	}
+
+	moved := sent
+	moved.DecimalBlockNumber, moved.BlockHash = 11, "0xb11"
+
+	if err = storage.Add(ctx, sender, []domain.Transaction{moved}); err != nil {
+		t.Fatalf("expected error to be nil")
+	}
+
+	byHash, _ = storage.GetTransactionsByHash(ctx, hash)
+	if !reflect.DeepEqual(byHash, []domain.Transaction{moved}) {
+		t.Fatalf("expected only the reorged record, got: %v", byHash)
+	}
+
+	byBlock, _ = storage.GetTransactionsByBlock(ctx, 10)
+	if !reflect.DeepEqual(byBlock, []domain.Transaction{other}) {
+		t.Fatalf("expected block 10 to only hold the other record, got: %v", byBlock)
+	}
+
+	byBlock, _ = storage.GetTransactionsByBlock(ctx, 12)
+	if len(byBlock) != 0 {
+		t.Fatalf("expected no records on block 12, got: %v", byBlock)
+	}
+}
@@ -2,7 +2,9 @@ package storage
 
 import (
 	"context"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
 )
@@ -54,3 +56,303 @@ func TestNewInMemory(t *testing.T) {
 		t.Fatalf("expected latest block to be 3, got: %v", latestBlock)
 	}
 }
+
+func TestInMemory_GetLatestBlock(t *testing.T) {
+	var ctx = context.Background()
+
+	storage := NewInMemory()
+
+	// the addresses are indexed up to different blocks, the latest one being the highest whatever the map order
+	for address, block := range map[domain.Address]uint64{"0x1": 10, "0x2": 30, "0x3": 20} {
+		if err := storage.UpdateLastBlock(ctx, address, block); err != nil {
+			t.Fatalf("expected error to be nil")
+		}
+	}
+
+	for i := 0; i < 10; i++ {
+		latestBlock, err := storage.GetLatestBlock(ctx)
+		if err != nil {
+			t.Fatalf("expected error to be nil")
+		}
+		if latestBlock != 30 {
+			t.Fatalf("expected latest block to be 30, got: %v", latestBlock)
+		}
+	}
+}
+
+func TestInMemory_GetTransactionsByTime(t *testing.T) {
+	var (
+		t1 = domain.Transaction{Hash: "0x1", DecimalBlockNumber: 1, Timestamp: 100}
+		t2 = domain.Transaction{Hash: "0x2", DecimalBlockNumber: 2, Timestamp: 112}
+		t3 = domain.Transaction{Hash: "0x3", DecimalBlockNumber: 3, Timestamp: 124}
+
+		ctx = context.Background()
+	)
+	storage := NewInMemory()
+
+	_, _, err := storage.GetBlockRange(ctx, time.Unix(100, 0), time.Unix(200, 0))
+	if err != domain.ErrBlockNotFound {
+		t.Fatalf("expected error due to block not found, got: %v", err)
+	}
+
+	if err = storage.Add(ctx, "1", []domain.Transaction{t1, t2, t3}); err != nil {
+		t.Fatalf("expected error to be nil")
+	}
+
+	fromBlock, toBlock, err := storage.GetBlockRange(ctx, time.Unix(110, 0), time.Time{})
+	if err != nil {
+		t.Fatalf("expected error to be nil")
+	}
+	if fromBlock != 2 || toBlock != 3 {
+		t.Fatalf("expected block range to be [2, 3], got: [%v, %v]", fromBlock, toBlock)
+	}
+
+	fromBlock, toBlock, err = storage.GetBlockRange(ctx, time.Time{}, time.Unix(112, 0))
+	if err != nil {
+		t.Fatalf("expected error to be nil")
+	}
+	if fromBlock != 1 || toBlock != 2 {
+		t.Fatalf("expected block range to be [1, 2], got: [%v, %v]", fromBlock, toBlock)
+	}
+
+	transactions, err := storage.GetTransactionsByBlockRange(ctx, "1", fromBlock, toBlock)
+	if err != nil {
+		t.Fatalf("expected error to be nil")
+	}
+	if len(transactions) != 2 {
+		t.Fatalf("expected 2 transactions, got: %v", len(transactions))
+	}
+
+	_, err = storage.GetTransactionsByBlockRange(ctx, "2", fromBlock, toBlock)
+	if err != domain.ErrAddressNotFound {
+		t.Fatalf("expected error due to address not found, got: %v", err)
+	}
+}
+
+func TestInMemory_PendingTransactions(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		firstSeen = time.Unix(100, 0)
+		pending   = domain.PendingTransaction{
+			Hash:        "0x1",
+			Address:     "1",
+			Status:      domain.PendingStatusPending,
+			FirstSeenAt: firstSeen,
+		}
+	)
+	storage := NewInMemory()
+
+	_, err := storage.GetPendingTransactions(ctx, "1")
+	if err != domain.ErrAddressNotFound {
+		t.Fatalf("expected error due to address not found, got: %v", err)
+	}
+
+	if err = storage.SavePendingTransactions(ctx, []domain.PendingTransaction{pending}); err != nil {
+		t.Fatalf("expected error to be nil")
+	}
+
+	mined := pending.Mined(10, "0xb10", time.Unix(200, 0))
+	mined.FirstSeenAt = time.Unix(200, 0)
+
+	if err = storage.SavePendingTransactions(ctx, []domain.PendingTransaction{mined}); err != nil {
+		t.Fatalf("expected error to be nil")
+	}
+
+	all, err := storage.GetPendingTransactions(ctx, "1")
+	if err != nil {
+		t.Fatalf("expected error to be nil")
+	}
+	if len(all) != 1 {
+		t.Fatalf("expected 1 pending transaction, got: %v", len(all))
+	}
+	if all[0].Status != domain.PendingStatusMined {
+		t.Fatalf("expected transaction to be mined, got: %v", all[0].Status)
+	}
+	if !all[0].FirstSeenAt.Equal(firstSeen) {
+		t.Fatalf("expected first seen to be kept, got: %v", all[0].FirstSeenAt)
+	}
+}
+
+func TestInMemory_AddLogsOfTheSameTransaction(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		address = domain.Address("1")
+		logs    = []domain.Transaction{
+			{Hash: "0x1", Type: domain.TransactionTypeLog, BlockHash: "0xa", DecimalBlockNumber: 1, LogIndex: 0},
+			{Hash: "0x1", Type: domain.TransactionTypeLog, BlockHash: "0xa", DecimalBlockNumber: 1, LogIndex: 1},
+			{Hash: "0x1", Type: domain.TransactionTypeLog, BlockHash: "0xa", DecimalBlockNumber: 1, LogIndex: 2},
+		}
+	)
+	storage := NewInMemory()
+
+	// the logs are stored again on a duplicated poll
+	if err := storage.Add(ctx, address, append(logs, logs...)); err != nil {
+		t.Fatalf("expected error to be nil")
+	}
+
+	all, _ := storage.GetTransactions(ctx, address)
+	if len(all) != 3 {
+		t.Fatalf("expected the 3 logs of the transaction, got: %v", all)
+	}
+
+	page, err := storage.QueryTransactions(ctx, domain.TransactionQuery{Address: address, Limit: 2})
+	if err != nil {
+		t.Fatalf("expected error to be nil, got: %v", err)
+	}
+
+	page, err = storage.QueryTransactions(
+		ctx,
+		domain.TransactionQuery{Address: address, Limit: 2, Cursor: page.NextCursor},
+	)
+	if err != nil || len(page.Transactions) != 1 || page.Transactions[0].LogIndex != 2 {
+		t.Fatalf("expected the last log on the second page, got: %v, %v", page.Transactions, err)
+	}
+
+	if err = storage.Remove(ctx, address, logs[1:2]); err != nil {
+		t.Fatalf("expected error to be nil")
+	}
+
+	if all, _ = storage.GetTransactions(ctx, address); len(all) != 2 {
+		t.Fatalf("expected the other logs of the transaction to be kept, got: %v", all)
+	}
+}
+
+func TestInMemory_Remove(t *testing.T) {
+	var (
+		t1 = domain.Transaction{Hash: "0x1", BlockHash: "0xa", DecimalBlockNumber: 1}
+		t2 = domain.Transaction{Hash: "0x2", BlockHash: "0xb", DecimalBlockNumber: 2}
+
+		ctx = context.Background()
+	)
+	storage := NewInMemory()
+
+	if err := storage.Remove(ctx, "1", []domain.Transaction{t1}); err != nil {
+		t.Fatalf("expected removing from an unknown address to be a no-op, got: %v", err)
+	}
+
+	_ = storage.Add(ctx, "1", []domain.Transaction{t1, t2})
+
+	// t2 was re-included in another block meanwhile, so the removal of its old log must be ignored
+	stale := t2
+	stale.BlockHash = "0xc"
+
+	if err := storage.Remove(ctx, "1", []domain.Transaction{t1, stale}); err != nil {
+		t.Fatalf("expected error to be nil")
+	}
+
+	all, _ := storage.GetTransactions(ctx, "1")
+	if len(all) != 1 || all[0].Hash != "0x2" {
+		t.Fatalf("expected only 0x2 to remain, got: %v", all)
+	}
+}
+
+func TestInMemory_ReplaceBlockRange(t *testing.T) {
+	var (
+		t1       = domain.Transaction{Hash: "0x1", BlockHash: "0xa", DecimalBlockNumber: 1}
+		t2       = domain.Transaction{Hash: "0x2", BlockHash: "0xb", DecimalBlockNumber: 2}
+		t3       = domain.Transaction{Hash: "0x3", BlockHash: "0xc", DecimalBlockNumber: 3}
+		t4       = domain.Transaction{Hash: "0x4", BlockHash: "0xd", DecimalBlockNumber: 4}
+		internal = domain.Transaction{
+			Hash:               "0x5",
+			Type:               domain.TransactionTypeInternal,
+			BlockHash:          "0xb",
+			DecimalBlockNumber: 2,
+			TraceAddress:       "0",
+		}
+
+		ctx = context.Background()
+	)
+	storage := NewInMemory()
+
+	_ = storage.Add(ctx, "1", []domain.Transaction{t1, t2, t4, internal})
+
+	// t2 is superseded by t3, the records out of the range and the internal ones being kept
+	superseded, err := storage.ReplaceBlockRange(ctx, "1", 2, 3, []domain.Transaction{t3})
+	if err != nil {
+		t.Fatalf("expected error to be nil, got: %v", err)
+	}
+
+	if !reflect.DeepEqual(superseded, []domain.Transaction{t2}) {
+		t.Fatalf("expected 0x2 to be superseded, got: %v", superseded)
+	}
+
+	all, _ := storage.GetTransactions(ctx, "1")
+	if len(all) != 4 || all[0].Hash != "0x1" || all[1].Hash != "0x5" || all[2].Hash != "0x3" || all[3].Hash != "0x4" {
+		t.Fatalf("expected 0x1, 0x5, 0x3 and 0x4, got: %v", all)
+	}
+
+	if got, _ := storage.GetTransactionsByHash(ctx, "0x2"); len(got) != 0 {
+		t.Fatalf("expected the hash index of 0x2 to be removed, got: %v", got)
+	}
+}
+
+func TestInMemory_QueryTransactions(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		address = domain.Address("1")
+		t1      = domain.Transaction{Hash: "0x1", DecimalBlockNumber: 1, LogIndex: 0, Topics: []string{"0xaa"}}
+		t2      = domain.Transaction{Hash: "0x2", DecimalBlockNumber: 2, LogIndex: 5, Topics: []string{"0xbb"}}
+		t3      = domain.Transaction{Hash: "0x3", DecimalBlockNumber: 2, LogIndex: 1, Topics: []string{"0xaa"}}
+		t4      = domain.Transaction{Hash: "0x4", DecimalBlockNumber: 4, LogIndex: 0, Topics: []string{"0xaa"}}
+	)
+	storage := NewInMemory()
+
+	_, err := storage.QueryTransactions(ctx, domain.TransactionQuery{Address: address})
+	if err != domain.ErrAddressNotFound {
+		t.Fatalf("expected error due to address not found, got: %v", err)
+	}
+
+	if err = storage.Add(ctx, address, []domain.Transaction{t4, t2, t1, t3}); err != nil {
+		t.Fatalf("expected error to be nil")
+	}
+
+	hashes := func(query domain.TransactionQuery) []string {
+		var got []string
+
+		for {
+			page, err := storage.QueryTransactions(ctx, query)
+			if err != nil {
+				t.Fatalf("expected error to be nil, got: %v", err)
+			}
+
+			for _, v := range page.Transactions {
+				got = append(got, v.Hash)
+			}
+
+			if page.NextCursor == "" {
+				return got
+			}
+
+			query.Cursor = page.NextCursor
+		}
+	}
+
+	tests := []struct {
+		name  string
+		query domain.TransactionQuery
+		want  []string
+	}{
+		{
+			name:  "should page through the records by block number and log index",
+			query: domain.TransactionQuery{Address: address, Order: domain.SortAscending, Limit: 1},
+			want:  []string{"0x1", "0x3", "0x2", "0x4"},
+		},
+		{
+			name:  "should page through the records in descending order",
+			query: domain.TransactionQuery{Address: address, Order: domain.SortDescending, Limit: 3},
+			want:  []string{"0x4", "0x2", "0x3", "0x1"},
+		},
+		{
+			name:  "should filter by block range and topic0",
+			query: domain.TransactionQuery{Address: address, FromBlock: 2, ToBlock: 4, Topic0: "0xaa", Limit: 1},
+			want:  []string{"0x3", "0x4"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hashes(tt.query); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("QueryTransactions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
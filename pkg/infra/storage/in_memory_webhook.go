@@ -0,0 +1,95 @@
+package storage
+
+import (
+	"context"
+	"sort"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+// webhookKey identifies the webhook of an address on a chain, the same address being watched on several chains.
+type webhookKey struct {
+	chainID domain.ChainID
+	address domain.Address
+}
+
+// SaveWebhook registers the webhook of an address on its chain, replacing the previous one.
+func (s *InMemory) SaveWebhook(_ context.Context, webhook domain.Webhook) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.webhooks[webhookKey{chainID: webhook.ChainID, address: webhook.Address}] = webhook
+
+	return nil
+}
+
+func (s *InMemory) GetWebhook(
+	_ context.Context,
+	chainID domain.ChainID,
+	address domain.Address,
+) (domain.Webhook, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	webhook, ok := s.webhooks[webhookKey{chainID: chainID, address: address}]
+	if !ok {
+		return domain.Webhook{}, domain.ErrWebhookNotFound
+	}
+
+	return webhook, nil
+}
+
+// SaveDelivery inserts or updates the given delivery, keyed by ID.
+func (s *InMemory) SaveDelivery(_ context.Context, delivery domain.WebhookDelivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.deliveries[delivery.ID] = delivery
+
+	return nil
+}
+
+func (s *InMemory) GetDelivery(_ context.Context, id string) (domain.WebhookDelivery, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	delivery, ok := s.deliveries[id]
+	if !ok {
+		return domain.WebhookDelivery{}, domain.ErrDeliveryNotFound
+	}
+
+	return delivery, nil
+}
+
+// GetDeliveries returns the deliveries of the events of an address, oldest first.
+func (s *InMemory) GetDeliveries(_ context.Context, address domain.Address) ([]domain.WebhookDelivery, error) {
+	return s.filterDeliveries(func(d domain.WebhookDelivery) bool {
+		return d.Event.Address == address
+	}), nil
+}
+
+// GetDeadDeliveries returns the deliveries whose attempts were exhausted, oldest first.
+func (s *InMemory) GetDeadDeliveries(_ context.Context) ([]domain.WebhookDelivery, error) {
+	return s.filterDeliveries(func(d domain.WebhookDelivery) bool {
+		return d.Status == domain.DeliveryStatusDead
+	}), nil
+}
+
+func (s *InMemory) filterDeliveries(keep func(domain.WebhookDelivery) bool) []domain.WebhookDelivery {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var slice = make([]domain.WebhookDelivery, 0)
+
+	for _, v := range s.deliveries {
+		if keep(v) {
+			slice = append(slice, v)
+		}
+	}
+
+	sort.Slice(slice, func(i, j int) bool {
+		return slice[i].CreatedAt.Before(slice[j].CreatedAt)
+	})
+
+	return slice
+}
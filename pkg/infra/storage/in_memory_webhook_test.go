@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+func TestInMemory_Webhooks(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		address = domain.Address("0x1")
+		now     = time.Unix(1711724987, 0)
+	)
+	storage := NewInMemory()
+
+	if _, err := storage.GetWebhook(ctx, 1, address); err != domain.ErrWebhookNotFound {
+		t.Fatalf("expected error due to webhook not found, got: %v", err)
+	}
+
+	_ = storage.SaveWebhook(ctx, domain.Webhook{ChainID: 1, Address: address, URL: "http://old"})
+	_ = storage.SaveWebhook(ctx, domain.Webhook{ChainID: 1, Address: address, URL: "http://new"})
+	_ = storage.SaveWebhook(ctx, domain.Webhook{ChainID: 8453, Address: address, URL: "http://base"})
+
+	webhook, err := storage.GetWebhook(ctx, 1, address)
+	if err != nil {
+		t.Fatalf("expected error to be nil")
+	}
+	if webhook.URL != "http://new" {
+		t.Fatalf("expected webhook to be replaced, got: %v", webhook.URL)
+	}
+
+	// the same address has a webhook of its own on each chain
+	if webhook, _ = storage.GetWebhook(ctx, 8453, address); webhook.URL != "http://base" {
+		t.Fatalf("expected the webhook of the other chain, got: %v", webhook.URL)
+	}
+
+	if _, err = storage.GetWebhook(ctx, 10, address); err != domain.ErrWebhookNotFound {
+		t.Fatalf("expected error due to webhook not found on another chain, got: %v", err)
+	}
+
+	var (
+		d1 = domain.WebhookDelivery{ID: "1", Status: domain.DeliveryStatusDead, CreatedAt: now.Add(time.Second)}
+		d2 = domain.WebhookDelivery{ID: "2", Status: domain.DeliveryStatusDelivered, CreatedAt: now}
+		d3 = domain.WebhookDelivery{ID: "3", Status: domain.DeliveryStatusDead, CreatedAt: now}
+	)
+
+	d1.Event.Address = address
+	d2.Event.Address = address
+	d3.Event.Address = "0x2"
+
+	for _, d := range []domain.WebhookDelivery{d1, d2, d3} {
+		if err = storage.SaveDelivery(ctx, d); err != nil {
+			t.Fatalf("expected error to be nil")
+		}
+	}
+
+	if _, err = storage.GetDelivery(ctx, "4"); err != domain.ErrDeliveryNotFound {
+		t.Fatalf("expected error due to delivery not found, got: %v", err)
+	}
+
+	deliveries, _ := storage.GetDeliveries(ctx, address)
+	if len(deliveries) != 2 || deliveries[0].ID != "2" || deliveries[1].ID != "1" {
+		t.Fatalf("expected deliveries 2 and 1, got: %v", deliveries)
+	}
+
+	dead, _ := storage.GetDeadDeliveries(ctx)
+	if len(dead) != 2 || dead[0].ID != "3" || dead[1].ID != "1" {
+		t.Fatalf("expected dead deliveries 3 and 1, got: %v", dead)
+	}
+}
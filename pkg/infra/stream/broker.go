@@ -0,0 +1,366 @@
+package stream
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+const (
+	defaultBufferSize      = 256
+	defaultBacklogPageSize = 500
+)
+
+// EventLog stores the published events, so consumers can resume after the last event they've seen.
+type EventLog interface {
+	AppendEvents(ctx context.Context, events []domain.Event) ([]domain.Event, error)
+	GetEventsSince(ctx context.Context, afterID uint64, limit int) ([]domain.Event, error)
+}
+
+type Options func(*Broker)
+
+func WithConfig(cfg *Config) Options {
+	return func(b *Broker) {
+		b.cfg = cfg
+	}
+}
+
+func WithLogger(l *slog.Logger) Options {
+	return func(b *Broker) {
+		b.logger = l
+	}
+}
+
+type Config struct {
+	// BufferSize is the default number of live events buffered per subscriber
+	BufferSize int
+	// BacklogPageSize is the number of stored events read at once when resuming
+	BacklogPageSize int
+}
+
+// Broker stores the published events into the event log and fans them out to the live subscribers.
+type Broker struct {
+	mu          sync.Mutex
+	logger      *slog.Logger
+	cfg         *Config
+	log         EventLog
+	subscribers map[*subscriber]struct{}
+}
+
+type subscriber struct {
+	ctx    context.Context
+	filter domain.EventFilter
+	policy domain.SlowConsumerPolicy
+	// events is never closed, as it's sent to outside the lock
+	events chan domain.Event
+	// done is closed once the subscriber is removed
+	done chan struct{}
+	// turn is closed once the events published before are handed to the subscriber
+	turn chan struct{}
+}
+
+func (s *subscriber) matchesAny(events []domain.Event) bool {
+	for _, v := range events {
+		if s.filter.Matches(v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// delivery is the hand over of published events to a subscriber, once the previous one is over.
+type delivery struct {
+	sub  *subscriber
+	wait <-chan struct{}
+	over chan struct{}
+}
+
+func NewBroker(log EventLog, opts ...Options) *Broker {
+	b := &Broker{
+		logger:      slog.Default(),
+		cfg:         &Config{BufferSize: defaultBufferSize, BacklogPageSize: defaultBacklogPageSize},
+		log:         log,
+		subscribers: make(map[*subscriber]struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	return b
+}
+
+// Publish stores the events and hands them to the matching subscribers.
+// The events are handed over outside the lock, so a blocked subscriber only holds up its own deliveries, until it
+// catches up, is removed, or the context is done.
+func (b *Broker) Publish(ctx context.Context, events []domain.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	// stores and queues the deliveries under the same lock, so subscribers receive events ordered by ID
+	b.mu.Lock()
+
+	stored, err := b.log.AppendEvents(ctx, events)
+	if err != nil {
+		b.mu.Unlock()
+		return errors.Wrap(err, "failed to store events")
+	}
+
+	var deliveries = make([]delivery, 0, len(b.subscribers))
+
+	for sub := range b.subscribers {
+		// a subscriber blocked on earlier events doesn't hold up the events it doesn't match
+		if !sub.matchesAny(stored) {
+			continue
+		}
+
+		d := delivery{sub: sub, wait: sub.turn, over: make(chan struct{})}
+		sub.turn = d.over
+
+		deliveries = append(deliveries, d)
+	}
+
+	b.mu.Unlock()
+
+	var wg sync.WaitGroup
+
+	for _, d := range deliveries {
+		wg.Add(1)
+
+		go func(d delivery) {
+			defer wg.Done()
+			b.deliver(ctx, d, stored)
+		}(d)
+	}
+
+	wg.Wait()
+
+	return nil
+}
+
+// deliver hands the events to the subscriber once the previous delivery is over.
+func (b *Broker) deliver(ctx context.Context, d delivery, events []domain.Event) {
+	select {
+	case <-d.wait:
+	case <-d.sub.done:
+	case <-ctx.Done():
+		// the events are skipped, the next delivery still waits for the previous one
+		go func() {
+			<-d.wait
+			close(d.over)
+		}()
+
+		return
+	}
+
+	defer close(d.over)
+
+	b.fanOut(ctx, d.sub, events)
+}
+
+// fanOut hands the events to the subscriber, applying its policy once its buffer is full.
+func (b *Broker) fanOut(ctx context.Context, sub *subscriber, events []domain.Event) {
+	for _, v := range events {
+		if !sub.filter.Matches(v) {
+			continue
+		}
+
+		select {
+		case sub.events <- v:
+			continue
+		case <-sub.done:
+			return
+		default:
+		}
+
+		switch sub.policy {
+		case domain.SlowConsumerBlock:
+			select {
+			case sub.events <- v:
+			case <-sub.done:
+				return
+			case <-sub.ctx.Done():
+				return
+			case <-ctx.Done():
+				return
+			}
+
+		case domain.SlowConsumerDropOldest:
+			b.dropOldest(sub, v)
+
+		default:
+			b.logger.Warn("Disconnecting slow event subscriber", "event", v.ID)
+			b.unsubscribe(sub)
+			return
+		}
+	}
+}
+
+// dropOldest makes room for the event by discarding the oldest buffered ones.
+// The deliveries to a subscriber being sequential, the buffer can't be filled again in between.
+func (b *Broker) dropOldest(sub *subscriber, v domain.Event) {
+	for {
+		select {
+		case sub.events <- v:
+			return
+		default:
+		}
+
+		select {
+		case dropped := <-sub.events:
+			b.logger.Debug("Dropping event of slow subscriber", "event", dropped.ID)
+		default:
+		}
+	}
+}
+
+// Subscribe streams the live events matching the filter.
+// The channel is closed once the context is done, or when a slow subscriber is disconnected.
+func (b *Broker) Subscribe(ctx context.Context, filter domain.EventFilter, opts ...domain.WatchOption) <-chan domain.Event {
+	return b.subscribe(ctx, filter, 0, false, opts)
+}
+
+// SubscribeSince streams the stored events matching the filter after the given event ID, then the live ones.
+func (b *Broker) SubscribeSince(
+	ctx context.Context,
+	filter domain.EventFilter,
+	afterID uint64,
+	opts ...domain.WatchOption,
+) <-chan domain.Event {
+	return b.subscribe(ctx, filter, afterID, true, opts)
+}
+
+func (b *Broker) subscribe(
+	ctx context.Context,
+	filter domain.EventFilter,
+	afterID uint64,
+	replay bool,
+	opts []domain.WatchOption,
+) <-chan domain.Event {
+	var options = domain.WatchOptions{BufferSize: b.cfg.BufferSize, Policy: domain.SlowConsumerDisconnect}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	// the slow consumer policies rely on a buffer
+	if options.BufferSize < 1 {
+		options.BufferSize = 1
+	}
+
+	var (
+		sub = &subscriber{
+			ctx:    ctx,
+			filter: filter,
+			policy: options.Policy,
+			events: make(chan domain.Event, options.BufferSize),
+			done:   make(chan struct{}),
+			turn:   make(chan struct{}),
+		}
+		out = make(chan domain.Event)
+	)
+
+	close(sub.turn)
+
+	// registers before reading the backlog, so no event is missed in between
+	b.mu.Lock()
+	b.subscribers[sub] = struct{}{}
+	b.mu.Unlock()
+
+	go func() {
+		defer close(out)
+		defer b.unsubscribe(sub)
+
+		var last = afterID
+
+		if replay {
+			var ok bool
+
+			if last, ok = b.replay(ctx, filter, afterID, out); !ok {
+				return
+			}
+		}
+
+		for {
+			var v domain.Event
+
+			select {
+			case <-ctx.Done():
+				return
+
+			case v = <-sub.events:
+
+			case <-sub.done:
+				// the events buffered before the removal are still handed over
+				select {
+				case v = <-sub.events:
+				default:
+					return
+				}
+			}
+
+			// already sent from the backlog
+			if replay && v.ID <= last {
+				continue
+			}
+
+			select {
+			case out <- v:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+// replay sends the stored events after the given ID, returning the ID of the last one read.
+func (b *Broker) replay(
+	ctx context.Context,
+	filter domain.EventFilter,
+	afterID uint64,
+	out chan<- domain.Event,
+) (uint64, bool) {
+	for {
+		events, err := b.log.GetEventsSince(ctx, afterID, b.cfg.BacklogPageSize)
+		if err != nil {
+			b.logger.Error("Failed to read events backlog", "error", err)
+			return afterID, false
+		}
+
+		for _, v := range events {
+			afterID = v.ID
+
+			if !filter.Matches(v) {
+				continue
+			}
+
+			select {
+			case out <- v:
+			case <-ctx.Done():
+				return afterID, false
+			}
+		}
+
+		if len(events) < b.cfg.BacklogPageSize {
+			return afterID, true
+		}
+	}
+}
+
+func (b *Broker) unsubscribe(sub *subscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subscribers[sub]; ok {
+		close(sub.done)
+		delete(b.subscribers, sub)
+	}
+}
@@ -0,0 +1,259 @@
+package stream
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/infra/storage"
+)
+
+func TestBroker_Subscribe(t *testing.T) {
+	var ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	broker := newTestBroker(10)
+
+	all := broker.Subscribe(ctx, domain.EventFilter{})
+	filtered := broker.Subscribe(ctx, domain.EventFilter{Addresses: []domain.Address{"0x2"}})
+
+	publish(t, broker, "0x1", "0x2", "0x1")
+
+	assertEventIDs(t, all, 1, 2, 3)
+	assertEventIDs(t, filtered, 2)
+
+	cancel()
+
+	if _, ok := <-all; ok {
+		t.Errorf("expected channel to be closed once the context is done")
+	}
+}
+
+func TestBroker_SubscribeSince(t *testing.T) {
+	var ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	broker := newTestBroker(10)
+
+	publish(t, broker, "0x1", "0x2", "0x1")
+
+	events := broker.SubscribeSince(ctx, domain.EventFilter{Addresses: []domain.Address{"0x1"}}, 1)
+
+	publish(t, broker, "0x1")
+
+	// resumes with the stored event 3, then the live event 4, without duplicates
+	assertEventIDs(t, events, 3, 4)
+}
+
+func TestBroker_DisconnectSlowSubscriber(t *testing.T) {
+	var ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	broker := newTestBroker(1)
+
+	events := broker.Subscribe(ctx, domain.EventFilter{})
+
+	publish(t, broker, "0x1", "0x1", "0x1", "0x1")
+
+	var received int
+
+	for range events {
+		received++
+	}
+
+	if received == 0 || received >= 4 {
+		t.Errorf("expected the slow subscriber to be disconnected, received %d events", received)
+	}
+}
+
+func newTestBroker(bufferSize int) *Broker {
+	return NewBroker(
+		storage.NewInMemory(),
+		WithLogger(slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))),
+		WithConfig(&Config{BufferSize: bufferSize, BacklogPageSize: 2}),
+	)
+}
+
+func publish(t *testing.T, broker *Broker, addresses ...domain.Address) {
+	t.Helper()
+
+	var events = make([]domain.Event, len(addresses))
+
+	for i, v := range addresses {
+		events[i] = domain.Event{Type: domain.EventTransactionAdded, Address: v}
+	}
+
+	if err := broker.Publish(context.Background(), events); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+}
+
+func assertEventIDs(t *testing.T, events <-chan domain.Event, ids ...uint64) {
+	t.Helper()
+
+	for _, id := range ids {
+		select {
+		case v := <-events:
+			if v.ID != id {
+				t.Fatalf("expected event %d, got: %d", id, v.ID)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("expected event %d, got none", id)
+		}
+	}
+
+	select {
+	case v := <-events:
+		t.Fatalf("expected no more events, got: %d", v.ID)
+	case <-time.After(time.Millisecond * 20):
+	}
+}
+
+func TestBroker_SubscribeDropOldest(t *testing.T) {
+	var ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	broker := newTestBroker(10)
+
+	events := broker.Subscribe(
+		ctx,
+		domain.EventFilter{},
+		domain.WithBufferSize(2),
+		domain.WithSlowConsumerPolicy(domain.SlowConsumerDropOldest),
+	)
+
+	publish(t, broker, "0x1", "0x1", "0x1", "0x1", "0x1", "0x1")
+
+	// at most one event is held by the forwarding goroutine besides the buffer, the newest ones are kept
+	var ids []uint64
+
+	for len(ids) == 0 || ids[len(ids)-1] != 6 {
+		select {
+		case v := <-events:
+			ids = append(ids, v.ID)
+		case <-time.After(time.Second):
+			t.Fatalf("expected the newest event, got: %v", ids)
+		}
+	}
+
+	if len(ids) > 3 {
+		t.Errorf("expected the oldest events to be dropped, got: %v", ids)
+	}
+}
+
+func TestBroker_SubscribeBlock(t *testing.T) {
+	var ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	broker := newTestBroker(10)
+
+	events := broker.Subscribe(
+		ctx,
+		domain.EventFilter{},
+		domain.WithBufferSize(1),
+		domain.WithSlowConsumerPolicy(domain.SlowConsumerBlock),
+	)
+
+	published := make(chan struct{})
+
+	go func() {
+		defer close(published)
+		publish(t, broker, "0x1", "0x1", "0x1", "0x1")
+	}()
+
+	select {
+	case <-published:
+		t.Fatalf("expected publishing to block until the subscriber catches up")
+	case <-time.After(time.Millisecond * 20):
+	}
+
+	// every event is delivered once the subscriber reads them
+	assertEventIDs(t, events, 1, 2, 3, 4)
+	<-published
+}
+
+func TestBroker_SubscribeBlockCancelled(t *testing.T) {
+	var ctx, cancel = context.WithCancel(context.Background())
+
+	broker := newTestBroker(10)
+
+	_ = broker.Subscribe(
+		ctx,
+		domain.EventFilter{},
+		domain.WithBufferSize(1),
+		domain.WithSlowConsumerPolicy(domain.SlowConsumerBlock),
+	)
+
+	published := make(chan struct{})
+
+	go func() {
+		defer close(published)
+		publish(t, broker, "0x1", "0x1", "0x1", "0x1")
+	}()
+
+	// cancelling the subscription must release the blocked publisher
+	cancel()
+
+	select {
+	case <-published:
+	case <-time.After(time.Second):
+		t.Fatalf("expected publishing to be released once the subscriber is cancelled")
+	}
+}
+
+func TestBroker_BlockedSubscriberDoesNotStallOthers(t *testing.T) {
+	var ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	broker := newTestBroker(10)
+
+	// never read
+	_ = broker.Subscribe(
+		ctx,
+		domain.EventFilter{Addresses: []domain.Address{"0x1"}},
+		domain.WithBufferSize(1),
+		domain.WithSlowConsumerPolicy(domain.SlowConsumerBlock),
+	)
+
+	healthy := broker.Subscribe(ctx, domain.EventFilter{})
+
+	published := make(chan struct{})
+
+	go func() {
+		defer close(published)
+		publish(t, broker, "0x1", "0x1", "0x1")
+	}()
+
+	// the healthy subscriber keeps receiving events, the broker accepts new subscribers and publishers meanwhile
+	assertEventIDs(t, healthy, 1, 2, 3)
+
+	other := broker.Subscribe(ctx, domain.EventFilter{Addresses: []domain.Address{"0x2"}})
+
+	publish(t, broker, "0x2")
+
+	assertEventIDs(t, healthy, 4)
+	assertEventIDs(t, other, 4)
+
+	// a publisher blocked by the subscriber is released once its context is done
+	timeout, cancelTimeout := context.WithTimeout(context.Background(), time.Millisecond*20)
+	defer cancelTimeout()
+
+	err := broker.Publish(timeout, []domain.Event{{Type: domain.EventTransactionAdded, Address: "0x1"}})
+	if err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	assertEventIDs(t, healthy, 5)
+
+	select {
+	case <-published:
+		t.Fatalf("expected the first publisher to stay blocked by the subscriber")
+	default:
+	}
+
+	cancel()
+	<-published
+}
@@ -0,0 +1,59 @@
+package verifier
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+// WriteReport writes the report as a diff of the stored records against the logs of the node: missing logs are
+// prefixed by a "+", extra records by a "-" and records stored with another block by a "~".
+func WriteReport(w io.Writer, report domain.IntegrityReport) error {
+	buf := bufio.NewWriter(w)
+
+	_, _ = fmt.Fprintf(
+		buf,
+		"%s blocks %d to %d: %d logs on the node, %d records stored\n",
+		report.Address,
+		report.FromBlock,
+		report.ToBlock,
+		report.Expected,
+		report.Stored,
+	)
+
+	for _, v := range report.Missing {
+		_, _ = fmt.Fprintf(buf, "+ block %d %s tx %s\n", v.DecimalBlockNumber, v.BlockHash, v.Hash)
+	}
+
+	for _, v := range report.Extra {
+		_, _ = fmt.Fprintf(buf, "- block %d %s tx %s\n", v.DecimalBlockNumber, v.BlockHash, v.Hash)
+	}
+
+	for _, v := range report.Mismatched {
+		_, _ = fmt.Fprintf(
+			buf,
+			"~ tx %s stored in block %d %s, on the node in block %d %s\n",
+			v.Expected.Hash,
+			v.Stored.DecimalBlockNumber,
+			v.Stored.BlockHash,
+			v.Expected.DecimalBlockNumber,
+			v.Expected.BlockHash,
+		)
+	}
+
+	if report.Consistent() {
+		_, _ = fmt.Fprintln(buf, "consistent")
+	}
+
+	for _, r := range report.Repaired {
+		_, _ = fmt.Fprintf(buf, "repaired blocks %d to %d\n", r.FromBlock, r.ToBlock)
+	}
+
+	if report.RepairError != "" {
+		_, _ = fmt.Fprintf(buf, "repair failed: %s\n", report.RepairError)
+	}
+
+	return buf.Flush()
+}
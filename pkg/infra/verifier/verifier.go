@@ -0,0 +1,215 @@
+package verifier
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/infra/backfill"
+)
+
+const defaultChunkSize = 1000
+
+type NodeAPI interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+	GetLogs(ctx context.Context, address domain.Address, fromBlock, toBlock uint64) ([]domain.Transaction, error)
+}
+
+type RecordReader interface {
+	GetTransactionsByBlockRange(
+		ctx context.Context,
+		address domain.Address,
+		fromBlock, toBlock uint64,
+	) ([]domain.Transaction, error)
+}
+
+// Repairer indexes a block range of an address again, superseding its stored records.
+type Repairer interface {
+	Reindex(ctx context.Context, address domain.Address, fromBlock, toBlock uint64) error
+}
+
+type Options func(*Verifier)
+
+func WithConfig(cfg *Config) Options {
+	return func(v *Verifier) {
+		v.cfg = cfg
+	}
+}
+
+func WithLogger(l *slog.Logger) Options {
+	return func(v *Verifier) {
+		v.logger = l
+	}
+}
+
+// WithRepairer enables repairing the blocks found to differ.
+func WithRepairer(repairer Repairer) Options {
+	return func(v *Verifier) {
+		v.repairer = repairer
+	}
+}
+
+type Config struct {
+	// ChunkSize is the initial number of blocks fetched by eth_getLogs call, halved while the node returns too many
+	// results, and doubled up to MaxChunkSize as chunks are fetched
+	ChunkSize    uint64
+	MaxChunkSize uint64
+	// Workers is the number of chunks fetched concurrently
+	Workers int
+	// Confirmations is the number of blocks on top of the last block verified, the records of the blocks after it
+	// not being stored yet
+	Confirmations uint64
+}
+
+// Verifier compares the records stored for an address with the logs fetched again from the node.
+type Verifier struct {
+	logger   *slog.Logger
+	cfg      *Config
+	api      NodeAPI
+	repo     RecordReader
+	repairer Repairer
+	// engine fetches the logs of the range verified, as a backfill does
+	engine *backfill.Engine
+}
+
+// collector gathers the logs fetched by the engine, which writes them in block order.
+type collector struct {
+	logs []domain.Transaction
+}
+
+func (c *collector) ReplaceBlockRange(
+	_ context.Context,
+	_ domain.Address,
+	_, _ uint64,
+	transactions []domain.Transaction,
+) error {
+	c.logs = append(c.logs, transactions...)
+	return nil
+}
+
+func NewVerifier(api NodeAPI, repo RecordReader, opts ...Options) *Verifier {
+	v := &Verifier{
+		logger: slog.Default(),
+		cfg:    &Config{ChunkSize: defaultChunkSize},
+		api:    api,
+		repo:   repo,
+	}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	if v.cfg.ChunkSize == 0 {
+		v.cfg.ChunkSize = defaultChunkSize
+	}
+
+	v.engine = backfill.NewEngine(
+		api,
+		backfill.WithLogger(v.logger),
+		backfill.WithConfig(&backfill.Config{
+			Workers:      v.cfg.Workers,
+			ChunkSize:    v.cfg.ChunkSize,
+			MaxChunkSize: v.cfg.MaxChunkSize,
+		}),
+	)
+
+	return v
+}
+
+// Verify reports the logs of [fromBlock, toBlock] missing from the stored records of the address, the records the
+// node doesn't know about and the ones stored with another block. With repair set, the blocks holding a difference
+// are indexed again, the report listing the ranges repaired.
+func (v *Verifier) Verify(
+	ctx context.Context,
+	address domain.Address,
+	fromBlock, toBlock uint64,
+	repair bool,
+) (domain.IntegrityReport, error) {
+	if repair && v.repairer == nil {
+		return domain.IntegrityReport{}, errors.New("repairing is not enabled")
+	}
+
+	if err := v.checkRange(ctx, fromBlock, toBlock); err != nil {
+		return domain.IntegrityReport{}, err
+	}
+
+	var expected collector
+
+	job := backfill.Job{Address: address, FromBlock: fromBlock, ToBlock: toBlock}
+
+	if err := v.engine.Run(ctx, job, &expected); err != nil {
+		return domain.IntegrityReport{}, err
+	}
+
+	stored, err := v.repo.GetTransactionsByBlockRange(ctx, address, fromBlock, toBlock)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return domain.IntegrityReport{}, errors.Wrap(err, "failed to get stored records")
+	}
+
+	report := domain.IntegrityReport{
+		Address:    address,
+		FromBlock:  fromBlock,
+		ToBlock:    toBlock,
+		VerifiedAt: time.Now(),
+	}
+
+	report.Diff(expected.logs, stored)
+
+	if !report.Consistent() {
+		v.logger.Warn(
+			"Stored records differ from the node",
+			"address", address,
+			"from_block", fromBlock,
+			"to_block", toBlock,
+			"missing", len(report.Missing),
+			"extra", len(report.Extra),
+			"mismatched", len(report.Mismatched),
+		)
+	}
+
+	if repair {
+		v.repair(ctx, &report)
+	}
+
+	return report, nil
+}
+
+// checkRange refuses the ranges going past the last confirmed block.
+func (v *Verifier) checkRange(ctx context.Context, fromBlock, toBlock uint64) error {
+	if fromBlock > toBlock {
+		return errors.Wrapf(domain.ErrInvalidBlockRange, "from %d to %d", fromBlock, toBlock)
+	}
+
+	head, err := v.api.BlockNumber(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch block number")
+	}
+
+	if head < v.cfg.Confirmations || toBlock > head-v.cfg.Confirmations {
+		return errors.Wrapf(domain.ErrInvalidBlockRange, "block %d is not confirmed yet", toBlock)
+	}
+
+	return nil
+}
+
+// repair indexes the blocks holding a difference again, stopping on the first range failing.
+func (v *Verifier) repair(ctx context.Context, report *domain.IntegrityReport) {
+	for _, r := range report.AffectedRanges() {
+		if err := v.repairer.Reindex(ctx, report.Address, r.FromBlock, r.ToBlock); err != nil {
+			err = errors.Wrapf(err, "failed to repair blocks from %d to %d", r.FromBlock, r.ToBlock)
+			v.logger.Error("Failed to repair records", "error", err, "address", report.Address)
+			report.RepairError = err.Error()
+
+			return
+		}
+
+		report.Repaired = append(report.Repaired, r)
+	}
+
+	if len(report.Repaired) > 0 {
+		v.logger.Info("Repaired records", "address", report.Address, "ranges", len(report.Repaired))
+	}
+}
@@ -0,0 +1,220 @@
+package verifier
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/mocks"
+)
+
+const address = domain.Address("0x123")
+
+func TestVerifier_Verify(t *testing.T) {
+	var (
+		logger = slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))
+		logs   = []domain.Transaction{
+			{Hash: "0x1", BlockHash: "0xa", DecimalBlockNumber: 100},
+			{Hash: "0x2", BlockHash: "0xb", DecimalBlockNumber: 112},
+			{Hash: "0x3", BlockHash: "0xc", DecimalBlockNumber: 125},
+		}
+		stored = []domain.Transaction{
+			{Hash: "0x1", BlockHash: "0xa", DecimalBlockNumber: 100},
+			{Hash: "0x3", BlockHash: "0xf", DecimalBlockNumber: 125},
+			{Hash: "0x4", BlockHash: "0xd", DecimalBlockNumber: 126},
+		}
+	)
+
+	tests := []struct {
+		name         string
+		api          func(*testing.T) NodeAPI
+		repo         func(*testing.T) RecordReader
+		repairer     func(*testing.T) Repairer
+		repair       bool
+		wantErr      error
+		wantRepaired []domain.BlockRange
+		wantRepairOK bool
+	}{
+		{
+			name: "should refuse blocks not confirmed yet",
+			api: func(t *testing.T) NodeAPI {
+				api := mocks.NewNodeAPI(t)
+				api.EXPECT().BlockNumber(mock.Anything).Return(130, nil).Once()
+				return api
+			},
+			repo:     func(t *testing.T) RecordReader { return mocks.NewRecordReader(t) },
+			repairer: func(t *testing.T) Repairer { return nil },
+			wantErr:  domain.ErrInvalidBlockRange,
+		},
+		{
+			name: "should report the differences, splitting the chunks refused by the node",
+			api: func(t *testing.T) NodeAPI {
+				api := mocks.NewNodeAPI(t)
+				api.EXPECT().BlockNumber(mock.Anything).Return(150, nil).Once()
+				api.EXPECT().GetLogs(mock.Anything, address, uint64(100), uint64(119)).
+					Return(nil, errors.Wrap(domain.ErrTooManyResults, "query returned more than 10000 results")).
+					Once()
+				api.EXPECT().GetLogs(mock.Anything, address, uint64(100), uint64(109)).Return(logs[:1], nil).Once()
+				api.EXPECT().GetLogs(mock.Anything, address, uint64(110), uint64(119)).Return(logs[1:2], nil).Once()
+				api.EXPECT().GetLogs(mock.Anything, address, uint64(120), uint64(129)).Return(logs[2:], nil).Once()
+				return api
+			},
+			repo: func(t *testing.T) RecordReader {
+				repo := mocks.NewRecordReader(t)
+				repo.EXPECT().GetTransactionsByBlockRange(mock.Anything, address, uint64(100), uint64(129)).
+					Return(stored, nil).
+					Once()
+				return repo
+			},
+			repairer: func(t *testing.T) Repairer { return nil },
+		},
+		{
+			name: "should repair the blocks holding a difference",
+			api: func(t *testing.T) NodeAPI {
+				api := mocks.NewNodeAPI(t)
+				api.EXPECT().BlockNumber(mock.Anything).Return(150, nil).Once()
+				api.EXPECT().GetLogs(mock.Anything, address, mock.Anything, mock.Anything).RunAndReturn(logsBetween(logs))
+				return api
+			},
+			repo: func(t *testing.T) RecordReader {
+				repo := mocks.NewRecordReader(t)
+				repo.EXPECT().GetTransactionsByBlockRange(mock.Anything, address, uint64(100), uint64(129)).
+					Return(stored, nil).
+					Once()
+				return repo
+			},
+			repairer: func(t *testing.T) Repairer {
+				repairer := mocks.NewRepairer(t)
+				repairer.EXPECT().Reindex(mock.Anything, address, uint64(112), uint64(112)).Return(nil).Once()
+				repairer.EXPECT().Reindex(mock.Anything, address, uint64(125), uint64(126)).Return(nil).Once()
+				return repairer
+			},
+			repair:       true,
+			wantRepaired: []domain.BlockRange{{FromBlock: 112, ToBlock: 112}, {FromBlock: 125, ToBlock: 126}},
+			wantRepairOK: true,
+		},
+		{
+			name: "should report the failure of a repair",
+			api: func(t *testing.T) NodeAPI {
+				api := mocks.NewNodeAPI(t)
+				api.EXPECT().BlockNumber(mock.Anything).Return(150, nil).Once()
+				api.EXPECT().GetLogs(mock.Anything, address, mock.Anything, mock.Anything).RunAndReturn(logsBetween(logs))
+				return api
+			},
+			repo: func(t *testing.T) RecordReader {
+				repo := mocks.NewRecordReader(t)
+				repo.EXPECT().GetTransactionsByBlockRange(mock.Anything, address, uint64(100), uint64(129)).
+					Return(stored, nil).
+					Once()
+				return repo
+			},
+			repairer: func(t *testing.T) Repairer {
+				repairer := mocks.NewRepairer(t)
+				repairer.EXPECT().Reindex(mock.Anything, address, uint64(112), uint64(112)).
+					Return(errors.New("node unavailable")).
+					Once()
+				return repairer
+			},
+			repair: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := []Options{WithLogger(logger), WithConfig(&Config{ChunkSize: 20, Confirmations: 12})}
+
+			if repairer := tt.repairer(t); repairer != nil {
+				opts = append(opts, WithRepairer(repairer))
+			}
+
+			v := NewVerifier(tt.api(t), tt.repo(t), opts...)
+
+			report, err := v.Verify(context.Background(), address, 100, 129, tt.repair)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if report.Consistent() || report.Expected != 3 || report.Stored != 3 {
+				t.Errorf("Verify() = %+v, want 3 logs compared with 3 differing records", report)
+			}
+
+			if !reflect.DeepEqual(report.Repaired, tt.wantRepaired) {
+				t.Errorf("Verify() repaired = %v, want %v", report.Repaired, tt.wantRepaired)
+			}
+
+			if tt.repair && (report.RepairError == "") != tt.wantRepairOK {
+				t.Errorf("Verify() repair error = %q", report.RepairError)
+			}
+		})
+	}
+}
+
+// logsBetween returns a GetLogs returning the given logs within the range requested.
+func logsBetween(
+	logs []domain.Transaction,
+) func(context.Context, domain.Address, uint64, uint64) ([]domain.Transaction, error) {
+	return func(_ context.Context, _ domain.Address, from, to uint64) ([]domain.Transaction, error) {
+		var found []domain.Transaction
+
+		for _, v := range logs {
+			if v.DecimalBlockNumber >= from && v.DecimalBlockNumber <= to {
+				found = append(found, v)
+			}
+		}
+
+		return found, nil
+	}
+}
+
+func TestVerifier_VerifyWithoutRepairer(t *testing.T) {
+	v := NewVerifier(mocks.NewNodeAPI(t), mocks.NewRecordReader(t))
+
+	if _, err := v.Verify(context.Background(), address, 100, 129, true); err == nil {
+		t.Errorf("Verify() error = nil, want repairing to be refused")
+	}
+}
+
+func TestWriteReport(t *testing.T) {
+	report := domain.IntegrityReport{
+		Address:   address,
+		FromBlock: 100,
+		ToBlock:   129,
+		Expected:  2,
+		Stored:    2,
+		Missing:   []domain.Transaction{{Hash: "0x2", BlockHash: "0xb", DecimalBlockNumber: 112}},
+		Extra:     []domain.Transaction{{Hash: "0x4", BlockHash: "0xd", DecimalBlockNumber: 126}},
+		Mismatched: []domain.RecordMismatch{{
+			Stored:   domain.Transaction{Hash: "0x3", BlockHash: "0xf", DecimalBlockNumber: 125},
+			Expected: domain.Transaction{Hash: "0x3", BlockHash: "0xc", DecimalBlockNumber: 125},
+		}},
+		Repaired: []domain.BlockRange{{FromBlock: 112, ToBlock: 112}},
+	}
+
+	var buf bytes.Buffer
+
+	if err := WriteReport(&buf, report); err != nil {
+		t.Fatalf("WriteReport() error = %v", err)
+	}
+
+	want := []string{
+		"0x123 blocks 100 to 129: 2 logs on the node, 2 records stored",
+		"+ block 112 0xb tx 0x2",
+		"- block 126 0xd tx 0x4",
+		"~ tx 0x3 stored in block 125 0xf, on the node in block 125 0xc",
+		"repaired blocks 112 to 112",
+	}
+
+	if got := strings.Split(strings.TrimSpace(buf.String()), "\n"); !reflect.DeepEqual(got, want) {
+		t.Errorf("WriteReport() = %q, want %q", got, want)
+	}
+}
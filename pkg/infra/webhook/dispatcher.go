@@ -0,0 +1,352 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+const (
+	defaultWorkers        = 4
+	defaultMaxAttempts    = 5
+	defaultInitialBackoff = 1 * time.Second
+	defaultMaxBackoff     = 1 * time.Minute
+	defaultRequestTimeout = 10 * time.Second
+	defaultQueueSize      = 1_000
+)
+
+type Repository interface {
+	SaveWebhook(ctx context.Context, webhook domain.Webhook) error
+	GetWebhook(ctx context.Context, chainID domain.ChainID, address domain.Address) (domain.Webhook, error)
+	SaveDelivery(ctx context.Context, delivery domain.WebhookDelivery) error
+	GetDelivery(ctx context.Context, id string) (domain.WebhookDelivery, error)
+	GetDeliveries(ctx context.Context, address domain.Address) ([]domain.WebhookDelivery, error)
+	GetDeadDeliveries(ctx context.Context) ([]domain.WebhookDelivery, error)
+}
+
+type Options func(*Dispatcher)
+
+func WithConfig(cfg *Config) Options {
+	return func(d *Dispatcher) {
+		d.cfg = cfg
+	}
+}
+
+func WithLogger(l *slog.Logger) Options {
+	return func(d *Dispatcher) {
+		d.logger = l
+	}
+}
+
+// WithDefaultChain sets the chain of the webhooks registered without one.
+func WithDefaultChain(id domain.ChainID) Options {
+	return func(d *Dispatcher) {
+		d.chain = id
+	}
+}
+
+func WithHTTPClient(c *http.Client) Options {
+	return func(d *Dispatcher) {
+		d.httpClient = c
+	}
+}
+
+type Config struct {
+	Workers     int
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry, doubled on every following one up to MaxBackoff
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	RequestTimeout time.Duration
+	// QueueSize bounds the deliveries waiting for a worker, the ones not fitting go straight to dead-letter
+	QueueSize int
+}
+
+// Dispatcher POSTs the transactions stored for an address to its webhook, signing the payload with HMAC-SHA256
+// and retrying with exponential backoff. Every attempt is recorded, and deliveries go dead once their attempts
+// are exhausted, until being replayed.
+type Dispatcher struct {
+	logger     *slog.Logger
+	cfg        *Config
+	repo       Repository
+	httpClient *http.Client
+	queue      chan string
+	now        func() time.Time
+	// chain is the default chain of the webhooks registered without one
+	chain domain.ChainID
+}
+
+func NewDispatcher(repo Repository, opts ...Options) *Dispatcher {
+	d := &Dispatcher{
+		logger: slog.Default(),
+		cfg: &Config{
+			Workers:        defaultWorkers,
+			MaxAttempts:    defaultMaxAttempts,
+			InitialBackoff: defaultInitialBackoff,
+			MaxBackoff:     defaultMaxBackoff,
+			RequestTimeout: defaultRequestTimeout,
+			QueueSize:      defaultQueueSize,
+		},
+		repo:       repo,
+		httpClient: &http.Client{},
+		now:        time.Now,
+		chain:      domain.DefaultChainID,
+	}
+
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	d.queue = make(chan string, d.cfg.QueueSize)
+
+	return d
+}
+
+// Run delivers the queued events until the context is done.
+func (d *Dispatcher) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+
+	for i := 0; i < d.cfg.Workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			d.work(ctx)
+		}()
+	}
+
+	wg.Wait()
+
+	return ctx.Err()
+}
+
+// Register sets the webhook notified of the activity of its address on its chain, replacing the previous one.
+// A webhook without chain is registered on the default chain.
+func (d *Dispatcher) Register(ctx context.Context, webhook domain.Webhook) error {
+	if webhook.ChainID == 0 {
+		webhook.ChainID = d.chain
+	}
+
+	return d.repo.SaveWebhook(ctx, webhook)
+}
+
+// Deliveries returns the deliveries of the events of an address, along with their attempts.
+func (d *Dispatcher) Deliveries(ctx context.Context, address domain.Address) ([]domain.WebhookDelivery, error) {
+	return d.repo.GetDeliveries(ctx, address)
+}
+
+// DeadLetters returns the deliveries whose attempts were exhausted, which can be replayed.
+func (d *Dispatcher) DeadLetters(ctx context.Context) ([]domain.WebhookDelivery, error) {
+	return d.repo.GetDeadDeliveries(ctx)
+}
+
+// Notify queues an event with the given transactions, when the address has a webhook on the chain.
+func (d *Dispatcher) Notify(
+	ctx context.Context,
+	chainID domain.ChainID,
+	address domain.Address,
+	transactions []domain.Transaction,
+) error {
+	if len(transactions) == 0 {
+		return nil
+	}
+
+	webhook, err := d.repo.GetWebhook(ctx, chainID, address)
+	if errors.Is(err, domain.ErrWebhookNotFound) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to get webhook")
+	}
+
+	id, err := newID()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate event ID")
+	}
+
+	now := d.now()
+
+	delivery := domain.WebhookDelivery{
+		ID:  id,
+		URL: webhook.URL,
+		Event: domain.WebhookEvent{
+			ID:           id,
+			Type:         domain.WebhookEventTransactionsAdded,
+			ChainID:      chainID,
+			Address:      address,
+			Transactions: transactions,
+			CreatedAt:    now,
+		},
+		Status:    domain.DeliveryStatusPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	return d.enqueue(ctx, delivery)
+}
+
+// Replay delivers again the given delivery, usually a dead one.
+func (d *Dispatcher) Replay(ctx context.Context, id string) error {
+	delivery, err := d.repo.GetDelivery(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	delivery.Replay(d.now())
+
+	return d.enqueue(ctx, delivery)
+}
+
+// enqueue saves the delivery and hands it to the workers, without ever blocking the caller.
+func (d *Dispatcher) enqueue(ctx context.Context, delivery domain.WebhookDelivery) error {
+	if err := d.repo.SaveDelivery(ctx, delivery); err != nil {
+		return errors.Wrap(err, "failed to save delivery")
+	}
+
+	select {
+	case d.queue <- delivery.ID:
+		return nil
+	default:
+	}
+
+	delivery.Attempts = append(delivery.Attempts, domain.DeliveryAttempt{At: d.now(), Error: "delivery queue is full"})
+	delivery.Status = domain.DeliveryStatusDead
+
+	if err := d.repo.SaveDelivery(ctx, delivery); err != nil {
+		return errors.Wrap(err, "failed to save delivery")
+	}
+
+	return errors.Errorf("delivery queue is full, event %s moved to dead-letter", delivery.ID)
+}
+
+func (d *Dispatcher) work(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case id := <-d.queue:
+			if err := d.deliver(ctx, id); err != nil {
+				d.logger.Error("Failed to deliver webhook", "error", err, "delivery", id)
+			}
+		}
+	}
+}
+
+// deliver POSTs the event until it succeeds or its attempts are exhausted.
+func (d *Dispatcher) deliver(ctx context.Context, id string) error {
+	delivery, err := d.repo.GetDelivery(ctx, id)
+	if err != nil {
+		return errors.Wrap(err, "failed to get delivery")
+	}
+
+	webhook, err := d.repo.GetWebhook(ctx, delivery.Event.ChainID, delivery.Event.Address)
+	if err != nil {
+		return errors.Wrap(err, "failed to get webhook")
+	}
+
+	body, err := json.Marshal(delivery.Event)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal event")
+	}
+
+	for retry := 0; delivery.Status == domain.DeliveryStatusPending; retry++ {
+		if retry > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(d.backoff(retry)):
+			}
+		}
+
+		attempt := d.post(ctx, delivery.URL, webhook.Secret, delivery.ID, body)
+		delivery.Record(attempt, d.cfg.MaxAttempts)
+
+		if err = d.repo.SaveDelivery(ctx, delivery); err != nil {
+			return errors.Wrap(err, "failed to save delivery")
+		}
+
+		d.logger.Debug(
+			"Webhook delivery attempt",
+			"delivery", delivery.ID,
+			"status", delivery.Status,
+			"status_code", attempt.StatusCode,
+			"error", attempt.Error,
+		)
+	}
+
+	if delivery.Status == domain.DeliveryStatusDead {
+		d.logger.Warn("Webhook delivery moved to dead-letter", "delivery", delivery.ID, "url", delivery.URL)
+	}
+
+	return nil
+}
+
+func (d *Dispatcher) post(ctx context.Context, url, secret, id string, body []byte) domain.DeliveryAttempt {
+	var attempt = domain.DeliveryAttempt{At: d.now()}
+
+	ctx, cancel := context.WithTimeout(ctx, d.cfg.RequestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, Sign(secret, body))
+	req.Header.Set(EventIDHeader, id)
+
+	res, err := d.httpClient.Do(req)
+	attempt.Duration = d.now().Sub(attempt.At)
+
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	attempt.StatusCode = res.StatusCode
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		attempt.Error = fmt.Sprintf("unexpected status code %d", res.StatusCode)
+	}
+
+	return attempt
+}
+
+func (d *Dispatcher) backoff(retry int) time.Duration {
+	backoff := d.cfg.InitialBackoff << (retry - 1)
+
+	if backoff <= 0 || backoff > d.cfg.MaxBackoff {
+		return d.cfg.MaxBackoff
+	}
+
+	return backoff
+}
+
+func newID() (string, error) {
+	var b = make([]byte, 16)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,285 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+	"github.com/tonytcb/ethereum-blockchain-parser/pkg/infra/storage"
+)
+
+const testSecret = "s3cr3t"
+
+func TestDispatcher_Notify(t *testing.T) {
+	var (
+		address      = domain.Address("0x35fa164735182de50811e8e2e824cfb9b6118ac2")
+		transactions = []domain.Transaction{{ChainID: 1, Hash: "0x1", Address: address, DecimalBlockNumber: 65}}
+	)
+
+	tests := []struct {
+		name         string
+		statusCodes  []int
+		wantStatus   domain.DeliveryStatus
+		wantAttempts int
+	}{
+		{
+			name:         "should deliver the signed event",
+			statusCodes:  []int{http.StatusNoContent},
+			wantStatus:   domain.DeliveryStatusDelivered,
+			wantAttempts: 1,
+		},
+		{
+			name:         "should retry until the receiver succeeds",
+			statusCodes:  []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusOK},
+			wantStatus:   domain.DeliveryStatusDelivered,
+			wantAttempts: 3,
+		},
+		{
+			name:         "should move to dead-letter once attempts are exhausted",
+			statusCodes:  []int{http.StatusInternalServerError},
+			wantStatus:   domain.DeliveryStatusDead,
+			wantAttempts: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+
+			receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+
+				if !VerifySignature(testSecret, body, r.Header.Get(SignatureHeader)) {
+					t.Errorf("invalid signature %s", r.Header.Get(SignatureHeader))
+				}
+
+				var event domain.WebhookEvent
+				if err := json.Unmarshal(body, &event); err != nil {
+					t.Errorf("invalid payload: %v", err)
+				}
+
+				if event.ID != r.Header.Get(EventIDHeader) || event.Address != address || len(event.Transactions) != 1 {
+					t.Errorf("unexpected event %v", event)
+				}
+
+				call := int(calls.Add(1)) - 1
+				if call >= len(tt.statusCodes) {
+					call = len(tt.statusCodes) - 1
+				}
+
+				w.WriteHeader(tt.statusCodes[call])
+			}))
+			defer receiver.Close()
+
+			repo, dispatcher := newTestDispatcher(t, address, receiver.URL)
+
+			if err := dispatcher.Notify(context.Background(), 1, address, transactions); err != nil {
+				t.Fatalf("Notify() error = %v", err)
+			}
+
+			delivery := waitForDelivery(t, repo, address, tt.wantStatus)
+
+			if len(delivery.Attempts) != tt.wantAttempts {
+				t.Errorf("attempts = %v, want %v", len(delivery.Attempts), tt.wantAttempts)
+			}
+			if delivery.Event.ChainID != 1 || delivery.Event.Type != domain.WebhookEventTransactionsAdded {
+				t.Errorf("unexpected event %v", delivery.Event)
+			}
+		})
+	}
+}
+
+func TestDispatcher_NotifyWithoutWebhook(t *testing.T) {
+	var repo = storage.NewInMemory()
+
+	dispatcher := NewDispatcher(repo)
+
+	err := dispatcher.Notify(context.Background(), 1, "0x1", []domain.Transaction{{Hash: "0x1"}})
+	if err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	if deliveries, _ := repo.GetDeliveries(context.Background(), "0x1"); len(deliveries) != 0 {
+		t.Errorf("expected no delivery, got: %v", deliveries)
+	}
+}
+
+func TestDispatcher_NotifyPerChain(t *testing.T) {
+	var (
+		address = domain.Address("0x35fa164735182de50811e8e2e824cfb9b6118ac2")
+		hits    = make(map[domain.ChainID]*atomic.Int32)
+	)
+
+	receiver := func(chainID domain.ChainID) string {
+		hits[chainID] = &atomic.Int32{}
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var event domain.WebhookEvent
+			if err := json.NewDecoder(r.Body).Decode(&event); err != nil || event.ChainID != chainID {
+				t.Errorf("unexpected event %v on the webhook of chain %d", event, chainID)
+			}
+
+			hits[chainID].Add(1)
+			w.WriteHeader(http.StatusOK)
+		}))
+		t.Cleanup(server.Close)
+
+		return server.URL
+	}
+
+	// the webhook registered without chain goes to the default one
+	repo, dispatcher := newTestDispatcher(t, address, receiver(1))
+
+	err := dispatcher.Register(context.Background(), domain.Webhook{
+		ChainID: 8453,
+		Address: address,
+		URL:     receiver(8453),
+		Secret:  testSecret,
+	})
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	err = dispatcher.Notify(context.Background(), 8453, address, []domain.Transaction{{Hash: "0x1", Address: address}})
+	if err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	delivery := waitForDelivery(t, repo, address, domain.DeliveryStatusDelivered)
+
+	if delivery.Event.ChainID != 8453 || hits[8453].Load() != 1 || hits[1].Load() != 0 {
+		t.Errorf("expected only the webhook of chain 8453 to be notified, got: %v", delivery)
+	}
+
+	if err = dispatcher.Notify(context.Background(), 10, address, []domain.Transaction{{Hash: "0x2"}}); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	if deliveries, _ := repo.GetDeliveries(context.Background(), address); len(deliveries) != 1 {
+		t.Errorf("expected no delivery on a chain without webhook, got: %v", deliveries)
+	}
+}
+
+func TestDispatcher_Replay(t *testing.T) {
+	var (
+		address = domain.Address("0x35fa164735182de50811e8e2e824cfb9b6118ac2")
+		healthy atomic.Bool
+	)
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	repo, dispatcher := newTestDispatcher(t, address, receiver.URL)
+
+	err := dispatcher.Notify(context.Background(), 1, address, []domain.Transaction{{Hash: "0x1", Address: address}})
+	if err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	dead := waitForDelivery(t, repo, address, domain.DeliveryStatusDead)
+
+	if dead, _ := dispatcher.DeadLetters(context.Background()); len(dead) != 1 {
+		t.Fatalf("expected 1 dead letter, got: %v", dead)
+	}
+
+	healthy.Store(true)
+
+	if err = dispatcher.Replay(context.Background(), dead.ID); err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+
+	delivered := waitForDelivery(t, repo, address, domain.DeliveryStatusDelivered)
+
+	if delivered.ID != dead.ID || len(delivered.Attempts) != 4 {
+		t.Errorf("expected the dead delivery to be delivered on a 4th attempt, got: %v", delivered)
+	}
+
+	if err = dispatcher.Replay(context.Background(), "unknown"); err != domain.ErrDeliveryNotFound {
+		t.Errorf("Replay() error = %v, want %v", err, domain.ErrDeliveryNotFound)
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	var body = []byte(`{"id":"1"}`)
+
+	if !VerifySignature(testSecret, body, Sign(testSecret, body)) {
+		t.Errorf("expected signature to be valid")
+	}
+
+	if VerifySignature("other", body, Sign(testSecret, body)) {
+		t.Errorf("expected signature with another secret to be invalid")
+	}
+
+	if VerifySignature(testSecret, bytes.ToUpper(body), Sign(testSecret, body)) {
+		t.Errorf("expected signature of another body to be invalid")
+	}
+}
+
+func newTestDispatcher(t *testing.T, address domain.Address, url string) (*storage.InMemory, *Dispatcher) {
+	t.Helper()
+
+	var (
+		ctx, cancel = context.WithCancel(context.Background())
+		repo        = storage.NewInMemory()
+		logger      = slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{}))
+	)
+
+	t.Cleanup(cancel)
+
+	dispatcher := NewDispatcher(
+		repo,
+		WithLogger(logger),
+		WithConfig(&Config{
+			Workers:        1,
+			MaxAttempts:    3,
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     time.Millisecond * 5,
+			RequestTimeout: time.Second,
+			QueueSize:      10,
+		}),
+	)
+
+	if err := dispatcher.Register(ctx, domain.Webhook{Address: address, URL: url, Secret: testSecret}); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	go func() {
+		_ = dispatcher.Run(ctx)
+	}()
+
+	return repo, dispatcher
+}
+
+func waitForDelivery(
+	t *testing.T,
+	repo *storage.InMemory,
+	address domain.Address,
+	status domain.DeliveryStatus,
+) domain.WebhookDelivery {
+	t.Helper()
+
+	for deadline := time.Now().Add(time.Second * 2); time.Now().Before(deadline); time.Sleep(time.Millisecond * 5) {
+		deliveries, _ := repo.GetDeliveries(context.Background(), address)
+		if len(deliveries) == 1 && deliveries[0].Status == status {
+			return deliveries[0]
+		}
+	}
+
+	t.Fatalf("no delivery reached status %s", status)
+
+	return domain.WebhookDelivery{}
+}
@@ -0,0 +1,29 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+const (
+	// SignatureHeader carries the HMAC-SHA256 of the request body, keyed by the webhook secret.
+	SignatureHeader = "X-Signature-256"
+	// EventIDHeader carries the event ID, allowing receivers to discard duplicated deliveries.
+	EventIDHeader = "X-Webhook-Event-Id"
+
+	signaturePrefix = "sha256="
+)
+
+// Sign returns the signature of the body, as sent on the SignatureHeader.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(body)
+
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature checks, in constant time, the signature received on the SignatureHeader.
+func VerifySignature(secret string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+}
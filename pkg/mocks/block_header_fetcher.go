@@ -0,0 +1,95 @@
+// Code generated by mockery v2.39.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// BlockHeaderFetcher is an autogenerated mock type for the BlockHeaderFetcher type
+type BlockHeaderFetcher struct {
+	mock.Mock
+}
+
+type BlockHeaderFetcher_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BlockHeaderFetcher) EXPECT() *BlockHeaderFetcher_Expecter {
+	return &BlockHeaderFetcher_Expecter{mock: &_m.Mock}
+}
+
+// GetBlockByNumber provides a mock function with given fields: ctx, number
+func (_m *BlockHeaderFetcher) GetBlockByNumber(ctx context.Context, number uint64) (domain.BlockHeader, error) {
+	ret := _m.Called(ctx, number)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlockByNumber")
+	}
+
+	var r0 domain.BlockHeader
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (domain.BlockHeader, error)); ok {
+		return rf(ctx, number)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) domain.BlockHeader); ok {
+		r0 = rf(ctx, number)
+	} else {
+		r0 = ret.Get(0).(domain.BlockHeader)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, number)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BlockHeaderFetcher_GetBlockByNumber_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBlockByNumber'
+type BlockHeaderFetcher_GetBlockByNumber_Call struct {
+	*mock.Call
+}
+
+// GetBlockByNumber is a helper method to define mock.On call
+//   - ctx context.Context
+//   - number uint64
+func (_e *BlockHeaderFetcher_Expecter) GetBlockByNumber(ctx interface{}, number interface{}) *BlockHeaderFetcher_GetBlockByNumber_Call {
+	return &BlockHeaderFetcher_GetBlockByNumber_Call{Call: _e.mock.On("GetBlockByNumber", ctx, number)}
+}
+
+func (_c *BlockHeaderFetcher_GetBlockByNumber_Call) Run(run func(ctx context.Context, number uint64)) *BlockHeaderFetcher_GetBlockByNumber_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64))
+	})
+	return _c
+}
+
+func (_c *BlockHeaderFetcher_GetBlockByNumber_Call) Return(_a0 domain.BlockHeader, _a1 error) *BlockHeaderFetcher_GetBlockByNumber_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BlockHeaderFetcher_GetBlockByNumber_Call) RunAndReturn(run func(context.Context, uint64) (domain.BlockHeader, error)) *BlockHeaderFetcher_GetBlockByNumber_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBlockHeaderFetcher creates a new instance of BlockHeaderFetcher. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBlockHeaderFetcher(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BlockHeaderFetcher {
+	mock := &BlockHeaderFetcher{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
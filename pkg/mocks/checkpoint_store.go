@@ -0,0 +1,247 @@
+// Code generated by mockery v2.39.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	domain "github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+// CheckpointStore is an autogenerated mock type for the CheckpointStore type
+type CheckpointStore struct {
+	mock.Mock
+}
+
+type CheckpointStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *CheckpointStore) EXPECT() *CheckpointStore_Expecter {
+	return &CheckpointStore_Expecter{mock: &_m.Mock}
+}
+
+// DeleteBackfillCheckpoint provides a mock function with given fields: ctx, job
+func (_m *CheckpointStore) DeleteBackfillCheckpoint(ctx context.Context, job string) error {
+	ret := _m.Called(ctx, job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteBackfillCheckpoint")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, job)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CheckpointStore_DeleteBackfillCheckpoint_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteBackfillCheckpoint'
+type CheckpointStore_DeleteBackfillCheckpoint_Call struct {
+	*mock.Call
+}
+
+// DeleteBackfillCheckpoint is a helper method to define mock.On call
+//   - ctx context.Context
+//   - job string
+func (_e *CheckpointStore_Expecter) DeleteBackfillCheckpoint(ctx interface{}, job interface{}) *CheckpointStore_DeleteBackfillCheckpoint_Call {
+	return &CheckpointStore_DeleteBackfillCheckpoint_Call{Call: _e.mock.On("DeleteBackfillCheckpoint", ctx, job)}
+}
+
+func (_c *CheckpointStore_DeleteBackfillCheckpoint_Call) Run(run func(ctx context.Context, job string)) *CheckpointStore_DeleteBackfillCheckpoint_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *CheckpointStore_DeleteBackfillCheckpoint_Call) Return(_a0 error) *CheckpointStore_DeleteBackfillCheckpoint_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CheckpointStore_DeleteBackfillCheckpoint_Call) RunAndReturn(run func(context.Context, string) error) *CheckpointStore_DeleteBackfillCheckpoint_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBackfillCheckpoint provides a mock function with given fields: ctx, job
+func (_m *CheckpointStore) GetBackfillCheckpoint(ctx context.Context, job string) (domain.BackfillCheckpoint, error) {
+	ret := _m.Called(ctx, job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBackfillCheckpoint")
+	}
+
+	var r0 domain.BackfillCheckpoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.BackfillCheckpoint, error)); ok {
+		return rf(ctx, job)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.BackfillCheckpoint); ok {
+		r0 = rf(ctx, job)
+	} else {
+		r0 = ret.Get(0).(domain.BackfillCheckpoint)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, job)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CheckpointStore_GetBackfillCheckpoint_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBackfillCheckpoint'
+type CheckpointStore_GetBackfillCheckpoint_Call struct {
+	*mock.Call
+}
+
+// GetBackfillCheckpoint is a helper method to define mock.On call
+//   - ctx context.Context
+//   - job string
+func (_e *CheckpointStore_Expecter) GetBackfillCheckpoint(ctx interface{}, job interface{}) *CheckpointStore_GetBackfillCheckpoint_Call {
+	return &CheckpointStore_GetBackfillCheckpoint_Call{Call: _e.mock.On("GetBackfillCheckpoint", ctx, job)}
+}
+
+func (_c *CheckpointStore_GetBackfillCheckpoint_Call) Run(run func(ctx context.Context, job string)) *CheckpointStore_GetBackfillCheckpoint_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *CheckpointStore_GetBackfillCheckpoint_Call) Return(_a0 domain.BackfillCheckpoint, _a1 error) *CheckpointStore_GetBackfillCheckpoint_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CheckpointStore_GetBackfillCheckpoint_Call) RunAndReturn(run func(context.Context, string) (domain.BackfillCheckpoint, error)) *CheckpointStore_GetBackfillCheckpoint_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBackfillCheckpoints provides a mock function with given fields: ctx
+func (_m *CheckpointStore) GetBackfillCheckpoints(ctx context.Context) (map[string]domain.BackfillCheckpoint, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBackfillCheckpoints")
+	}
+
+	var r0 map[string]domain.BackfillCheckpoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (map[string]domain.BackfillCheckpoint, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) map[string]domain.BackfillCheckpoint); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]domain.BackfillCheckpoint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CheckpointStore_GetBackfillCheckpoints_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBackfillCheckpoints'
+type CheckpointStore_GetBackfillCheckpoints_Call struct {
+	*mock.Call
+}
+
+// GetBackfillCheckpoints is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *CheckpointStore_Expecter) GetBackfillCheckpoints(ctx interface{}) *CheckpointStore_GetBackfillCheckpoints_Call {
+	return &CheckpointStore_GetBackfillCheckpoints_Call{Call: _e.mock.On("GetBackfillCheckpoints", ctx)}
+}
+
+func (_c *CheckpointStore_GetBackfillCheckpoints_Call) Run(run func(ctx context.Context)) *CheckpointStore_GetBackfillCheckpoints_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *CheckpointStore_GetBackfillCheckpoints_Call) Return(_a0 map[string]domain.BackfillCheckpoint, _a1 error) *CheckpointStore_GetBackfillCheckpoints_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CheckpointStore_GetBackfillCheckpoints_Call) RunAndReturn(run func(context.Context) (map[string]domain.BackfillCheckpoint, error)) *CheckpointStore_GetBackfillCheckpoints_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveBackfillCheckpoint provides a mock function with given fields: ctx, job, checkpoint
+func (_m *CheckpointStore) SaveBackfillCheckpoint(ctx context.Context, job string, checkpoint domain.BackfillCheckpoint) error {
+	ret := _m.Called(ctx, job, checkpoint)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveBackfillCheckpoint")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.BackfillCheckpoint) error); ok {
+		r0 = rf(ctx, job, checkpoint)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CheckpointStore_SaveBackfillCheckpoint_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveBackfillCheckpoint'
+type CheckpointStore_SaveBackfillCheckpoint_Call struct {
+	*mock.Call
+}
+
+// SaveBackfillCheckpoint is a helper method to define mock.On call
+//   - ctx context.Context
+//   - job string
+//   - checkpoint domain.BackfillCheckpoint
+func (_e *CheckpointStore_Expecter) SaveBackfillCheckpoint(ctx interface{}, job interface{}, checkpoint interface{}) *CheckpointStore_SaveBackfillCheckpoint_Call {
+	return &CheckpointStore_SaveBackfillCheckpoint_Call{Call: _e.mock.On("SaveBackfillCheckpoint", ctx, job, checkpoint)}
+}
+
+func (_c *CheckpointStore_SaveBackfillCheckpoint_Call) Run(run func(ctx context.Context, job string, checkpoint domain.BackfillCheckpoint)) *CheckpointStore_SaveBackfillCheckpoint_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(domain.BackfillCheckpoint))
+	})
+	return _c
+}
+
+func (_c *CheckpointStore_SaveBackfillCheckpoint_Call) Return(_a0 error) *CheckpointStore_SaveBackfillCheckpoint_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CheckpointStore_SaveBackfillCheckpoint_Call) RunAndReturn(run func(context.Context, string, domain.BackfillCheckpoint) error) *CheckpointStore_SaveBackfillCheckpoint_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewCheckpointStore creates a new instance of CheckpointStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCheckpointStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CheckpointStore {
+	mock := &CheckpointStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
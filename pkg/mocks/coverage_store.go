@@ -0,0 +1,145 @@
+// Code generated by mockery v2.39.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CoverageStore is an autogenerated mock type for the CoverageStore type
+type CoverageStore struct {
+	mock.Mock
+}
+
+type CoverageStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *CoverageStore) EXPECT() *CoverageStore_Expecter {
+	return &CoverageStore_Expecter{mock: &_m.Mock}
+}
+
+// AddCoveredRange provides a mock function with given fields: ctx, address, r
+func (_m *CoverageStore) AddCoveredRange(ctx context.Context, address domain.Address, r domain.BlockRange) error {
+	ret := _m.Called(ctx, address, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddCoveredRange")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address, domain.BlockRange) error); ok {
+		r0 = rf(ctx, address, r)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CoverageStore_AddCoveredRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddCoveredRange'
+type CoverageStore_AddCoveredRange_Call struct {
+	*mock.Call
+}
+
+// AddCoveredRange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - address domain.Address
+//   - r domain.BlockRange
+func (_e *CoverageStore_Expecter) AddCoveredRange(ctx interface{}, address interface{}, r interface{}) *CoverageStore_AddCoveredRange_Call {
+	return &CoverageStore_AddCoveredRange_Call{Call: _e.mock.On("AddCoveredRange", ctx, address, r)}
+}
+
+func (_c *CoverageStore_AddCoveredRange_Call) Run(run func(ctx context.Context, address domain.Address, r domain.BlockRange)) *CoverageStore_AddCoveredRange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Address), args[2].(domain.BlockRange))
+	})
+	return _c
+}
+
+func (_c *CoverageStore_AddCoveredRange_Call) Return(_a0 error) *CoverageStore_AddCoveredRange_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CoverageStore_AddCoveredRange_Call) RunAndReturn(run func(context.Context, domain.Address, domain.BlockRange) error) *CoverageStore_AddCoveredRange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCoveredRanges provides a mock function with given fields: ctx, address
+func (_m *CoverageStore) GetCoveredRanges(ctx context.Context, address domain.Address) ([]domain.BlockRange, error) {
+	ret := _m.Called(ctx, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCoveredRanges")
+	}
+
+	var r0 []domain.BlockRange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address) ([]domain.BlockRange, error)); ok {
+		return rf(ctx, address)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address) []domain.BlockRange); ok {
+		r0 = rf(ctx, address)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.BlockRange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Address) error); ok {
+		r1 = rf(ctx, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CoverageStore_GetCoveredRanges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCoveredRanges'
+type CoverageStore_GetCoveredRanges_Call struct {
+	*mock.Call
+}
+
+// GetCoveredRanges is a helper method to define mock.On call
+//   - ctx context.Context
+//   - address domain.Address
+func (_e *CoverageStore_Expecter) GetCoveredRanges(ctx interface{}, address interface{}) *CoverageStore_GetCoveredRanges_Call {
+	return &CoverageStore_GetCoveredRanges_Call{Call: _e.mock.On("GetCoveredRanges", ctx, address)}
+}
+
+func (_c *CoverageStore_GetCoveredRanges_Call) Run(run func(ctx context.Context, address domain.Address)) *CoverageStore_GetCoveredRanges_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Address))
+	})
+	return _c
+}
+
+func (_c *CoverageStore_GetCoveredRanges_Call) Return(_a0 []domain.BlockRange, _a1 error) *CoverageStore_GetCoveredRanges_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CoverageStore_GetCoveredRanges_Call) RunAndReturn(run func(context.Context, domain.Address) ([]domain.BlockRange, error)) *CoverageStore_GetCoveredRanges_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewCoverageStore creates a new instance of CoverageStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCoverageStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CoverageStore {
+	mock := &CoverageStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
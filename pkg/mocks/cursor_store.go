@@ -0,0 +1,141 @@
+// Code generated by mockery v2.39.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CursorStore is an autogenerated mock type for the CursorStore type
+type CursorStore struct {
+	mock.Mock
+}
+
+type CursorStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *CursorStore) EXPECT() *CursorStore_Expecter {
+	return &CursorStore_Expecter{mock: &_m.Mock}
+}
+
+// GetSinkCursor provides a mock function with given fields: ctx, _a1
+func (_m *CursorStore) GetSinkCursor(ctx context.Context, _a1 string) (uint64, error) {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSinkCursor")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (uint64, error)); ok {
+		return rf(ctx, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) uint64); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CursorStore_GetSinkCursor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSinkCursor'
+type CursorStore_GetSinkCursor_Call struct {
+	*mock.Call
+}
+
+// GetSinkCursor is a helper method to define mock.On call
+//   - ctx context.Context
+//   - _a1 string
+func (_e *CursorStore_Expecter) GetSinkCursor(ctx interface{}, _a1 interface{}) *CursorStore_GetSinkCursor_Call {
+	return &CursorStore_GetSinkCursor_Call{Call: _e.mock.On("GetSinkCursor", ctx, _a1)}
+}
+
+func (_c *CursorStore_GetSinkCursor_Call) Run(run func(ctx context.Context, _a1 string)) *CursorStore_GetSinkCursor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *CursorStore_GetSinkCursor_Call) Return(_a0 uint64, _a1 error) *CursorStore_GetSinkCursor_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CursorStore_GetSinkCursor_Call) RunAndReturn(run func(context.Context, string) (uint64, error)) *CursorStore_GetSinkCursor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveSinkCursor provides a mock function with given fields: ctx, _a1, eventID
+func (_m *CursorStore) SaveSinkCursor(ctx context.Context, _a1 string, eventID uint64) error {
+	ret := _m.Called(ctx, _a1, eventID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveSinkCursor")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64) error); ok {
+		r0 = rf(ctx, _a1, eventID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CursorStore_SaveSinkCursor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveSinkCursor'
+type CursorStore_SaveSinkCursor_Call struct {
+	*mock.Call
+}
+
+// SaveSinkCursor is a helper method to define mock.On call
+//   - ctx context.Context
+//   - _a1 string
+//   - eventID uint64
+func (_e *CursorStore_Expecter) SaveSinkCursor(ctx interface{}, _a1 interface{}, eventID interface{}) *CursorStore_SaveSinkCursor_Call {
+	return &CursorStore_SaveSinkCursor_Call{Call: _e.mock.On("SaveSinkCursor", ctx, _a1, eventID)}
+}
+
+func (_c *CursorStore_SaveSinkCursor_Call) Run(run func(ctx context.Context, _a1 string, eventID uint64)) *CursorStore_SaveSinkCursor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uint64))
+	})
+	return _c
+}
+
+func (_c *CursorStore_SaveSinkCursor_Call) Return(_a0 error) *CursorStore_SaveSinkCursor_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CursorStore_SaveSinkCursor_Call) RunAndReturn(run func(context.Context, string, uint64) error) *CursorStore_SaveSinkCursor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewCursorStore creates a new instance of CursorStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCursorStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CursorStore {
+	mock := &CursorStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,97 @@
+// Code generated by mockery v2.39.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Enricher is an autogenerated mock type for the Enricher type
+type Enricher struct {
+	mock.Mock
+}
+
+type Enricher_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Enricher) EXPECT() *Enricher_Expecter {
+	return &Enricher_Expecter{mock: &_m.Mock}
+}
+
+// Enrich provides a mock function with given fields: ctx, transactions
+func (_m *Enricher) Enrich(ctx context.Context, transactions []domain.Transaction) ([]domain.Transaction, error) {
+	ret := _m.Called(ctx, transactions)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Enrich")
+	}
+
+	var r0 []domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.Transaction) ([]domain.Transaction, error)); ok {
+		return rf(ctx, transactions)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.Transaction) []domain.Transaction); ok {
+		r0 = rf(ctx, transactions)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []domain.Transaction) error); ok {
+		r1 = rf(ctx, transactions)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Enricher_Enrich_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Enrich'
+type Enricher_Enrich_Call struct {
+	*mock.Call
+}
+
+// Enrich is a helper method to define mock.On call
+//   - ctx context.Context
+//   - transactions []domain.Transaction
+func (_e *Enricher_Expecter) Enrich(ctx interface{}, transactions interface{}) *Enricher_Enrich_Call {
+	return &Enricher_Enrich_Call{Call: _e.mock.On("Enrich", ctx, transactions)}
+}
+
+func (_c *Enricher_Enrich_Call) Run(run func(ctx context.Context, transactions []domain.Transaction)) *Enricher_Enrich_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]domain.Transaction))
+	})
+	return _c
+}
+
+func (_c *Enricher_Enrich_Call) Return(_a0 []domain.Transaction, _a1 error) *Enricher_Enrich_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Enricher_Enrich_Call) RunAndReturn(run func(context.Context, []domain.Transaction) ([]domain.Transaction, error)) *Enricher_Enrich_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewEnricher creates a new instance of Enricher. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewEnricher(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Enricher {
+	mock := &Enricher{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -23,6 +23,62 @@ func (_m *EthJSONAPI) EXPECT() *EthJSONAPI_Expecter {
 	return &EthJSONAPI_Expecter{mock: &_m.Mock}
 }
 
+// BlockNumber provides a mock function with given fields: ctx
+func (_m *EthJSONAPI) BlockNumber(ctx context.Context) (uint64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BlockNumber")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (uint64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) uint64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EthJSONAPI_BlockNumber_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BlockNumber'
+type EthJSONAPI_BlockNumber_Call struct {
+	*mock.Call
+}
+
+// BlockNumber is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *EthJSONAPI_Expecter) BlockNumber(ctx interface{}) *EthJSONAPI_BlockNumber_Call {
+	return &EthJSONAPI_BlockNumber_Call{Call: _e.mock.On("BlockNumber", ctx)}
+}
+
+func (_c *EthJSONAPI_BlockNumber_Call) Run(run func(ctx context.Context)) *EthJSONAPI_BlockNumber_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *EthJSONAPI_BlockNumber_Call) Return(_a0 uint64, _a1 error) *EthJSONAPI_BlockNumber_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *EthJSONAPI_BlockNumber_Call) RunAndReturn(run func(context.Context) (uint64, error)) *EthJSONAPI_BlockNumber_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FetchTransactions provides a mock function with given fields: ctx, filter
 func (_m *EthJSONAPI) FetchTransactions(ctx context.Context, filter string) ([]domain.Transaction, error) {
 	ret := _m.Called(ctx, filter)
@@ -82,8 +138,69 @@ func (_c *EthJSONAPI_FetchTransactions_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
+// GetLogs provides a mock function with given fields: ctx, address, fromBlock, toBlock
+func (_m *EthJSONAPI) GetLogs(ctx context.Context, address domain.Address, fromBlock uint64, toBlock uint64) ([]domain.Transaction, error) {
+	ret := _m.Called(ctx, address, fromBlock, toBlock)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLogs")
+	}
+
+	var r0 []domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address, uint64, uint64) ([]domain.Transaction, error)); ok {
+		return rf(ctx, address, fromBlock, toBlock)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address, uint64, uint64) []domain.Transaction); ok {
+		r0 = rf(ctx, address, fromBlock, toBlock)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Address, uint64, uint64) error); ok {
+		r1 = rf(ctx, address, fromBlock, toBlock)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EthJSONAPI_GetLogs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLogs'
+type EthJSONAPI_GetLogs_Call struct {
+	*mock.Call
+}
+
+// GetLogs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - address domain.Address
+//   - fromBlock uint64
+//   - toBlock uint64
+func (_e *EthJSONAPI_Expecter) GetLogs(ctx interface{}, address interface{}, fromBlock interface{}, toBlock interface{}) *EthJSONAPI_GetLogs_Call {
+	return &EthJSONAPI_GetLogs_Call{Call: _e.mock.On("GetLogs", ctx, address, fromBlock, toBlock)}
+}
+
+func (_c *EthJSONAPI_GetLogs_Call) Run(run func(ctx context.Context, address domain.Address, fromBlock uint64, toBlock uint64)) *EthJSONAPI_GetLogs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Address), args[2].(uint64), args[3].(uint64))
+	})
+	return _c
+}
+
+func (_c *EthJSONAPI_GetLogs_Call) Return(_a0 []domain.Transaction, _a1 error) *EthJSONAPI_GetLogs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *EthJSONAPI_GetLogs_Call) RunAndReturn(run func(context.Context, domain.Address, uint64, uint64) ([]domain.Transaction, error)) *EthJSONAPI_GetLogs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewFilter provides a mock function with given fields: ctx, address
-func (_m *EthJSONAPI) NewFilter(ctx context.Context, address string) (string, error) {
+func (_m *EthJSONAPI) NewFilter(ctx context.Context, address domain.Address) (string, error) {
 	ret := _m.Called(ctx, address)
 
 	if len(ret) == 0 {
@@ -92,16 +209,16 @@ func (_m *EthJSONAPI) NewFilter(ctx context.Context, address string) (string, er
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address) (string, error)); ok {
 		return rf(ctx, address)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address) string); ok {
 		r0 = rf(ctx, address)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Address) error); ok {
 		r1 = rf(ctx, address)
 	} else {
 		r1 = ret.Error(1)
@@ -117,14 +234,14 @@ type EthJSONAPI_NewFilter_Call struct {
 
 // NewFilter is a helper method to define mock.On call
 //   - ctx context.Context
-//   - address string
+//   - address domain.Address
 func (_e *EthJSONAPI_Expecter) NewFilter(ctx interface{}, address interface{}) *EthJSONAPI_NewFilter_Call {
 	return &EthJSONAPI_NewFilter_Call{Call: _e.mock.On("NewFilter", ctx, address)}
 }
 
-func (_c *EthJSONAPI_NewFilter_Call) Run(run func(ctx context.Context, address string)) *EthJSONAPI_NewFilter_Call {
+func (_c *EthJSONAPI_NewFilter_Call) Run(run func(ctx context.Context, address domain.Address)) *EthJSONAPI_NewFilter_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(domain.Address))
 	})
 	return _c
 }
@@ -134,7 +251,7 @@ func (_c *EthJSONAPI_NewFilter_Call) Return(_a0 string, _a1 error) *EthJSONAPI_N
 	return _c
 }
 
-func (_c *EthJSONAPI_NewFilter_Call) RunAndReturn(run func(context.Context, string) (string, error)) *EthJSONAPI_NewFilter_Call {
+func (_c *EthJSONAPI_NewFilter_Call) RunAndReturn(run func(context.Context, domain.Address) (string, error)) *EthJSONAPI_NewFilter_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -6,6 +6,7 @@ import (
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+	domain "github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
 )
 
 // EventListener is an autogenerated mock type for the EventListener type
@@ -21,17 +22,24 @@ func (_m *EventListener) EXPECT() *EventListener_Expecter {
 	return &EventListener_Expecter{mock: &_m.Mock}
 }
 
-// Listen provides a mock function with given fields: ctx, address
-func (_m *EventListener) Listen(ctx context.Context, address string) error {
-	ret := _m.Called(ctx, address)
+// Listen provides a mock function with given fields: ctx, address, opts
+func (_m *EventListener) Listen(ctx context.Context, address domain.Address, opts ...domain.SubscribeOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, address)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Listen")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, address)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address, ...domain.SubscribeOption) error); ok {
+		r0 = rf(ctx, address, opts...)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -46,14 +54,22 @@ type EventListener_Listen_Call struct {
 
 // Listen is a helper method to define mock.On call
 //   - ctx context.Context
-//   - address string
-func (_e *EventListener_Expecter) Listen(ctx interface{}, address interface{}) *EventListener_Listen_Call {
-	return &EventListener_Listen_Call{Call: _e.mock.On("Listen", ctx, address)}
+//   - address domain.Address
+//   - opts ...domain.SubscribeOption
+func (_e *EventListener_Expecter) Listen(ctx interface{}, address interface{}, opts ...interface{}) *EventListener_Listen_Call {
+	return &EventListener_Listen_Call{Call: _e.mock.On("Listen",
+		append([]interface{}{ctx, address}, opts...)...)}
 }
 
-func (_c *EventListener_Listen_Call) Run(run func(ctx context.Context, address string)) *EventListener_Listen_Call {
+func (_c *EventListener_Listen_Call) Run(run func(ctx context.Context, address domain.Address, opts ...domain.SubscribeOption)) *EventListener_Listen_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		variadicArgs := make([]domain.SubscribeOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(domain.SubscribeOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(domain.Address), variadicArgs...)
 	})
 	return _c
 }
@@ -63,7 +79,252 @@ func (_c *EventListener_Listen_Call) Return(_a0 error) *EventListener_Listen_Cal
 	return _c
 }
 
-func (_c *EventListener_Listen_Call) RunAndReturn(run func(context.Context, string) error) *EventListener_Listen_Call {
+func (_c *EventListener_Listen_Call) RunAndReturn(run func(context.Context, domain.Address, ...domain.SubscribeOption) error) *EventListener_Listen_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Pause provides a mock function with given fields: ctx, address
+func (_m *EventListener) Pause(ctx context.Context, address domain.Address) error {
+	ret := _m.Called(ctx, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Pause")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address) error); ok {
+		r0 = rf(ctx, address)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EventListener_Pause_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Pause'
+type EventListener_Pause_Call struct {
+	*mock.Call
+}
+
+// Pause is a helper method to define mock.On call
+//   - ctx context.Context
+//   - address domain.Address
+func (_e *EventListener_Expecter) Pause(ctx interface{}, address interface{}) *EventListener_Pause_Call {
+	return &EventListener_Pause_Call{Call: _e.mock.On("Pause", ctx, address)}
+}
+
+func (_c *EventListener_Pause_Call) Run(run func(ctx context.Context, address domain.Address)) *EventListener_Pause_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Address))
+	})
+	return _c
+}
+
+func (_c *EventListener_Pause_Call) Return(_a0 error) *EventListener_Pause_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *EventListener_Pause_Call) RunAndReturn(run func(context.Context, domain.Address) error) *EventListener_Pause_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Rescan provides a mock function with given fields: ctx, address, fromBlock
+func (_m *EventListener) Rescan(ctx context.Context, address domain.Address, fromBlock uint64) error {
+	ret := _m.Called(ctx, address, fromBlock)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Rescan")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address, uint64) error); ok {
+		r0 = rf(ctx, address, fromBlock)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EventListener_Rescan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Rescan'
+type EventListener_Rescan_Call struct {
+	*mock.Call
+}
+
+// Rescan is a helper method to define mock.On call
+//   - ctx context.Context
+//   - address domain.Address
+//   - fromBlock uint64
+func (_e *EventListener_Expecter) Rescan(ctx interface{}, address interface{}, fromBlock interface{}) *EventListener_Rescan_Call {
+	return &EventListener_Rescan_Call{Call: _e.mock.On("Rescan", ctx, address, fromBlock)}
+}
+
+func (_c *EventListener_Rescan_Call) Run(run func(ctx context.Context, address domain.Address, fromBlock uint64)) *EventListener_Rescan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Address), args[2].(uint64))
+	})
+	return _c
+}
+
+func (_c *EventListener_Rescan_Call) Return(_a0 error) *EventListener_Rescan_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *EventListener_Rescan_Call) RunAndReturn(run func(context.Context, domain.Address, uint64) error) *EventListener_Rescan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Resume provides a mock function with given fields: ctx, address
+func (_m *EventListener) Resume(ctx context.Context, address domain.Address) error {
+	ret := _m.Called(ctx, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Resume")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address) error); ok {
+		r0 = rf(ctx, address)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EventListener_Resume_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Resume'
+type EventListener_Resume_Call struct {
+	*mock.Call
+}
+
+// Resume is a helper method to define mock.On call
+//   - ctx context.Context
+//   - address domain.Address
+func (_e *EventListener_Expecter) Resume(ctx interface{}, address interface{}) *EventListener_Resume_Call {
+	return &EventListener_Resume_Call{Call: _e.mock.On("Resume", ctx, address)}
+}
+
+func (_c *EventListener_Resume_Call) Run(run func(ctx context.Context, address domain.Address)) *EventListener_Resume_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Address))
+	})
+	return _c
+}
+
+func (_c *EventListener_Resume_Call) Return(_a0 error) *EventListener_Resume_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *EventListener_Resume_Call) RunAndReturn(run func(context.Context, domain.Address) error) *EventListener_Resume_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Subscription provides a mock function with given fields: address
+func (_m *EventListener) Subscription(address domain.Address) (domain.Subscription, error) {
+	ret := _m.Called(address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Subscription")
+	}
+
+	var r0 domain.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.Address) (domain.Subscription, error)); ok {
+		return rf(address)
+	}
+	if rf, ok := ret.Get(0).(func(domain.Address) domain.Subscription); ok {
+		r0 = rf(address)
+	} else {
+		r0 = ret.Get(0).(domain.Subscription)
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.Address) error); ok {
+		r1 = rf(address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EventListener_Subscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Subscription'
+type EventListener_Subscription_Call struct {
+	*mock.Call
+}
+
+// Subscription is a helper method to define mock.On call
+//   - address domain.Address
+func (_e *EventListener_Expecter) Subscription(address interface{}) *EventListener_Subscription_Call {
+	return &EventListener_Subscription_Call{Call: _e.mock.On("Subscription", address)}
+}
+
+func (_c *EventListener_Subscription_Call) Run(run func(address domain.Address)) *EventListener_Subscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(domain.Address))
+	})
+	return _c
+}
+
+func (_c *EventListener_Subscription_Call) Return(_a0 domain.Subscription, _a1 error) *EventListener_Subscription_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *EventListener_Subscription_Call) RunAndReturn(run func(domain.Address) (domain.Subscription, error)) *EventListener_Subscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Subscriptions provides a mock function with given fields:
+func (_m *EventListener) Subscriptions() []domain.Subscription {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Subscriptions")
+	}
+
+	var r0 []domain.Subscription
+	if rf, ok := ret.Get(0).(func() []domain.Subscription); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Subscription)
+		}
+	}
+
+	return r0
+}
+
+// EventListener_Subscriptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Subscriptions'
+type EventListener_Subscriptions_Call struct {
+	*mock.Call
+}
+
+// Subscriptions is a helper method to define mock.On call
+func (_e *EventListener_Expecter) Subscriptions() *EventListener_Subscriptions_Call {
+	return &EventListener_Subscriptions_Call{Call: _e.mock.On("Subscriptions")}
+}
+
+func (_c *EventListener_Subscriptions_Call) Run(run func()) *EventListener_Subscriptions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *EventListener_Subscriptions_Call) Return(_a0 []domain.Subscription) *EventListener_Subscriptions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *EventListener_Subscriptions_Call) RunAndReturn(run func() []domain.Subscription) *EventListener_Subscriptions_Call {
 	_c.Call.Return(run)
 	return _c
 }
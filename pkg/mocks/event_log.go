@@ -0,0 +1,156 @@
+// Code generated by mockery v2.39.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	domain "github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+// EventLog is an autogenerated mock type for the EventLog type
+type EventLog struct {
+	mock.Mock
+}
+
+type EventLog_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *EventLog) EXPECT() *EventLog_Expecter {
+	return &EventLog_Expecter{mock: &_m.Mock}
+}
+
+// AppendEvents provides a mock function with given fields: ctx, events
+func (_m *EventLog) AppendEvents(ctx context.Context, events []domain.Event) ([]domain.Event, error) {
+	ret := _m.Called(ctx, events)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AppendEvents")
+	}
+
+	var r0 []domain.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.Event) ([]domain.Event, error)); ok {
+		return rf(ctx, events)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.Event) []domain.Event); ok {
+		r0 = rf(ctx, events)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []domain.Event) error); ok {
+		r1 = rf(ctx, events)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EventLog_AppendEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AppendEvents'
+type EventLog_AppendEvents_Call struct {
+	*mock.Call
+}
+
+// AppendEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - events []domain.Event
+func (_e *EventLog_Expecter) AppendEvents(ctx interface{}, events interface{}) *EventLog_AppendEvents_Call {
+	return &EventLog_AppendEvents_Call{Call: _e.mock.On("AppendEvents", ctx, events)}
+}
+
+func (_c *EventLog_AppendEvents_Call) Run(run func(ctx context.Context, events []domain.Event)) *EventLog_AppendEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]domain.Event))
+	})
+	return _c
+}
+
+func (_c *EventLog_AppendEvents_Call) Return(_a0 []domain.Event, _a1 error) *EventLog_AppendEvents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *EventLog_AppendEvents_Call) RunAndReturn(run func(context.Context, []domain.Event) ([]domain.Event, error)) *EventLog_AppendEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEventsSince provides a mock function with given fields: ctx, afterID, limit
+func (_m *EventLog) GetEventsSince(ctx context.Context, afterID uint64, limit int) ([]domain.Event, error) {
+	ret := _m.Called(ctx, afterID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEventsSince")
+	}
+
+	var r0 []domain.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, int) ([]domain.Event, error)); ok {
+		return rf(ctx, afterID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, int) []domain.Event); ok {
+		r0 = rf(ctx, afterID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, int) error); ok {
+		r1 = rf(ctx, afterID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EventLog_GetEventsSince_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventsSince'
+type EventLog_GetEventsSince_Call struct {
+	*mock.Call
+}
+
+// GetEventsSince is a helper method to define mock.On call
+//   - ctx context.Context
+//   - afterID uint64
+//   - limit int
+func (_e *EventLog_Expecter) GetEventsSince(ctx interface{}, afterID interface{}, limit interface{}) *EventLog_GetEventsSince_Call {
+	return &EventLog_GetEventsSince_Call{Call: _e.mock.On("GetEventsSince", ctx, afterID, limit)}
+}
+
+func (_c *EventLog_GetEventsSince_Call) Run(run func(ctx context.Context, afterID uint64, limit int)) *EventLog_GetEventsSince_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *EventLog_GetEventsSince_Call) Return(_a0 []domain.Event, _a1 error) *EventLog_GetEventsSince_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *EventLog_GetEventsSince_Call) RunAndReturn(run func(context.Context, uint64, int) ([]domain.Event, error)) *EventLog_GetEventsSince_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewEventLog creates a new instance of EventLog. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewEventLog(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *EventLog {
+	mock := &EventLog{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,129 @@
+// Code generated by mockery v2.39.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	domain "github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+// EventSink is an autogenerated mock type for the EventSink type
+type EventSink struct {
+	mock.Mock
+}
+
+type EventSink_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *EventSink) EXPECT() *EventSink_Expecter {
+	return &EventSink_Expecter{mock: &_m.Mock}
+}
+
+// Name provides a mock function with given fields:
+func (_m *EventSink) Name() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Name")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// EventSink_Name_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Name'
+type EventSink_Name_Call struct {
+	*mock.Call
+}
+
+// Name is a helper method to define mock.On call
+func (_e *EventSink_Expecter) Name() *EventSink_Name_Call {
+	return &EventSink_Name_Call{Call: _e.mock.On("Name")}
+}
+
+func (_c *EventSink_Name_Call) Run(run func()) *EventSink_Name_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *EventSink_Name_Call) Return(_a0 string) *EventSink_Name_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *EventSink_Name_Call) RunAndReturn(run func() string) *EventSink_Name_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Write provides a mock function with given fields: ctx, events
+func (_m *EventSink) Write(ctx context.Context, events []domain.Event) error {
+	ret := _m.Called(ctx, events)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Write")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.Event) error); ok {
+		r0 = rf(ctx, events)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EventSink_Write_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Write'
+type EventSink_Write_Call struct {
+	*mock.Call
+}
+
+// Write is a helper method to define mock.On call
+//   - ctx context.Context
+//   - events []domain.Event
+func (_e *EventSink_Expecter) Write(ctx interface{}, events interface{}) *EventSink_Write_Call {
+	return &EventSink_Write_Call{Call: _e.mock.On("Write", ctx, events)}
+}
+
+func (_c *EventSink_Write_Call) Run(run func(ctx context.Context, events []domain.Event)) *EventSink_Write_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]domain.Event))
+	})
+	return _c
+}
+
+func (_c *EventSink_Write_Call) Return(_a0 error) *EventSink_Write_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *EventSink_Write_Call) RunAndReturn(run func(context.Context, []domain.Event) error) *EventSink_Write_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewEventSink creates a new instance of EventSink. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewEventSink(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *EventSink {
+	mock := &EventSink{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,101 @@
+// Code generated by mockery v2.39.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	domain "github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+// EventSource is an autogenerated mock type for the EventSource type
+type EventSource struct {
+	mock.Mock
+}
+
+type EventSource_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *EventSource) EXPECT() *EventSource_Expecter {
+	return &EventSource_Expecter{mock: &_m.Mock}
+}
+
+// Subscribe provides a mock function with given fields: ctx, filter, opts
+func (_m *EventSource) Subscribe(ctx context.Context, filter domain.EventFilter, opts ...domain.WatchOption) <-chan domain.Event {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, filter)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Subscribe")
+	}
+
+	var r0 <-chan domain.Event
+	if rf, ok := ret.Get(0).(func(context.Context, domain.EventFilter, ...domain.WatchOption) <-chan domain.Event); ok {
+		r0 = rf(ctx, filter, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan domain.Event)
+		}
+	}
+
+	return r0
+}
+
+// EventSource_Subscribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Subscribe'
+type EventSource_Subscribe_Call struct {
+	*mock.Call
+}
+
+// Subscribe is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.EventFilter
+//   - opts ...domain.WatchOption
+func (_e *EventSource_Expecter) Subscribe(ctx interface{}, filter interface{}, opts ...interface{}) *EventSource_Subscribe_Call {
+	return &EventSource_Subscribe_Call{Call: _e.mock.On("Subscribe",
+		append([]interface{}{ctx, filter}, opts...)...)}
+}
+
+func (_c *EventSource_Subscribe_Call) Run(run func(ctx context.Context, filter domain.EventFilter, opts ...domain.WatchOption)) *EventSource_Subscribe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]domain.WatchOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(domain.WatchOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(domain.EventFilter), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *EventSource_Subscribe_Call) Return(_a0 <-chan domain.Event) *EventSource_Subscribe_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *EventSource_Subscribe_Call) RunAndReturn(run func(context.Context, domain.EventFilter, ...domain.WatchOption) <-chan domain.Event) *EventSource_Subscribe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewEventSource creates a new instance of EventSource. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewEventSource(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *EventSource {
+	mock := &EventSource{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,251 @@
+// Code generated by mockery v2.39.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// GapStore is an autogenerated mock type for the GapStore type
+type GapStore struct {
+	mock.Mock
+}
+
+type GapStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *GapStore) EXPECT() *GapStore_Expecter {
+	return &GapStore_Expecter{mock: &_m.Mock}
+}
+
+// AddCoveredRange provides a mock function with given fields: ctx, address, r
+func (_m *GapStore) AddCoveredRange(ctx context.Context, address domain.Address, r domain.BlockRange) error {
+	ret := _m.Called(ctx, address, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddCoveredRange")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address, domain.BlockRange) error); ok {
+		r0 = rf(ctx, address, r)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GapStore_AddCoveredRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddCoveredRange'
+type GapStore_AddCoveredRange_Call struct {
+	*mock.Call
+}
+
+// AddCoveredRange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - address domain.Address
+//   - r domain.BlockRange
+func (_e *GapStore_Expecter) AddCoveredRange(ctx interface{}, address interface{}, r interface{}) *GapStore_AddCoveredRange_Call {
+	return &GapStore_AddCoveredRange_Call{Call: _e.mock.On("AddCoveredRange", ctx, address, r)}
+}
+
+func (_c *GapStore_AddCoveredRange_Call) Run(run func(ctx context.Context, address domain.Address, r domain.BlockRange)) *GapStore_AddCoveredRange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Address), args[2].(domain.BlockRange))
+	})
+	return _c
+}
+
+func (_c *GapStore_AddCoveredRange_Call) Return(_a0 error) *GapStore_AddCoveredRange_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *GapStore_AddCoveredRange_Call) RunAndReturn(run func(context.Context, domain.Address, domain.BlockRange) error) *GapStore_AddCoveredRange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCoveredRanges provides a mock function with given fields: ctx, address
+func (_m *GapStore) GetCoveredRanges(ctx context.Context, address domain.Address) ([]domain.BlockRange, error) {
+	ret := _m.Called(ctx, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCoveredRanges")
+	}
+
+	var r0 []domain.BlockRange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address) ([]domain.BlockRange, error)); ok {
+		return rf(ctx, address)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address) []domain.BlockRange); ok {
+		r0 = rf(ctx, address)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.BlockRange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Address) error); ok {
+		r1 = rf(ctx, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GapStore_GetCoveredRanges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCoveredRanges'
+type GapStore_GetCoveredRanges_Call struct {
+	*mock.Call
+}
+
+// GetCoveredRanges is a helper method to define mock.On call
+//   - ctx context.Context
+//   - address domain.Address
+func (_e *GapStore_Expecter) GetCoveredRanges(ctx interface{}, address interface{}) *GapStore_GetCoveredRanges_Call {
+	return &GapStore_GetCoveredRanges_Call{Call: _e.mock.On("GetCoveredRanges", ctx, address)}
+}
+
+func (_c *GapStore_GetCoveredRanges_Call) Run(run func(ctx context.Context, address domain.Address)) *GapStore_GetCoveredRanges_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Address))
+	})
+	return _c
+}
+
+func (_c *GapStore_GetCoveredRanges_Call) Return(_a0 []domain.BlockRange, _a1 error) *GapStore_GetCoveredRanges_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *GapStore_GetCoveredRanges_Call) RunAndReturn(run func(context.Context, domain.Address) ([]domain.BlockRange, error)) *GapStore_GetCoveredRanges_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetGaps provides a mock function with given fields: ctx, address
+func (_m *GapStore) GetGaps(ctx context.Context, address domain.Address) ([]domain.Gap, error) {
+	ret := _m.Called(ctx, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGaps")
+	}
+
+	var r0 []domain.Gap
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address) ([]domain.Gap, error)); ok {
+		return rf(ctx, address)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address) []domain.Gap); ok {
+		r0 = rf(ctx, address)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Gap)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Address) error); ok {
+		r1 = rf(ctx, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GapStore_GetGaps_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetGaps'
+type GapStore_GetGaps_Call struct {
+	*mock.Call
+}
+
+// GetGaps is a helper method to define mock.On call
+//   - ctx context.Context
+//   - address domain.Address
+func (_e *GapStore_Expecter) GetGaps(ctx interface{}, address interface{}) *GapStore_GetGaps_Call {
+	return &GapStore_GetGaps_Call{Call: _e.mock.On("GetGaps", ctx, address)}
+}
+
+func (_c *GapStore_GetGaps_Call) Run(run func(ctx context.Context, address domain.Address)) *GapStore_GetGaps_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Address))
+	})
+	return _c
+}
+
+func (_c *GapStore_GetGaps_Call) Return(_a0 []domain.Gap, _a1 error) *GapStore_GetGaps_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *GapStore_GetGaps_Call) RunAndReturn(run func(context.Context, domain.Address) ([]domain.Gap, error)) *GapStore_GetGaps_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveGap provides a mock function with given fields: ctx, gap
+func (_m *GapStore) SaveGap(ctx context.Context, gap domain.Gap) error {
+	ret := _m.Called(ctx, gap)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveGap")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Gap) error); ok {
+		r0 = rf(ctx, gap)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GapStore_SaveGap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveGap'
+type GapStore_SaveGap_Call struct {
+	*mock.Call
+}
+
+// SaveGap is a helper method to define mock.On call
+//   - ctx context.Context
+//   - gap domain.Gap
+func (_e *GapStore_Expecter) SaveGap(ctx interface{}, gap interface{}) *GapStore_SaveGap_Call {
+	return &GapStore_SaveGap_Call{Call: _e.mock.On("SaveGap", ctx, gap)}
+}
+
+func (_c *GapStore_SaveGap_Call) Run(run func(ctx context.Context, gap domain.Gap)) *GapStore_SaveGap_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Gap))
+	})
+	return _c
+}
+
+func (_c *GapStore_SaveGap_Call) Return(_a0 error) *GapStore_SaveGap_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *GapStore_SaveGap_Call) RunAndReturn(run func(context.Context, domain.Gap) error) *GapStore_SaveGap_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewGapStore creates a new instance of GapStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewGapStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *GapStore {
+	mock := &GapStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
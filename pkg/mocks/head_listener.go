@@ -0,0 +1,70 @@
+// Code generated by mockery v2.39.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// HeadListener is an autogenerated mock type for the HeadListener type
+type HeadListener struct {
+	mock.Mock
+}
+
+type HeadListener_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *HeadListener) EXPECT() *HeadListener_Expecter {
+	return &HeadListener_Expecter{mock: &_m.Mock}
+}
+
+// NewHead provides a mock function with given fields: ctx, head
+func (_m *HeadListener) NewHead(ctx context.Context, head uint64) {
+	_m.Called(ctx, head)
+}
+
+// HeadListener_NewHead_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NewHead'
+type HeadListener_NewHead_Call struct {
+	*mock.Call
+}
+
+// NewHead is a helper method to define mock.On call
+//   - ctx context.Context
+//   - head uint64
+func (_e *HeadListener_Expecter) NewHead(ctx interface{}, head interface{}) *HeadListener_NewHead_Call {
+	return &HeadListener_NewHead_Call{Call: _e.mock.On("NewHead", ctx, head)}
+}
+
+func (_c *HeadListener_NewHead_Call) Run(run func(ctx context.Context, head uint64)) *HeadListener_NewHead_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64))
+	})
+	return _c
+}
+
+func (_c *HeadListener_NewHead_Call) Return() *HeadListener_NewHead_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *HeadListener_NewHead_Call) RunAndReturn(run func(context.Context, uint64)) *HeadListener_NewHead_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewHeadListener creates a new instance of HeadListener. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewHeadListener(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *HeadListener {
+	mock := &HeadListener{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,154 @@
+// Code generated by mockery v2.39.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	domain "github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+// NodeAPI is an autogenerated mock type for the NodeAPI type
+type NodeAPI struct {
+	mock.Mock
+}
+
+type NodeAPI_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *NodeAPI) EXPECT() *NodeAPI_Expecter {
+	return &NodeAPI_Expecter{mock: &_m.Mock}
+}
+
+// BlockNumber provides a mock function with given fields: ctx
+func (_m *NodeAPI) BlockNumber(ctx context.Context) (uint64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BlockNumber")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (uint64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) uint64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NodeAPI_BlockNumber_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BlockNumber'
+type NodeAPI_BlockNumber_Call struct {
+	*mock.Call
+}
+
+// BlockNumber is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *NodeAPI_Expecter) BlockNumber(ctx interface{}) *NodeAPI_BlockNumber_Call {
+	return &NodeAPI_BlockNumber_Call{Call: _e.mock.On("BlockNumber", ctx)}
+}
+
+func (_c *NodeAPI_BlockNumber_Call) Run(run func(ctx context.Context)) *NodeAPI_BlockNumber_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *NodeAPI_BlockNumber_Call) Return(_a0 uint64, _a1 error) *NodeAPI_BlockNumber_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *NodeAPI_BlockNumber_Call) RunAndReturn(run func(context.Context) (uint64, error)) *NodeAPI_BlockNumber_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLogs provides a mock function with given fields: ctx, address, fromBlock, toBlock
+func (_m *NodeAPI) GetLogs(ctx context.Context, address domain.Address, fromBlock uint64, toBlock uint64) ([]domain.Transaction, error) {
+	ret := _m.Called(ctx, address, fromBlock, toBlock)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLogs")
+	}
+
+	var r0 []domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address, uint64, uint64) ([]domain.Transaction, error)); ok {
+		return rf(ctx, address, fromBlock, toBlock)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address, uint64, uint64) []domain.Transaction); ok {
+		r0 = rf(ctx, address, fromBlock, toBlock)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Address, uint64, uint64) error); ok {
+		r1 = rf(ctx, address, fromBlock, toBlock)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NodeAPI_GetLogs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLogs'
+type NodeAPI_GetLogs_Call struct {
+	*mock.Call
+}
+
+// GetLogs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - address domain.Address
+//   - fromBlock uint64
+//   - toBlock uint64
+func (_e *NodeAPI_Expecter) GetLogs(ctx interface{}, address interface{}, fromBlock interface{}, toBlock interface{}) *NodeAPI_GetLogs_Call {
+	return &NodeAPI_GetLogs_Call{Call: _e.mock.On("GetLogs", ctx, address, fromBlock, toBlock)}
+}
+
+func (_c *NodeAPI_GetLogs_Call) Run(run func(ctx context.Context, address domain.Address, fromBlock uint64, toBlock uint64)) *NodeAPI_GetLogs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Address), args[2].(uint64), args[3].(uint64))
+	})
+	return _c
+}
+
+func (_c *NodeAPI_GetLogs_Call) Return(_a0 []domain.Transaction, _a1 error) *NodeAPI_GetLogs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *NodeAPI_GetLogs_Call) RunAndReturn(run func(context.Context, domain.Address, uint64, uint64) ([]domain.Transaction, error)) *NodeAPI_GetLogs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewNodeAPI creates a new instance of NodeAPI. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewNodeAPI(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *NodeAPI {
+	mock := &NodeAPI{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
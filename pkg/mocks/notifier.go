@@ -0,0 +1,87 @@
+// Code generated by mockery v2.39.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Notifier is an autogenerated mock type for the Notifier type
+type Notifier struct {
+	mock.Mock
+}
+
+type Notifier_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Notifier) EXPECT() *Notifier_Expecter {
+	return &Notifier_Expecter{mock: &_m.Mock}
+}
+
+// Notify provides a mock function with given fields: ctx, chainID, address, transactions
+func (_m *Notifier) Notify(ctx context.Context, chainID domain.ChainID, address domain.Address, transactions []domain.Transaction) error {
+	ret := _m.Called(ctx, chainID, address, transactions)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Notify")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChainID, domain.Address, []domain.Transaction) error); ok {
+		r0 = rf(ctx, chainID, address, transactions)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Notifier_Notify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Notify'
+type Notifier_Notify_Call struct {
+	*mock.Call
+}
+
+// Notify is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chainID domain.ChainID
+//   - address domain.Address
+//   - transactions []domain.Transaction
+func (_e *Notifier_Expecter) Notify(ctx interface{}, chainID interface{}, address interface{}, transactions interface{}) *Notifier_Notify_Call {
+	return &Notifier_Notify_Call{Call: _e.mock.On("Notify", ctx, chainID, address, transactions)}
+}
+
+func (_c *Notifier_Notify_Call) Run(run func(ctx context.Context, chainID domain.ChainID, address domain.Address, transactions []domain.Transaction)) *Notifier_Notify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.ChainID), args[2].(domain.Address), args[3].([]domain.Transaction))
+	})
+	return _c
+}
+
+func (_c *Notifier_Notify_Call) Return(_a0 error) *Notifier_Notify_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Notifier_Notify_Call) RunAndReturn(run func(context.Context, domain.ChainID, domain.Address, []domain.Transaction) error) *Notifier_Notify_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewNotifier creates a new instance of Notifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewNotifier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Notifier {
+	mock := &Notifier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,799 @@
+// Code generated by mockery v2.39.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	domain "github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+
+	time "time"
+)
+
+// ParserExtensions is an autogenerated mock type for the ParserExtensions type
+type ParserExtensions struct {
+	mock.Mock
+}
+
+type ParserExtensions_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ParserExtensions) EXPECT() *ParserExtensions_Expecter {
+	return &ParserExtensions_Expecter{mock: &_m.Mock}
+}
+
+// GetBlockTransactions provides a mock function with given fields: blockNumber
+func (_m *ParserExtensions) GetBlockTransactions(blockNumber uint64) []domain.Transaction {
+	ret := _m.Called(blockNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlockTransactions")
+	}
+
+	var r0 []domain.Transaction
+	if rf, ok := ret.Get(0).(func(uint64) []domain.Transaction); ok {
+		r0 = rf(blockNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Transaction)
+		}
+	}
+
+	return r0
+}
+
+// ParserExtensions_GetBlockTransactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBlockTransactions'
+type ParserExtensions_GetBlockTransactions_Call struct {
+	*mock.Call
+}
+
+// GetBlockTransactions is a helper method to define mock.On call
+//   - blockNumber uint64
+func (_e *ParserExtensions_Expecter) GetBlockTransactions(blockNumber interface{}) *ParserExtensions_GetBlockTransactions_Call {
+	return &ParserExtensions_GetBlockTransactions_Call{Call: _e.mock.On("GetBlockTransactions", blockNumber)}
+}
+
+func (_c *ParserExtensions_GetBlockTransactions_Call) Run(run func(blockNumber uint64)) *ParserExtensions_GetBlockTransactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint64))
+	})
+	return _c
+}
+
+func (_c *ParserExtensions_GetBlockTransactions_Call) Return(_a0 []domain.Transaction) *ParserExtensions_GetBlockTransactions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ParserExtensions_GetBlockTransactions_Call) RunAndReturn(run func(uint64) []domain.Transaction) *ParserExtensions_GetBlockTransactions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBlockTransactionsOn provides a mock function with given fields: chain, blockNumber
+func (_m *ParserExtensions) GetBlockTransactionsOn(chain domain.ChainID, blockNumber uint64) []domain.Transaction {
+	ret := _m.Called(chain, blockNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlockTransactionsOn")
+	}
+
+	var r0 []domain.Transaction
+	if rf, ok := ret.Get(0).(func(domain.ChainID, uint64) []domain.Transaction); ok {
+		r0 = rf(chain, blockNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Transaction)
+		}
+	}
+
+	return r0
+}
+
+// ParserExtensions_GetBlockTransactionsOn_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBlockTransactionsOn'
+type ParserExtensions_GetBlockTransactionsOn_Call struct {
+	*mock.Call
+}
+
+// GetBlockTransactionsOn is a helper method to define mock.On call
+//   - chain domain.ChainID
+//   - blockNumber uint64
+func (_e *ParserExtensions_Expecter) GetBlockTransactionsOn(chain interface{}, blockNumber interface{}) *ParserExtensions_GetBlockTransactionsOn_Call {
+	return &ParserExtensions_GetBlockTransactionsOn_Call{Call: _e.mock.On("GetBlockTransactionsOn", chain, blockNumber)}
+}
+
+func (_c *ParserExtensions_GetBlockTransactionsOn_Call) Run(run func(chain domain.ChainID, blockNumber uint64)) *ParserExtensions_GetBlockTransactionsOn_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(domain.ChainID), args[1].(uint64))
+	})
+	return _c
+}
+
+func (_c *ParserExtensions_GetBlockTransactionsOn_Call) Return(_a0 []domain.Transaction) *ParserExtensions_GetBlockTransactionsOn_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ParserExtensions_GetBlockTransactionsOn_Call) RunAndReturn(run func(domain.ChainID, uint64) []domain.Transaction) *ParserExtensions_GetBlockTransactionsOn_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCurrentBlock provides a mock function with given fields:
+func (_m *ParserExtensions) GetCurrentBlock() int {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCurrentBlock")
+	}
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// ParserExtensions_GetCurrentBlock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCurrentBlock'
+type ParserExtensions_GetCurrentBlock_Call struct {
+	*mock.Call
+}
+
+// GetCurrentBlock is a helper method to define mock.On call
+func (_e *ParserExtensions_Expecter) GetCurrentBlock() *ParserExtensions_GetCurrentBlock_Call {
+	return &ParserExtensions_GetCurrentBlock_Call{Call: _e.mock.On("GetCurrentBlock")}
+}
+
+func (_c *ParserExtensions_GetCurrentBlock_Call) Run(run func()) *ParserExtensions_GetCurrentBlock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *ParserExtensions_GetCurrentBlock_Call) Return(_a0 int) *ParserExtensions_GetCurrentBlock_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ParserExtensions_GetCurrentBlock_Call) RunAndReturn(run func() int) *ParserExtensions_GetCurrentBlock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCurrentBlockOn provides a mock function with given fields: chain
+func (_m *ParserExtensions) GetCurrentBlockOn(chain domain.ChainID) int {
+	ret := _m.Called(chain)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCurrentBlockOn")
+	}
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(domain.ChainID) int); ok {
+		r0 = rf(chain)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// ParserExtensions_GetCurrentBlockOn_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCurrentBlockOn'
+type ParserExtensions_GetCurrentBlockOn_Call struct {
+	*mock.Call
+}
+
+// GetCurrentBlockOn is a helper method to define mock.On call
+//   - chain domain.ChainID
+func (_e *ParserExtensions_Expecter) GetCurrentBlockOn(chain interface{}) *ParserExtensions_GetCurrentBlockOn_Call {
+	return &ParserExtensions_GetCurrentBlockOn_Call{Call: _e.mock.On("GetCurrentBlockOn", chain)}
+}
+
+func (_c *ParserExtensions_GetCurrentBlockOn_Call) Run(run func(chain domain.ChainID)) *ParserExtensions_GetCurrentBlockOn_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(domain.ChainID))
+	})
+	return _c
+}
+
+func (_c *ParserExtensions_GetCurrentBlockOn_Call) Return(_a0 int) *ParserExtensions_GetCurrentBlockOn_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ParserExtensions_GetCurrentBlockOn_Call) RunAndReturn(run func(domain.ChainID) int) *ParserExtensions_GetCurrentBlockOn_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPendingTransactions provides a mock function with given fields: address
+func (_m *ParserExtensions) GetPendingTransactions(address string) []domain.PendingTransaction {
+	ret := _m.Called(address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingTransactions")
+	}
+
+	var r0 []domain.PendingTransaction
+	if rf, ok := ret.Get(0).(func(string) []domain.PendingTransaction); ok {
+		r0 = rf(address)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PendingTransaction)
+		}
+	}
+
+	return r0
+}
+
+// ParserExtensions_GetPendingTransactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingTransactions'
+type ParserExtensions_GetPendingTransactions_Call struct {
+	*mock.Call
+}
+
+// GetPendingTransactions is a helper method to define mock.On call
+//   - address string
+func (_e *ParserExtensions_Expecter) GetPendingTransactions(address interface{}) *ParserExtensions_GetPendingTransactions_Call {
+	return &ParserExtensions_GetPendingTransactions_Call{Call: _e.mock.On("GetPendingTransactions", address)}
+}
+
+func (_c *ParserExtensions_GetPendingTransactions_Call) Run(run func(address string)) *ParserExtensions_GetPendingTransactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *ParserExtensions_GetPendingTransactions_Call) Return(_a0 []domain.PendingTransaction) *ParserExtensions_GetPendingTransactions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ParserExtensions_GetPendingTransactions_Call) RunAndReturn(run func(string) []domain.PendingTransaction) *ParserExtensions_GetPendingTransactions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTransactions provides a mock function with given fields: address
+func (_m *ParserExtensions) GetTransactions(address string) []domain.Transaction {
+	ret := _m.Called(address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactions")
+	}
+
+	var r0 []domain.Transaction
+	if rf, ok := ret.Get(0).(func(string) []domain.Transaction); ok {
+		r0 = rf(address)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Transaction)
+		}
+	}
+
+	return r0
+}
+
+// ParserExtensions_GetTransactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransactions'
+type ParserExtensions_GetTransactions_Call struct {
+	*mock.Call
+}
+
+// GetTransactions is a helper method to define mock.On call
+//   - address string
+func (_e *ParserExtensions_Expecter) GetTransactions(address interface{}) *ParserExtensions_GetTransactions_Call {
+	return &ParserExtensions_GetTransactions_Call{Call: _e.mock.On("GetTransactions", address)}
+}
+
+func (_c *ParserExtensions_GetTransactions_Call) Run(run func(address string)) *ParserExtensions_GetTransactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *ParserExtensions_GetTransactions_Call) Return(_a0 []domain.Transaction) *ParserExtensions_GetTransactions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ParserExtensions_GetTransactions_Call) RunAndReturn(run func(string) []domain.Transaction) *ParserExtensions_GetTransactions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTransactionsByHash provides a mock function with given fields: hash
+func (_m *ParserExtensions) GetTransactionsByHash(hash string) []domain.Transaction {
+	ret := _m.Called(hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionsByHash")
+	}
+
+	var r0 []domain.Transaction
+	if rf, ok := ret.Get(0).(func(string) []domain.Transaction); ok {
+		r0 = rf(hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Transaction)
+		}
+	}
+
+	return r0
+}
+
+// ParserExtensions_GetTransactionsByHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransactionsByHash'
+type ParserExtensions_GetTransactionsByHash_Call struct {
+	*mock.Call
+}
+
+// GetTransactionsByHash is a helper method to define mock.On call
+//   - hash string
+func (_e *ParserExtensions_Expecter) GetTransactionsByHash(hash interface{}) *ParserExtensions_GetTransactionsByHash_Call {
+	return &ParserExtensions_GetTransactionsByHash_Call{Call: _e.mock.On("GetTransactionsByHash", hash)}
+}
+
+func (_c *ParserExtensions_GetTransactionsByHash_Call) Run(run func(hash string)) *ParserExtensions_GetTransactionsByHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *ParserExtensions_GetTransactionsByHash_Call) Return(_a0 []domain.Transaction) *ParserExtensions_GetTransactionsByHash_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ParserExtensions_GetTransactionsByHash_Call) RunAndReturn(run func(string) []domain.Transaction) *ParserExtensions_GetTransactionsByHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTransactionsByHashOn provides a mock function with given fields: chain, hash
+func (_m *ParserExtensions) GetTransactionsByHashOn(chain domain.ChainID, hash string) []domain.Transaction {
+	ret := _m.Called(chain, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionsByHashOn")
+	}
+
+	var r0 []domain.Transaction
+	if rf, ok := ret.Get(0).(func(domain.ChainID, string) []domain.Transaction); ok {
+		r0 = rf(chain, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Transaction)
+		}
+	}
+
+	return r0
+}
+
+// ParserExtensions_GetTransactionsByHashOn_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransactionsByHashOn'
+type ParserExtensions_GetTransactionsByHashOn_Call struct {
+	*mock.Call
+}
+
+// GetTransactionsByHashOn is a helper method to define mock.On call
+//   - chain domain.ChainID
+//   - hash string
+func (_e *ParserExtensions_Expecter) GetTransactionsByHashOn(chain interface{}, hash interface{}) *ParserExtensions_GetTransactionsByHashOn_Call {
+	return &ParserExtensions_GetTransactionsByHashOn_Call{Call: _e.mock.On("GetTransactionsByHashOn", chain, hash)}
+}
+
+func (_c *ParserExtensions_GetTransactionsByHashOn_Call) Run(run func(chain domain.ChainID, hash string)) *ParserExtensions_GetTransactionsByHashOn_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(domain.ChainID), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ParserExtensions_GetTransactionsByHashOn_Call) Return(_a0 []domain.Transaction) *ParserExtensions_GetTransactionsByHashOn_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ParserExtensions_GetTransactionsByHashOn_Call) RunAndReturn(run func(domain.ChainID, string) []domain.Transaction) *ParserExtensions_GetTransactionsByHashOn_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTransactionsByTime provides a mock function with given fields: address, from, to
+func (_m *ParserExtensions) GetTransactionsByTime(address string, from time.Time, to time.Time) []domain.Transaction {
+	ret := _m.Called(address, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionsByTime")
+	}
+
+	var r0 []domain.Transaction
+	if rf, ok := ret.Get(0).(func(string, time.Time, time.Time) []domain.Transaction); ok {
+		r0 = rf(address, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Transaction)
+		}
+	}
+
+	return r0
+}
+
+// ParserExtensions_GetTransactionsByTime_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransactionsByTime'
+type ParserExtensions_GetTransactionsByTime_Call struct {
+	*mock.Call
+}
+
+// GetTransactionsByTime is a helper method to define mock.On call
+//   - address string
+//   - from time.Time
+//   - to time.Time
+func (_e *ParserExtensions_Expecter) GetTransactionsByTime(address interface{}, from interface{}, to interface{}) *ParserExtensions_GetTransactionsByTime_Call {
+	return &ParserExtensions_GetTransactionsByTime_Call{Call: _e.mock.On("GetTransactionsByTime", address, from, to)}
+}
+
+func (_c *ParserExtensions_GetTransactionsByTime_Call) Run(run func(address string, from time.Time, to time.Time)) *ParserExtensions_GetTransactionsByTime_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(time.Time), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *ParserExtensions_GetTransactionsByTime_Call) Return(_a0 []domain.Transaction) *ParserExtensions_GetTransactionsByTime_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ParserExtensions_GetTransactionsByTime_Call) RunAndReturn(run func(string, time.Time, time.Time) []domain.Transaction) *ParserExtensions_GetTransactionsByTime_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTransactionsOn provides a mock function with given fields: chain, address
+func (_m *ParserExtensions) GetTransactionsOn(chain domain.ChainID, address string) []domain.Transaction {
+	ret := _m.Called(chain, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionsOn")
+	}
+
+	var r0 []domain.Transaction
+	if rf, ok := ret.Get(0).(func(domain.ChainID, string) []domain.Transaction); ok {
+		r0 = rf(chain, address)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Transaction)
+		}
+	}
+
+	return r0
+}
+
+// ParserExtensions_GetTransactionsOn_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransactionsOn'
+type ParserExtensions_GetTransactionsOn_Call struct {
+	*mock.Call
+}
+
+// GetTransactionsOn is a helper method to define mock.On call
+//   - chain domain.ChainID
+//   - address string
+func (_e *ParserExtensions_Expecter) GetTransactionsOn(chain interface{}, address interface{}) *ParserExtensions_GetTransactionsOn_Call {
+	return &ParserExtensions_GetTransactionsOn_Call{Call: _e.mock.On("GetTransactionsOn", chain, address)}
+}
+
+func (_c *ParserExtensions_GetTransactionsOn_Call) Run(run func(chain domain.ChainID, address string)) *ParserExtensions_GetTransactionsOn_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(domain.ChainID), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ParserExtensions_GetTransactionsOn_Call) Return(_a0 []domain.Transaction) *ParserExtensions_GetTransactionsOn_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ParserExtensions_GetTransactionsOn_Call) RunAndReturn(run func(domain.ChainID, string) []domain.Transaction) *ParserExtensions_GetTransactionsOn_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// QueryTransactions provides a mock function with given fields: query
+func (_m *ParserExtensions) QueryTransactions(query domain.TransactionQuery) (domain.TransactionPage, error) {
+	ret := _m.Called(query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueryTransactions")
+	}
+
+	var r0 domain.TransactionPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.TransactionQuery) (domain.TransactionPage, error)); ok {
+		return rf(query)
+	}
+	if rf, ok := ret.Get(0).(func(domain.TransactionQuery) domain.TransactionPage); ok {
+		r0 = rf(query)
+	} else {
+		r0 = ret.Get(0).(domain.TransactionPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.TransactionQuery) error); ok {
+		r1 = rf(query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ParserExtensions_QueryTransactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryTransactions'
+type ParserExtensions_QueryTransactions_Call struct {
+	*mock.Call
+}
+
+// QueryTransactions is a helper method to define mock.On call
+//   - query domain.TransactionQuery
+func (_e *ParserExtensions_Expecter) QueryTransactions(query interface{}) *ParserExtensions_QueryTransactions_Call {
+	return &ParserExtensions_QueryTransactions_Call{Call: _e.mock.On("QueryTransactions", query)}
+}
+
+func (_c *ParserExtensions_QueryTransactions_Call) Run(run func(query domain.TransactionQuery)) *ParserExtensions_QueryTransactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(domain.TransactionQuery))
+	})
+	return _c
+}
+
+func (_c *ParserExtensions_QueryTransactions_Call) Return(_a0 domain.TransactionPage, _a1 error) *ParserExtensions_QueryTransactions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ParserExtensions_QueryTransactions_Call) RunAndReturn(run func(domain.TransactionQuery) (domain.TransactionPage, error)) *ParserExtensions_QueryTransactions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// QueryTransactionsOn provides a mock function with given fields: chain, query
+func (_m *ParserExtensions) QueryTransactionsOn(chain domain.ChainID, query domain.TransactionQuery) (domain.TransactionPage, error) {
+	ret := _m.Called(chain, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueryTransactionsOn")
+	}
+
+	var r0 domain.TransactionPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.ChainID, domain.TransactionQuery) (domain.TransactionPage, error)); ok {
+		return rf(chain, query)
+	}
+	if rf, ok := ret.Get(0).(func(domain.ChainID, domain.TransactionQuery) domain.TransactionPage); ok {
+		r0 = rf(chain, query)
+	} else {
+		r0 = ret.Get(0).(domain.TransactionPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.ChainID, domain.TransactionQuery) error); ok {
+		r1 = rf(chain, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ParserExtensions_QueryTransactionsOn_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryTransactionsOn'
+type ParserExtensions_QueryTransactionsOn_Call struct {
+	*mock.Call
+}
+
+// QueryTransactionsOn is a helper method to define mock.On call
+//   - chain domain.ChainID
+//   - query domain.TransactionQuery
+func (_e *ParserExtensions_Expecter) QueryTransactionsOn(chain interface{}, query interface{}) *ParserExtensions_QueryTransactionsOn_Call {
+	return &ParserExtensions_QueryTransactionsOn_Call{Call: _e.mock.On("QueryTransactionsOn", chain, query)}
+}
+
+func (_c *ParserExtensions_QueryTransactionsOn_Call) Run(run func(chain domain.ChainID, query domain.TransactionQuery)) *ParserExtensions_QueryTransactionsOn_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(domain.ChainID), args[1].(domain.TransactionQuery))
+	})
+	return _c
+}
+
+func (_c *ParserExtensions_QueryTransactionsOn_Call) Return(_a0 domain.TransactionPage, _a1 error) *ParserExtensions_QueryTransactionsOn_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ParserExtensions_QueryTransactionsOn_Call) RunAndReturn(run func(domain.ChainID, domain.TransactionQuery) (domain.TransactionPage, error)) *ParserExtensions_QueryTransactionsOn_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Subscribe provides a mock function with given fields: address
+func (_m *ParserExtensions) Subscribe(address string) bool {
+	ret := _m.Called(address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Subscribe")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(address)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// ParserExtensions_Subscribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Subscribe'
+type ParserExtensions_Subscribe_Call struct {
+	*mock.Call
+}
+
+// Subscribe is a helper method to define mock.On call
+//   - address string
+func (_e *ParserExtensions_Expecter) Subscribe(address interface{}) *ParserExtensions_Subscribe_Call {
+	return &ParserExtensions_Subscribe_Call{Call: _e.mock.On("Subscribe", address)}
+}
+
+func (_c *ParserExtensions_Subscribe_Call) Run(run func(address string)) *ParserExtensions_Subscribe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *ParserExtensions_Subscribe_Call) Return(_a0 bool) *ParserExtensions_Subscribe_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ParserExtensions_Subscribe_Call) RunAndReturn(run func(string) bool) *ParserExtensions_Subscribe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SubscribeOn provides a mock function with given fields: chain, address
+func (_m *ParserExtensions) SubscribeOn(chain domain.ChainID, address string) bool {
+	ret := _m.Called(chain, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscribeOn")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(domain.ChainID, string) bool); ok {
+		r0 = rf(chain, address)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// ParserExtensions_SubscribeOn_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SubscribeOn'
+type ParserExtensions_SubscribeOn_Call struct {
+	*mock.Call
+}
+
+// SubscribeOn is a helper method to define mock.On call
+//   - chain domain.ChainID
+//   - address string
+func (_e *ParserExtensions_Expecter) SubscribeOn(chain interface{}, address interface{}) *ParserExtensions_SubscribeOn_Call {
+	return &ParserExtensions_SubscribeOn_Call{Call: _e.mock.On("SubscribeOn", chain, address)}
+}
+
+func (_c *ParserExtensions_SubscribeOn_Call) Run(run func(chain domain.ChainID, address string)) *ParserExtensions_SubscribeOn_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(domain.ChainID), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ParserExtensions_SubscribeOn_Call) Return(_a0 bool) *ParserExtensions_SubscribeOn_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ParserExtensions_SubscribeOn_Call) RunAndReturn(run func(domain.ChainID, string) bool) *ParserExtensions_SubscribeOn_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Watch provides a mock function with given fields: ctx, filter, opts
+func (_m *ParserExtensions) Watch(ctx context.Context, filter domain.EventFilter, opts ...domain.WatchOption) (<-chan domain.Event, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, filter)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Watch")
+	}
+
+	var r0 <-chan domain.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.EventFilter, ...domain.WatchOption) (<-chan domain.Event, error)); ok {
+		return rf(ctx, filter, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.EventFilter, ...domain.WatchOption) <-chan domain.Event); ok {
+		r0 = rf(ctx, filter, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan domain.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.EventFilter, ...domain.WatchOption) error); ok {
+		r1 = rf(ctx, filter, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ParserExtensions_Watch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Watch'
+type ParserExtensions_Watch_Call struct {
+	*mock.Call
+}
+
+// Watch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.EventFilter
+//   - opts ...domain.WatchOption
+func (_e *ParserExtensions_Expecter) Watch(ctx interface{}, filter interface{}, opts ...interface{}) *ParserExtensions_Watch_Call {
+	return &ParserExtensions_Watch_Call{Call: _e.mock.On("Watch",
+		append([]interface{}{ctx, filter}, opts...)...)}
+}
+
+func (_c *ParserExtensions_Watch_Call) Run(run func(ctx context.Context, filter domain.EventFilter, opts ...domain.WatchOption)) *ParserExtensions_Watch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]domain.WatchOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(domain.WatchOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(domain.EventFilter), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *ParserExtensions_Watch_Call) Return(_a0 <-chan domain.Event, _a1 error) *ParserExtensions_Watch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ParserExtensions_Watch_Call) RunAndReturn(run func(context.Context, domain.EventFilter, ...domain.WatchOption) (<-chan domain.Event, error)) *ParserExtensions_Watch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewParserExtensions creates a new instance of ParserExtensions. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewParserExtensions(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ParserExtensions {
+	mock := &ParserExtensions{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,976 @@
+// Code generated by mockery v2.39.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	domain "github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+
+	time "time"
+)
+
+// ParserV2 is an autogenerated mock type for the ParserV2 type
+type ParserV2 struct {
+	mock.Mock
+}
+
+type ParserV2_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ParserV2) EXPECT() *ParserV2_Expecter {
+	return &ParserV2_Expecter{mock: &_m.Mock}
+}
+
+// GetBlockTransactions provides a mock function with given fields: ctx, chain, blockNumber
+func (_m *ParserV2) GetBlockTransactions(ctx context.Context, chain domain.ChainID, blockNumber uint64) ([]domain.Transaction, error) {
+	ret := _m.Called(ctx, chain, blockNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlockTransactions")
+	}
+
+	var r0 []domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChainID, uint64) ([]domain.Transaction, error)); ok {
+		return rf(ctx, chain, blockNumber)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChainID, uint64) []domain.Transaction); ok {
+		r0 = rf(ctx, chain, blockNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ChainID, uint64) error); ok {
+		r1 = rf(ctx, chain, blockNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ParserV2_GetBlockTransactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBlockTransactions'
+type ParserV2_GetBlockTransactions_Call struct {
+	*mock.Call
+}
+
+// GetBlockTransactions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chain domain.ChainID
+//   - blockNumber uint64
+func (_e *ParserV2_Expecter) GetBlockTransactions(ctx interface{}, chain interface{}, blockNumber interface{}) *ParserV2_GetBlockTransactions_Call {
+	return &ParserV2_GetBlockTransactions_Call{Call: _e.mock.On("GetBlockTransactions", ctx, chain, blockNumber)}
+}
+
+func (_c *ParserV2_GetBlockTransactions_Call) Run(run func(ctx context.Context, chain domain.ChainID, blockNumber uint64)) *ParserV2_GetBlockTransactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.ChainID), args[2].(uint64))
+	})
+	return _c
+}
+
+func (_c *ParserV2_GetBlockTransactions_Call) Return(_a0 []domain.Transaction, _a1 error) *ParserV2_GetBlockTransactions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ParserV2_GetBlockTransactions_Call) RunAndReturn(run func(context.Context, domain.ChainID, uint64) ([]domain.Transaction, error)) *ParserV2_GetBlockTransactions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCurrentBlock provides a mock function with given fields: ctx, chain
+func (_m *ParserV2) GetCurrentBlock(ctx context.Context, chain domain.ChainID) (uint64, error) {
+	ret := _m.Called(ctx, chain)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCurrentBlock")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChainID) (uint64, error)); ok {
+		return rf(ctx, chain)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChainID) uint64); ok {
+		r0 = rf(ctx, chain)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ChainID) error); ok {
+		r1 = rf(ctx, chain)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ParserV2_GetCurrentBlock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCurrentBlock'
+type ParserV2_GetCurrentBlock_Call struct {
+	*mock.Call
+}
+
+// GetCurrentBlock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chain domain.ChainID
+func (_e *ParserV2_Expecter) GetCurrentBlock(ctx interface{}, chain interface{}) *ParserV2_GetCurrentBlock_Call {
+	return &ParserV2_GetCurrentBlock_Call{Call: _e.mock.On("GetCurrentBlock", ctx, chain)}
+}
+
+func (_c *ParserV2_GetCurrentBlock_Call) Run(run func(ctx context.Context, chain domain.ChainID)) *ParserV2_GetCurrentBlock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.ChainID))
+	})
+	return _c
+}
+
+func (_c *ParserV2_GetCurrentBlock_Call) Return(_a0 uint64, _a1 error) *ParserV2_GetCurrentBlock_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ParserV2_GetCurrentBlock_Call) RunAndReturn(run func(context.Context, domain.ChainID) (uint64, error)) *ParserV2_GetCurrentBlock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPendingTransactions provides a mock function with given fields: ctx, chain, address
+func (_m *ParserV2) GetPendingTransactions(ctx context.Context, chain domain.ChainID, address string) ([]domain.PendingTransaction, error) {
+	ret := _m.Called(ctx, chain, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingTransactions")
+	}
+
+	var r0 []domain.PendingTransaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChainID, string) ([]domain.PendingTransaction, error)); ok {
+		return rf(ctx, chain, address)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChainID, string) []domain.PendingTransaction); ok {
+		r0 = rf(ctx, chain, address)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PendingTransaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ChainID, string) error); ok {
+		r1 = rf(ctx, chain, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ParserV2_GetPendingTransactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingTransactions'
+type ParserV2_GetPendingTransactions_Call struct {
+	*mock.Call
+}
+
+// GetPendingTransactions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chain domain.ChainID
+//   - address string
+func (_e *ParserV2_Expecter) GetPendingTransactions(ctx interface{}, chain interface{}, address interface{}) *ParserV2_GetPendingTransactions_Call {
+	return &ParserV2_GetPendingTransactions_Call{Call: _e.mock.On("GetPendingTransactions", ctx, chain, address)}
+}
+
+func (_c *ParserV2_GetPendingTransactions_Call) Run(run func(ctx context.Context, chain domain.ChainID, address string)) *ParserV2_GetPendingTransactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.ChainID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ParserV2_GetPendingTransactions_Call) Return(_a0 []domain.PendingTransaction, _a1 error) *ParserV2_GetPendingTransactions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ParserV2_GetPendingTransactions_Call) RunAndReturn(run func(context.Context, domain.ChainID, string) ([]domain.PendingTransaction, error)) *ParserV2_GetPendingTransactions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSubscription provides a mock function with given fields: ctx, chain, address
+func (_m *ParserV2) GetSubscription(ctx context.Context, chain domain.ChainID, address string) (domain.Subscription, error) {
+	ret := _m.Called(ctx, chain, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubscription")
+	}
+
+	var r0 domain.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChainID, string) (domain.Subscription, error)); ok {
+		return rf(ctx, chain, address)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChainID, string) domain.Subscription); ok {
+		r0 = rf(ctx, chain, address)
+	} else {
+		r0 = ret.Get(0).(domain.Subscription)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ChainID, string) error); ok {
+		r1 = rf(ctx, chain, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ParserV2_GetSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSubscription'
+type ParserV2_GetSubscription_Call struct {
+	*mock.Call
+}
+
+// GetSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chain domain.ChainID
+//   - address string
+func (_e *ParserV2_Expecter) GetSubscription(ctx interface{}, chain interface{}, address interface{}) *ParserV2_GetSubscription_Call {
+	return &ParserV2_GetSubscription_Call{Call: _e.mock.On("GetSubscription", ctx, chain, address)}
+}
+
+func (_c *ParserV2_GetSubscription_Call) Run(run func(ctx context.Context, chain domain.ChainID, address string)) *ParserV2_GetSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.ChainID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ParserV2_GetSubscription_Call) Return(_a0 domain.Subscription, _a1 error) *ParserV2_GetSubscription_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ParserV2_GetSubscription_Call) RunAndReturn(run func(context.Context, domain.ChainID, string) (domain.Subscription, error)) *ParserV2_GetSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSubscriptionGaps provides a mock function with given fields: ctx, chain, address
+func (_m *ParserV2) GetSubscriptionGaps(ctx context.Context, chain domain.ChainID, address string) ([]domain.Gap, error) {
+	ret := _m.Called(ctx, chain, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubscriptionGaps")
+	}
+
+	var r0 []domain.Gap
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChainID, string) ([]domain.Gap, error)); ok {
+		return rf(ctx, chain, address)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChainID, string) []domain.Gap); ok {
+		r0 = rf(ctx, chain, address)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Gap)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ChainID, string) error); ok {
+		r1 = rf(ctx, chain, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ParserV2_GetSubscriptionGaps_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSubscriptionGaps'
+type ParserV2_GetSubscriptionGaps_Call struct {
+	*mock.Call
+}
+
+// GetSubscriptionGaps is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chain domain.ChainID
+//   - address string
+func (_e *ParserV2_Expecter) GetSubscriptionGaps(ctx interface{}, chain interface{}, address interface{}) *ParserV2_GetSubscriptionGaps_Call {
+	return &ParserV2_GetSubscriptionGaps_Call{Call: _e.mock.On("GetSubscriptionGaps", ctx, chain, address)}
+}
+
+func (_c *ParserV2_GetSubscriptionGaps_Call) Run(run func(ctx context.Context, chain domain.ChainID, address string)) *ParserV2_GetSubscriptionGaps_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.ChainID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ParserV2_GetSubscriptionGaps_Call) Return(_a0 []domain.Gap, _a1 error) *ParserV2_GetSubscriptionGaps_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ParserV2_GetSubscriptionGaps_Call) RunAndReturn(run func(context.Context, domain.ChainID, string) ([]domain.Gap, error)) *ParserV2_GetSubscriptionGaps_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSubscriptions provides a mock function with given fields: ctx, chain
+func (_m *ParserV2) GetSubscriptions(ctx context.Context, chain domain.ChainID) ([]domain.Subscription, error) {
+	ret := _m.Called(ctx, chain)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubscriptions")
+	}
+
+	var r0 []domain.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChainID) ([]domain.Subscription, error)); ok {
+		return rf(ctx, chain)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChainID) []domain.Subscription); ok {
+		r0 = rf(ctx, chain)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ChainID) error); ok {
+		r1 = rf(ctx, chain)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ParserV2_GetSubscriptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSubscriptions'
+type ParserV2_GetSubscriptions_Call struct {
+	*mock.Call
+}
+
+// GetSubscriptions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chain domain.ChainID
+func (_e *ParserV2_Expecter) GetSubscriptions(ctx interface{}, chain interface{}) *ParserV2_GetSubscriptions_Call {
+	return &ParserV2_GetSubscriptions_Call{Call: _e.mock.On("GetSubscriptions", ctx, chain)}
+}
+
+func (_c *ParserV2_GetSubscriptions_Call) Run(run func(ctx context.Context, chain domain.ChainID)) *ParserV2_GetSubscriptions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.ChainID))
+	})
+	return _c
+}
+
+func (_c *ParserV2_GetSubscriptions_Call) Return(_a0 []domain.Subscription, _a1 error) *ParserV2_GetSubscriptions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ParserV2_GetSubscriptions_Call) RunAndReturn(run func(context.Context, domain.ChainID) ([]domain.Subscription, error)) *ParserV2_GetSubscriptions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTransactions provides a mock function with given fields: ctx, chain, address
+func (_m *ParserV2) GetTransactions(ctx context.Context, chain domain.ChainID, address string) ([]domain.Transaction, error) {
+	ret := _m.Called(ctx, chain, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactions")
+	}
+
+	var r0 []domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChainID, string) ([]domain.Transaction, error)); ok {
+		return rf(ctx, chain, address)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChainID, string) []domain.Transaction); ok {
+		r0 = rf(ctx, chain, address)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ChainID, string) error); ok {
+		r1 = rf(ctx, chain, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ParserV2_GetTransactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransactions'
+type ParserV2_GetTransactions_Call struct {
+	*mock.Call
+}
+
+// GetTransactions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chain domain.ChainID
+//   - address string
+func (_e *ParserV2_Expecter) GetTransactions(ctx interface{}, chain interface{}, address interface{}) *ParserV2_GetTransactions_Call {
+	return &ParserV2_GetTransactions_Call{Call: _e.mock.On("GetTransactions", ctx, chain, address)}
+}
+
+func (_c *ParserV2_GetTransactions_Call) Run(run func(ctx context.Context, chain domain.ChainID, address string)) *ParserV2_GetTransactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.ChainID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ParserV2_GetTransactions_Call) Return(_a0 []domain.Transaction, _a1 error) *ParserV2_GetTransactions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ParserV2_GetTransactions_Call) RunAndReturn(run func(context.Context, domain.ChainID, string) ([]domain.Transaction, error)) *ParserV2_GetTransactions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTransactionsByHash provides a mock function with given fields: ctx, chain, hash
+func (_m *ParserV2) GetTransactionsByHash(ctx context.Context, chain domain.ChainID, hash string) ([]domain.Transaction, error) {
+	ret := _m.Called(ctx, chain, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionsByHash")
+	}
+
+	var r0 []domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChainID, string) ([]domain.Transaction, error)); ok {
+		return rf(ctx, chain, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChainID, string) []domain.Transaction); ok {
+		r0 = rf(ctx, chain, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ChainID, string) error); ok {
+		r1 = rf(ctx, chain, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ParserV2_GetTransactionsByHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransactionsByHash'
+type ParserV2_GetTransactionsByHash_Call struct {
+	*mock.Call
+}
+
+// GetTransactionsByHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chain domain.ChainID
+//   - hash string
+func (_e *ParserV2_Expecter) GetTransactionsByHash(ctx interface{}, chain interface{}, hash interface{}) *ParserV2_GetTransactionsByHash_Call {
+	return &ParserV2_GetTransactionsByHash_Call{Call: _e.mock.On("GetTransactionsByHash", ctx, chain, hash)}
+}
+
+func (_c *ParserV2_GetTransactionsByHash_Call) Run(run func(ctx context.Context, chain domain.ChainID, hash string)) *ParserV2_GetTransactionsByHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.ChainID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ParserV2_GetTransactionsByHash_Call) Return(_a0 []domain.Transaction, _a1 error) *ParserV2_GetTransactionsByHash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ParserV2_GetTransactionsByHash_Call) RunAndReturn(run func(context.Context, domain.ChainID, string) ([]domain.Transaction, error)) *ParserV2_GetTransactionsByHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTransactionsByTime provides a mock function with given fields: ctx, chain, address, from, to
+func (_m *ParserV2) GetTransactionsByTime(ctx context.Context, chain domain.ChainID, address string, from time.Time, to time.Time) ([]domain.Transaction, error) {
+	ret := _m.Called(ctx, chain, address, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionsByTime")
+	}
+
+	var r0 []domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChainID, string, time.Time, time.Time) ([]domain.Transaction, error)); ok {
+		return rf(ctx, chain, address, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChainID, string, time.Time, time.Time) []domain.Transaction); ok {
+		r0 = rf(ctx, chain, address, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ChainID, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, chain, address, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ParserV2_GetTransactionsByTime_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransactionsByTime'
+type ParserV2_GetTransactionsByTime_Call struct {
+	*mock.Call
+}
+
+// GetTransactionsByTime is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chain domain.ChainID
+//   - address string
+//   - from time.Time
+//   - to time.Time
+func (_e *ParserV2_Expecter) GetTransactionsByTime(ctx interface{}, chain interface{}, address interface{}, from interface{}, to interface{}) *ParserV2_GetTransactionsByTime_Call {
+	return &ParserV2_GetTransactionsByTime_Call{Call: _e.mock.On("GetTransactionsByTime", ctx, chain, address, from, to)}
+}
+
+func (_c *ParserV2_GetTransactionsByTime_Call) Run(run func(ctx context.Context, chain domain.ChainID, address string, from time.Time, to time.Time)) *ParserV2_GetTransactionsByTime_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.ChainID), args[2].(string), args[3].(time.Time), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *ParserV2_GetTransactionsByTime_Call) Return(_a0 []domain.Transaction, _a1 error) *ParserV2_GetTransactionsByTime_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ParserV2_GetTransactionsByTime_Call) RunAndReturn(run func(context.Context, domain.ChainID, string, time.Time, time.Time) ([]domain.Transaction, error)) *ParserV2_GetTransactionsByTime_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PauseSubscription provides a mock function with given fields: ctx, chain, address
+func (_m *ParserV2) PauseSubscription(ctx context.Context, chain domain.ChainID, address string) error {
+	ret := _m.Called(ctx, chain, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PauseSubscription")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChainID, string) error); ok {
+		r0 = rf(ctx, chain, address)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ParserV2_PauseSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PauseSubscription'
+type ParserV2_PauseSubscription_Call struct {
+	*mock.Call
+}
+
+// PauseSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chain domain.ChainID
+//   - address string
+func (_e *ParserV2_Expecter) PauseSubscription(ctx interface{}, chain interface{}, address interface{}) *ParserV2_PauseSubscription_Call {
+	return &ParserV2_PauseSubscription_Call{Call: _e.mock.On("PauseSubscription", ctx, chain, address)}
+}
+
+func (_c *ParserV2_PauseSubscription_Call) Run(run func(ctx context.Context, chain domain.ChainID, address string)) *ParserV2_PauseSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.ChainID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ParserV2_PauseSubscription_Call) Return(_a0 error) *ParserV2_PauseSubscription_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ParserV2_PauseSubscription_Call) RunAndReturn(run func(context.Context, domain.ChainID, string) error) *ParserV2_PauseSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// QueryTransactions provides a mock function with given fields: ctx, chain, query
+func (_m *ParserV2) QueryTransactions(ctx context.Context, chain domain.ChainID, query domain.TransactionQuery) (domain.TransactionPage, error) {
+	ret := _m.Called(ctx, chain, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueryTransactions")
+	}
+
+	var r0 domain.TransactionPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChainID, domain.TransactionQuery) (domain.TransactionPage, error)); ok {
+		return rf(ctx, chain, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChainID, domain.TransactionQuery) domain.TransactionPage); ok {
+		r0 = rf(ctx, chain, query)
+	} else {
+		r0 = ret.Get(0).(domain.TransactionPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ChainID, domain.TransactionQuery) error); ok {
+		r1 = rf(ctx, chain, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ParserV2_QueryTransactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryTransactions'
+type ParserV2_QueryTransactions_Call struct {
+	*mock.Call
+}
+
+// QueryTransactions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chain domain.ChainID
+//   - query domain.TransactionQuery
+func (_e *ParserV2_Expecter) QueryTransactions(ctx interface{}, chain interface{}, query interface{}) *ParserV2_QueryTransactions_Call {
+	return &ParserV2_QueryTransactions_Call{Call: _e.mock.On("QueryTransactions", ctx, chain, query)}
+}
+
+func (_c *ParserV2_QueryTransactions_Call) Run(run func(ctx context.Context, chain domain.ChainID, query domain.TransactionQuery)) *ParserV2_QueryTransactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.ChainID), args[2].(domain.TransactionQuery))
+	})
+	return _c
+}
+
+func (_c *ParserV2_QueryTransactions_Call) Return(_a0 domain.TransactionPage, _a1 error) *ParserV2_QueryTransactions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ParserV2_QueryTransactions_Call) RunAndReturn(run func(context.Context, domain.ChainID, domain.TransactionQuery) (domain.TransactionPage, error)) *ParserV2_QueryTransactions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RescanSubscription provides a mock function with given fields: ctx, chain, address, fromBlock
+func (_m *ParserV2) RescanSubscription(ctx context.Context, chain domain.ChainID, address string, fromBlock uint64) error {
+	ret := _m.Called(ctx, chain, address, fromBlock)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RescanSubscription")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChainID, string, uint64) error); ok {
+		r0 = rf(ctx, chain, address, fromBlock)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ParserV2_RescanSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RescanSubscription'
+type ParserV2_RescanSubscription_Call struct {
+	*mock.Call
+}
+
+// RescanSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chain domain.ChainID
+//   - address string
+//   - fromBlock uint64
+func (_e *ParserV2_Expecter) RescanSubscription(ctx interface{}, chain interface{}, address interface{}, fromBlock interface{}) *ParserV2_RescanSubscription_Call {
+	return &ParserV2_RescanSubscription_Call{Call: _e.mock.On("RescanSubscription", ctx, chain, address, fromBlock)}
+}
+
+func (_c *ParserV2_RescanSubscription_Call) Run(run func(ctx context.Context, chain domain.ChainID, address string, fromBlock uint64)) *ParserV2_RescanSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.ChainID), args[2].(string), args[3].(uint64))
+	})
+	return _c
+}
+
+func (_c *ParserV2_RescanSubscription_Call) Return(_a0 error) *ParserV2_RescanSubscription_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ParserV2_RescanSubscription_Call) RunAndReturn(run func(context.Context, domain.ChainID, string, uint64) error) *ParserV2_RescanSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResumeSubscription provides a mock function with given fields: ctx, chain, address
+func (_m *ParserV2) ResumeSubscription(ctx context.Context, chain domain.ChainID, address string) error {
+	ret := _m.Called(ctx, chain, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResumeSubscription")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChainID, string) error); ok {
+		r0 = rf(ctx, chain, address)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ParserV2_ResumeSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResumeSubscription'
+type ParserV2_ResumeSubscription_Call struct {
+	*mock.Call
+}
+
+// ResumeSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chain domain.ChainID
+//   - address string
+func (_e *ParserV2_Expecter) ResumeSubscription(ctx interface{}, chain interface{}, address interface{}) *ParserV2_ResumeSubscription_Call {
+	return &ParserV2_ResumeSubscription_Call{Call: _e.mock.On("ResumeSubscription", ctx, chain, address)}
+}
+
+func (_c *ParserV2_ResumeSubscription_Call) Run(run func(ctx context.Context, chain domain.ChainID, address string)) *ParserV2_ResumeSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.ChainID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ParserV2_ResumeSubscription_Call) Return(_a0 error) *ParserV2_ResumeSubscription_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ParserV2_ResumeSubscription_Call) RunAndReturn(run func(context.Context, domain.ChainID, string) error) *ParserV2_ResumeSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Subscribe provides a mock function with given fields: ctx, chain, address, opts
+func (_m *ParserV2) Subscribe(ctx context.Context, chain domain.ChainID, address string, opts ...domain.SubscribeOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, chain, address)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Subscribe")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChainID, string, ...domain.SubscribeOption) error); ok {
+		r0 = rf(ctx, chain, address, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ParserV2_Subscribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Subscribe'
+type ParserV2_Subscribe_Call struct {
+	*mock.Call
+}
+
+// Subscribe is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chain domain.ChainID
+//   - address string
+//   - opts ...domain.SubscribeOption
+func (_e *ParserV2_Expecter) Subscribe(ctx interface{}, chain interface{}, address interface{}, opts ...interface{}) *ParserV2_Subscribe_Call {
+	return &ParserV2_Subscribe_Call{Call: _e.mock.On("Subscribe",
+		append([]interface{}{ctx, chain, address}, opts...)...)}
+}
+
+func (_c *ParserV2_Subscribe_Call) Run(run func(ctx context.Context, chain domain.ChainID, address string, opts ...domain.SubscribeOption)) *ParserV2_Subscribe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]domain.SubscribeOption, len(args)-3)
+		for i, a := range args[3:] {
+			if a != nil {
+				variadicArgs[i] = a.(domain.SubscribeOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(domain.ChainID), args[2].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *ParserV2_Subscribe_Call) Return(_a0 error) *ParserV2_Subscribe_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ParserV2_Subscribe_Call) RunAndReturn(run func(context.Context, domain.ChainID, string, ...domain.SubscribeOption) error) *ParserV2_Subscribe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// VerifySubscription provides a mock function with given fields: ctx, chain, address, fromBlock, toBlock, repair
+func (_m *ParserV2) VerifySubscription(ctx context.Context, chain domain.ChainID, address string, fromBlock uint64, toBlock uint64, repair bool) (domain.IntegrityReport, error) {
+	ret := _m.Called(ctx, chain, address, fromBlock, toBlock, repair)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifySubscription")
+	}
+
+	var r0 domain.IntegrityReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChainID, string, uint64, uint64, bool) (domain.IntegrityReport, error)); ok {
+		return rf(ctx, chain, address, fromBlock, toBlock, repair)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChainID, string, uint64, uint64, bool) domain.IntegrityReport); ok {
+		r0 = rf(ctx, chain, address, fromBlock, toBlock, repair)
+	} else {
+		r0 = ret.Get(0).(domain.IntegrityReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ChainID, string, uint64, uint64, bool) error); ok {
+		r1 = rf(ctx, chain, address, fromBlock, toBlock, repair)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ParserV2_VerifySubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifySubscription'
+type ParserV2_VerifySubscription_Call struct {
+	*mock.Call
+}
+
+// VerifySubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chain domain.ChainID
+//   - address string
+//   - fromBlock uint64
+//   - toBlock uint64
+//   - repair bool
+func (_e *ParserV2_Expecter) VerifySubscription(ctx interface{}, chain interface{}, address interface{}, fromBlock interface{}, toBlock interface{}, repair interface{}) *ParserV2_VerifySubscription_Call {
+	return &ParserV2_VerifySubscription_Call{Call: _e.mock.On("VerifySubscription", ctx, chain, address, fromBlock, toBlock, repair)}
+}
+
+func (_c *ParserV2_VerifySubscription_Call) Run(run func(ctx context.Context, chain domain.ChainID, address string, fromBlock uint64, toBlock uint64, repair bool)) *ParserV2_VerifySubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.ChainID), args[2].(string), args[3].(uint64), args[4].(uint64), args[5].(bool))
+	})
+	return _c
+}
+
+func (_c *ParserV2_VerifySubscription_Call) Return(_a0 domain.IntegrityReport, _a1 error) *ParserV2_VerifySubscription_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ParserV2_VerifySubscription_Call) RunAndReturn(run func(context.Context, domain.ChainID, string, uint64, uint64, bool) (domain.IntegrityReport, error)) *ParserV2_VerifySubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Watch provides a mock function with given fields: ctx, filter, opts
+func (_m *ParserV2) Watch(ctx context.Context, filter domain.EventFilter, opts ...domain.WatchOption) (<-chan domain.Event, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, filter)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Watch")
+	}
+
+	var r0 <-chan domain.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.EventFilter, ...domain.WatchOption) (<-chan domain.Event, error)); ok {
+		return rf(ctx, filter, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.EventFilter, ...domain.WatchOption) <-chan domain.Event); ok {
+		r0 = rf(ctx, filter, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan domain.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.EventFilter, ...domain.WatchOption) error); ok {
+		r1 = rf(ctx, filter, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ParserV2_Watch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Watch'
+type ParserV2_Watch_Call struct {
+	*mock.Call
+}
+
+// Watch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.EventFilter
+//   - opts ...domain.WatchOption
+func (_e *ParserV2_Expecter) Watch(ctx interface{}, filter interface{}, opts ...interface{}) *ParserV2_Watch_Call {
+	return &ParserV2_Watch_Call{Call: _e.mock.On("Watch",
+		append([]interface{}{ctx, filter}, opts...)...)}
+}
+
+func (_c *ParserV2_Watch_Call) Run(run func(ctx context.Context, filter domain.EventFilter, opts ...domain.WatchOption)) *ParserV2_Watch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]domain.WatchOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(domain.WatchOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(domain.EventFilter), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *ParserV2_Watch_Call) Return(_a0 <-chan domain.Event, _a1 error) *ParserV2_Watch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ParserV2_Watch_Call) RunAndReturn(run func(context.Context, domain.EventFilter, ...domain.WatchOption) (<-chan domain.Event, error)) *ParserV2_Watch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewParserV2 creates a new instance of ParserV2. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewParserV2(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ParserV2 {
+	mock := &ParserV2{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,316 @@
+// Code generated by mockery v2.39.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PendingAPI is an autogenerated mock type for the PendingAPI type
+type PendingAPI struct {
+	mock.Mock
+}
+
+type PendingAPI_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PendingAPI) EXPECT() *PendingAPI_Expecter {
+	return &PendingAPI_Expecter{mock: &_m.Mock}
+}
+
+// FetchPendingTransactionHashes provides a mock function with given fields: ctx, filter
+func (_m *PendingAPI) FetchPendingTransactionHashes(ctx context.Context, filter string) ([]string, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchPendingTransactionHashes")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PendingAPI_FetchPendingTransactionHashes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchPendingTransactionHashes'
+type PendingAPI_FetchPendingTransactionHashes_Call struct {
+	*mock.Call
+}
+
+// FetchPendingTransactionHashes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter string
+func (_e *PendingAPI_Expecter) FetchPendingTransactionHashes(ctx interface{}, filter interface{}) *PendingAPI_FetchPendingTransactionHashes_Call {
+	return &PendingAPI_FetchPendingTransactionHashes_Call{Call: _e.mock.On("FetchPendingTransactionHashes", ctx, filter)}
+}
+
+func (_c *PendingAPI_FetchPendingTransactionHashes_Call) Run(run func(ctx context.Context, filter string)) *PendingAPI_FetchPendingTransactionHashes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *PendingAPI_FetchPendingTransactionHashes_Call) Return(_a0 []string, _a1 error) *PendingAPI_FetchPendingTransactionHashes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PendingAPI_FetchPendingTransactionHashes_Call) RunAndReturn(run func(context.Context, string) ([]string, error)) *PendingAPI_FetchPendingTransactionHashes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTransactionCount provides a mock function with given fields: ctx, address
+func (_m *PendingAPI) GetTransactionCount(ctx context.Context, address domain.Address) (int64, error) {
+	ret := _m.Called(ctx, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionCount")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address) (int64, error)); ok {
+		return rf(ctx, address)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address) int64); ok {
+		r0 = rf(ctx, address)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Address) error); ok {
+		r1 = rf(ctx, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PendingAPI_GetTransactionCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransactionCount'
+type PendingAPI_GetTransactionCount_Call struct {
+	*mock.Call
+}
+
+// GetTransactionCount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - address domain.Address
+func (_e *PendingAPI_Expecter) GetTransactionCount(ctx interface{}, address interface{}) *PendingAPI_GetTransactionCount_Call {
+	return &PendingAPI_GetTransactionCount_Call{Call: _e.mock.On("GetTransactionCount", ctx, address)}
+}
+
+func (_c *PendingAPI_GetTransactionCount_Call) Run(run func(ctx context.Context, address domain.Address)) *PendingAPI_GetTransactionCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Address))
+	})
+	return _c
+}
+
+func (_c *PendingAPI_GetTransactionCount_Call) Return(_a0 int64, _a1 error) *PendingAPI_GetTransactionCount_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PendingAPI_GetTransactionCount_Call) RunAndReturn(run func(context.Context, domain.Address) (int64, error)) *PendingAPI_GetTransactionCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTransactionsByHash provides a mock function with given fields: ctx, hashes
+func (_m *PendingAPI) GetTransactionsByHash(ctx context.Context, hashes []string) (map[string]domain.PendingTransaction, error) {
+	ret := _m.Called(ctx, hashes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionsByHash")
+	}
+
+	var r0 map[string]domain.PendingTransaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (map[string]domain.PendingTransaction, error)); ok {
+		return rf(ctx, hashes)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) map[string]domain.PendingTransaction); ok {
+		r0 = rf(ctx, hashes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]domain.PendingTransaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, hashes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PendingAPI_GetTransactionsByHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransactionsByHash'
+type PendingAPI_GetTransactionsByHash_Call struct {
+	*mock.Call
+}
+
+// GetTransactionsByHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hashes []string
+func (_e *PendingAPI_Expecter) GetTransactionsByHash(ctx interface{}, hashes interface{}) *PendingAPI_GetTransactionsByHash_Call {
+	return &PendingAPI_GetTransactionsByHash_Call{Call: _e.mock.On("GetTransactionsByHash", ctx, hashes)}
+}
+
+func (_c *PendingAPI_GetTransactionsByHash_Call) Run(run func(ctx context.Context, hashes []string)) *PendingAPI_GetTransactionsByHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *PendingAPI_GetTransactionsByHash_Call) Return(_a0 map[string]domain.PendingTransaction, _a1 error) *PendingAPI_GetTransactionsByHash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PendingAPI_GetTransactionsByHash_Call) RunAndReturn(run func(context.Context, []string) (map[string]domain.PendingTransaction, error)) *PendingAPI_GetTransactionsByHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPendingTransactionFilter provides a mock function with given fields: ctx
+func (_m *PendingAPI) NewPendingTransactionFilter(ctx context.Context) (string, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NewPendingTransactionFilter")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (string, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) string); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PendingAPI_NewPendingTransactionFilter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NewPendingTransactionFilter'
+type PendingAPI_NewPendingTransactionFilter_Call struct {
+	*mock.Call
+}
+
+// NewPendingTransactionFilter is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *PendingAPI_Expecter) NewPendingTransactionFilter(ctx interface{}) *PendingAPI_NewPendingTransactionFilter_Call {
+	return &PendingAPI_NewPendingTransactionFilter_Call{Call: _e.mock.On("NewPendingTransactionFilter", ctx)}
+}
+
+func (_c *PendingAPI_NewPendingTransactionFilter_Call) Run(run func(ctx context.Context)) *PendingAPI_NewPendingTransactionFilter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *PendingAPI_NewPendingTransactionFilter_Call) Return(_a0 string, _a1 error) *PendingAPI_NewPendingTransactionFilter_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PendingAPI_NewPendingTransactionFilter_Call) RunAndReturn(run func(context.Context) (string, error)) *PendingAPI_NewPendingTransactionFilter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveFilter provides a mock function with given fields: ctx, filter
+func (_m *PendingAPI) RemoveFilter(ctx context.Context, filter string) error {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveFilter")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PendingAPI_RemoveFilter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveFilter'
+type PendingAPI_RemoveFilter_Call struct {
+	*mock.Call
+}
+
+// RemoveFilter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter string
+func (_e *PendingAPI_Expecter) RemoveFilter(ctx interface{}, filter interface{}) *PendingAPI_RemoveFilter_Call {
+	return &PendingAPI_RemoveFilter_Call{Call: _e.mock.On("RemoveFilter", ctx, filter)}
+}
+
+func (_c *PendingAPI_RemoveFilter_Call) Run(run func(ctx context.Context, filter string)) *PendingAPI_RemoveFilter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *PendingAPI_RemoveFilter_Call) Return(_a0 error) *PendingAPI_RemoveFilter_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PendingAPI_RemoveFilter_Call) RunAndReturn(run func(context.Context, string) error) *PendingAPI_RemoveFilter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPendingAPI creates a new instance of PendingAPI. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPendingAPI(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PendingAPI {
+	mock := &PendingAPI{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,85 @@
+// Code generated by mockery v2.39.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PendingRepositoryWriter is an autogenerated mock type for the PendingRepositoryWriter type
+type PendingRepositoryWriter struct {
+	mock.Mock
+}
+
+type PendingRepositoryWriter_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PendingRepositoryWriter) EXPECT() *PendingRepositoryWriter_Expecter {
+	return &PendingRepositoryWriter_Expecter{mock: &_m.Mock}
+}
+
+// SavePendingTransactions provides a mock function with given fields: ctx, transactions
+func (_m *PendingRepositoryWriter) SavePendingTransactions(ctx context.Context, transactions []domain.PendingTransaction) error {
+	ret := _m.Called(ctx, transactions)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SavePendingTransactions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.PendingTransaction) error); ok {
+		r0 = rf(ctx, transactions)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PendingRepositoryWriter_SavePendingTransactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SavePendingTransactions'
+type PendingRepositoryWriter_SavePendingTransactions_Call struct {
+	*mock.Call
+}
+
+// SavePendingTransactions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - transactions []domain.PendingTransaction
+func (_e *PendingRepositoryWriter_Expecter) SavePendingTransactions(ctx interface{}, transactions interface{}) *PendingRepositoryWriter_SavePendingTransactions_Call {
+	return &PendingRepositoryWriter_SavePendingTransactions_Call{Call: _e.mock.On("SavePendingTransactions", ctx, transactions)}
+}
+
+func (_c *PendingRepositoryWriter_SavePendingTransactions_Call) Run(run func(ctx context.Context, transactions []domain.PendingTransaction)) *PendingRepositoryWriter_SavePendingTransactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]domain.PendingTransaction))
+	})
+	return _c
+}
+
+func (_c *PendingRepositoryWriter_SavePendingTransactions_Call) Return(_a0 error) *PendingRepositoryWriter_SavePendingTransactions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PendingRepositoryWriter_SavePendingTransactions_Call) RunAndReturn(run func(context.Context, []domain.PendingTransaction) error) *PendingRepositoryWriter_SavePendingTransactions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPendingRepositoryWriter creates a new instance of PendingRepositoryWriter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPendingRepositoryWriter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PendingRepositoryWriter {
+	mock := &PendingRepositoryWriter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
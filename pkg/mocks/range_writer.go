@@ -0,0 +1,87 @@
+// Code generated by mockery v2.39.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	domain "github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+// RangeWriter is an autogenerated mock type for the RangeWriter type
+type RangeWriter struct {
+	mock.Mock
+}
+
+type RangeWriter_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RangeWriter) EXPECT() *RangeWriter_Expecter {
+	return &RangeWriter_Expecter{mock: &_m.Mock}
+}
+
+// ReplaceBlockRange provides a mock function with given fields: ctx, address, fromBlock, toBlock, transactions
+func (_m *RangeWriter) ReplaceBlockRange(ctx context.Context, address domain.Address, fromBlock uint64, toBlock uint64, transactions []domain.Transaction) error {
+	ret := _m.Called(ctx, address, fromBlock, toBlock, transactions)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceBlockRange")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address, uint64, uint64, []domain.Transaction) error); ok {
+		r0 = rf(ctx, address, fromBlock, toBlock, transactions)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RangeWriter_ReplaceBlockRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceBlockRange'
+type RangeWriter_ReplaceBlockRange_Call struct {
+	*mock.Call
+}
+
+// ReplaceBlockRange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - address domain.Address
+//   - fromBlock uint64
+//   - toBlock uint64
+//   - transactions []domain.Transaction
+func (_e *RangeWriter_Expecter) ReplaceBlockRange(ctx interface{}, address interface{}, fromBlock interface{}, toBlock interface{}, transactions interface{}) *RangeWriter_ReplaceBlockRange_Call {
+	return &RangeWriter_ReplaceBlockRange_Call{Call: _e.mock.On("ReplaceBlockRange", ctx, address, fromBlock, toBlock, transactions)}
+}
+
+func (_c *RangeWriter_ReplaceBlockRange_Call) Run(run func(ctx context.Context, address domain.Address, fromBlock uint64, toBlock uint64, transactions []domain.Transaction)) *RangeWriter_ReplaceBlockRange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Address), args[2].(uint64), args[3].(uint64), args[4].([]domain.Transaction))
+	})
+	return _c
+}
+
+func (_c *RangeWriter_ReplaceBlockRange_Call) Return(_a0 error) *RangeWriter_ReplaceBlockRange_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RangeWriter_ReplaceBlockRange_Call) RunAndReturn(run func(context.Context, domain.Address, uint64, uint64, []domain.Transaction) error) *RangeWriter_ReplaceBlockRange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRangeWriter creates a new instance of RangeWriter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRangeWriter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RangeWriter {
+	mock := &RangeWriter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
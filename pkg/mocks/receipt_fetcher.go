@@ -0,0 +1,97 @@
+// Code generated by mockery v2.39.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ReceiptFetcher is an autogenerated mock type for the ReceiptFetcher type
+type ReceiptFetcher struct {
+	mock.Mock
+}
+
+type ReceiptFetcher_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ReceiptFetcher) EXPECT() *ReceiptFetcher_Expecter {
+	return &ReceiptFetcher_Expecter{mock: &_m.Mock}
+}
+
+// GetTransactionReceipts provides a mock function with given fields: ctx, transactions
+func (_m *ReceiptFetcher) GetTransactionReceipts(ctx context.Context, transactions []domain.Transaction) (map[string]domain.Receipt, error) {
+	ret := _m.Called(ctx, transactions)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionReceipts")
+	}
+
+	var r0 map[string]domain.Receipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.Transaction) (map[string]domain.Receipt, error)); ok {
+		return rf(ctx, transactions)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.Transaction) map[string]domain.Receipt); ok {
+		r0 = rf(ctx, transactions)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]domain.Receipt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []domain.Transaction) error); ok {
+		r1 = rf(ctx, transactions)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReceiptFetcher_GetTransactionReceipts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransactionReceipts'
+type ReceiptFetcher_GetTransactionReceipts_Call struct {
+	*mock.Call
+}
+
+// GetTransactionReceipts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - transactions []domain.Transaction
+func (_e *ReceiptFetcher_Expecter) GetTransactionReceipts(ctx interface{}, transactions interface{}) *ReceiptFetcher_GetTransactionReceipts_Call {
+	return &ReceiptFetcher_GetTransactionReceipts_Call{Call: _e.mock.On("GetTransactionReceipts", ctx, transactions)}
+}
+
+func (_c *ReceiptFetcher_GetTransactionReceipts_Call) Run(run func(ctx context.Context, transactions []domain.Transaction)) *ReceiptFetcher_GetTransactionReceipts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]domain.Transaction))
+	})
+	return _c
+}
+
+func (_c *ReceiptFetcher_GetTransactionReceipts_Call) Return(_a0 map[string]domain.Receipt, _a1 error) *ReceiptFetcher_GetTransactionReceipts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ReceiptFetcher_GetTransactionReceipts_Call) RunAndReturn(run func(context.Context, []domain.Transaction) (map[string]domain.Receipt, error)) *ReceiptFetcher_GetTransactionReceipts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewReceiptFetcher creates a new instance of ReceiptFetcher. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReceiptFetcher(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReceiptFetcher {
+	mock := &ReceiptFetcher{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
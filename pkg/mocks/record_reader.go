@@ -0,0 +1,98 @@
+// Code generated by mockery v2.39.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	domain "github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+// RecordReader is an autogenerated mock type for the RecordReader type
+type RecordReader struct {
+	mock.Mock
+}
+
+type RecordReader_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RecordReader) EXPECT() *RecordReader_Expecter {
+	return &RecordReader_Expecter{mock: &_m.Mock}
+}
+
+// GetTransactionsByBlockRange provides a mock function with given fields: ctx, address, fromBlock, toBlock
+func (_m *RecordReader) GetTransactionsByBlockRange(ctx context.Context, address domain.Address, fromBlock uint64, toBlock uint64) ([]domain.Transaction, error) {
+	ret := _m.Called(ctx, address, fromBlock, toBlock)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionsByBlockRange")
+	}
+
+	var r0 []domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address, uint64, uint64) ([]domain.Transaction, error)); ok {
+		return rf(ctx, address, fromBlock, toBlock)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address, uint64, uint64) []domain.Transaction); ok {
+		r0 = rf(ctx, address, fromBlock, toBlock)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Address, uint64, uint64) error); ok {
+		r1 = rf(ctx, address, fromBlock, toBlock)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordReader_GetTransactionsByBlockRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransactionsByBlockRange'
+type RecordReader_GetTransactionsByBlockRange_Call struct {
+	*mock.Call
+}
+
+// GetTransactionsByBlockRange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - address domain.Address
+//   - fromBlock uint64
+//   - toBlock uint64
+func (_e *RecordReader_Expecter) GetTransactionsByBlockRange(ctx interface{}, address interface{}, fromBlock interface{}, toBlock interface{}) *RecordReader_GetTransactionsByBlockRange_Call {
+	return &RecordReader_GetTransactionsByBlockRange_Call{Call: _e.mock.On("GetTransactionsByBlockRange", ctx, address, fromBlock, toBlock)}
+}
+
+func (_c *RecordReader_GetTransactionsByBlockRange_Call) Run(run func(ctx context.Context, address domain.Address, fromBlock uint64, toBlock uint64)) *RecordReader_GetTransactionsByBlockRange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Address), args[2].(uint64), args[3].(uint64))
+	})
+	return _c
+}
+
+func (_c *RecordReader_GetTransactionsByBlockRange_Call) Return(_a0 []domain.Transaction, _a1 error) *RecordReader_GetTransactionsByBlockRange_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RecordReader_GetTransactionsByBlockRange_Call) RunAndReturn(run func(context.Context, domain.Address, uint64, uint64) ([]domain.Transaction, error)) *RecordReader_GetTransactionsByBlockRange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRecordReader creates a new instance of RecordReader. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRecordReader(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RecordReader {
+	mock := &RecordReader{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,86 @@
+// Code generated by mockery v2.39.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	domain "github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+// Repairer is an autogenerated mock type for the Repairer type
+type Repairer struct {
+	mock.Mock
+}
+
+type Repairer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Repairer) EXPECT() *Repairer_Expecter {
+	return &Repairer_Expecter{mock: &_m.Mock}
+}
+
+// Reindex provides a mock function with given fields: ctx, address, fromBlock, toBlock
+func (_m *Repairer) Reindex(ctx context.Context, address domain.Address, fromBlock uint64, toBlock uint64) error {
+	ret := _m.Called(ctx, address, fromBlock, toBlock)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reindex")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address, uint64, uint64) error); ok {
+		r0 = rf(ctx, address, fromBlock, toBlock)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repairer_Reindex_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reindex'
+type Repairer_Reindex_Call struct {
+	*mock.Call
+}
+
+// Reindex is a helper method to define mock.On call
+//   - ctx context.Context
+//   - address domain.Address
+//   - fromBlock uint64
+//   - toBlock uint64
+func (_e *Repairer_Expecter) Reindex(ctx interface{}, address interface{}, fromBlock interface{}, toBlock interface{}) *Repairer_Reindex_Call {
+	return &Repairer_Reindex_Call{Call: _e.mock.On("Reindex", ctx, address, fromBlock, toBlock)}
+}
+
+func (_c *Repairer_Reindex_Call) Run(run func(ctx context.Context, address domain.Address, fromBlock uint64, toBlock uint64)) *Repairer_Reindex_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Address), args[2].(uint64), args[3].(uint64))
+	})
+	return _c
+}
+
+func (_c *Repairer_Reindex_Call) Return(_a0 error) *Repairer_Reindex_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repairer_Reindex_Call) RunAndReturn(run func(context.Context, domain.Address, uint64, uint64) error) *Repairer_Reindex_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRepairer creates a new instance of Repairer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRepairer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Repairer {
+	mock := &Repairer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
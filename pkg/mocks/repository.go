@@ -0,0 +1,363 @@
+// Code generated by mockery v2.39.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	domain "github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+type Repository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Repository) EXPECT() *Repository_Expecter {
+	return &Repository_Expecter{mock: &_m.Mock}
+}
+
+// GetDeadDeliveries provides a mock function with given fields: ctx
+func (_m *Repository) GetDeadDeliveries(ctx context.Context) ([]domain.WebhookDelivery, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeadDeliveries")
+	}
+
+	var r0 []domain.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.WebhookDelivery, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.WebhookDelivery); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_GetDeadDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeadDeliveries'
+type Repository_GetDeadDeliveries_Call struct {
+	*mock.Call
+}
+
+// GetDeadDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Repository_Expecter) GetDeadDeliveries(ctx interface{}) *Repository_GetDeadDeliveries_Call {
+	return &Repository_GetDeadDeliveries_Call{Call: _e.mock.On("GetDeadDeliveries", ctx)}
+}
+
+func (_c *Repository_GetDeadDeliveries_Call) Run(run func(ctx context.Context)) *Repository_GetDeadDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Repository_GetDeadDeliveries_Call) Return(_a0 []domain.WebhookDelivery, _a1 error) *Repository_GetDeadDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_GetDeadDeliveries_Call) RunAndReturn(run func(context.Context) ([]domain.WebhookDelivery, error)) *Repository_GetDeadDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDeliveries provides a mock function with given fields: ctx, address
+func (_m *Repository) GetDeliveries(ctx context.Context, address domain.Address) ([]domain.WebhookDelivery, error) {
+	ret := _m.Called(ctx, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeliveries")
+	}
+
+	var r0 []domain.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address) ([]domain.WebhookDelivery, error)); ok {
+		return rf(ctx, address)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address) []domain.WebhookDelivery); ok {
+		r0 = rf(ctx, address)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Address) error); ok {
+		r1 = rf(ctx, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_GetDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeliveries'
+type Repository_GetDeliveries_Call struct {
+	*mock.Call
+}
+
+// GetDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - address domain.Address
+func (_e *Repository_Expecter) GetDeliveries(ctx interface{}, address interface{}) *Repository_GetDeliveries_Call {
+	return &Repository_GetDeliveries_Call{Call: _e.mock.On("GetDeliveries", ctx, address)}
+}
+
+func (_c *Repository_GetDeliveries_Call) Run(run func(ctx context.Context, address domain.Address)) *Repository_GetDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Address))
+	})
+	return _c
+}
+
+func (_c *Repository_GetDeliveries_Call) Return(_a0 []domain.WebhookDelivery, _a1 error) *Repository_GetDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_GetDeliveries_Call) RunAndReturn(run func(context.Context, domain.Address) ([]domain.WebhookDelivery, error)) *Repository_GetDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDelivery provides a mock function with given fields: ctx, id
+func (_m *Repository) GetDelivery(ctx context.Context, id string) (domain.WebhookDelivery, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDelivery")
+	}
+
+	var r0 domain.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.WebhookDelivery, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.WebhookDelivery); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.WebhookDelivery)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_GetDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDelivery'
+type Repository_GetDelivery_Call struct {
+	*mock.Call
+}
+
+// GetDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *Repository_Expecter) GetDelivery(ctx interface{}, id interface{}) *Repository_GetDelivery_Call {
+	return &Repository_GetDelivery_Call{Call: _e.mock.On("GetDelivery", ctx, id)}
+}
+
+func (_c *Repository_GetDelivery_Call) Run(run func(ctx context.Context, id string)) *Repository_GetDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_GetDelivery_Call) Return(_a0 domain.WebhookDelivery, _a1 error) *Repository_GetDelivery_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_GetDelivery_Call) RunAndReturn(run func(context.Context, string) (domain.WebhookDelivery, error)) *Repository_GetDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWebhook provides a mock function with given fields: ctx, chainID, address
+func (_m *Repository) GetWebhook(ctx context.Context, chainID domain.ChainID, address domain.Address) (domain.Webhook, error) {
+	ret := _m.Called(ctx, chainID, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhook")
+	}
+
+	var r0 domain.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChainID, domain.Address) (domain.Webhook, error)); ok {
+		return rf(ctx, chainID, address)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChainID, domain.Address) domain.Webhook); ok {
+		r0 = rf(ctx, chainID, address)
+	} else {
+		r0 = ret.Get(0).(domain.Webhook)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ChainID, domain.Address) error); ok {
+		r1 = rf(ctx, chainID, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_GetWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhook'
+type Repository_GetWebhook_Call struct {
+	*mock.Call
+}
+
+// GetWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chainID domain.ChainID
+//   - address domain.Address
+func (_e *Repository_Expecter) GetWebhook(ctx interface{}, chainID interface{}, address interface{}) *Repository_GetWebhook_Call {
+	return &Repository_GetWebhook_Call{Call: _e.mock.On("GetWebhook", ctx, chainID, address)}
+}
+
+func (_c *Repository_GetWebhook_Call) Run(run func(ctx context.Context, chainID domain.ChainID, address domain.Address)) *Repository_GetWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.ChainID), args[2].(domain.Address))
+	})
+	return _c
+}
+
+func (_c *Repository_GetWebhook_Call) Return(_a0 domain.Webhook, _a1 error) *Repository_GetWebhook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_GetWebhook_Call) RunAndReturn(run func(context.Context, domain.ChainID, domain.Address) (domain.Webhook, error)) *Repository_GetWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveDelivery provides a mock function with given fields: ctx, delivery
+func (_m *Repository) SaveDelivery(ctx context.Context, delivery domain.WebhookDelivery) error {
+	ret := _m.Called(ctx, delivery)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveDelivery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.WebhookDelivery) error); ok {
+		r0 = rf(ctx, delivery)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_SaveDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveDelivery'
+type Repository_SaveDelivery_Call struct {
+	*mock.Call
+}
+
+// SaveDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - delivery domain.WebhookDelivery
+func (_e *Repository_Expecter) SaveDelivery(ctx interface{}, delivery interface{}) *Repository_SaveDelivery_Call {
+	return &Repository_SaveDelivery_Call{Call: _e.mock.On("SaveDelivery", ctx, delivery)}
+}
+
+func (_c *Repository_SaveDelivery_Call) Run(run func(ctx context.Context, delivery domain.WebhookDelivery)) *Repository_SaveDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.WebhookDelivery))
+	})
+	return _c
+}
+
+func (_c *Repository_SaveDelivery_Call) Return(_a0 error) *Repository_SaveDelivery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_SaveDelivery_Call) RunAndReturn(run func(context.Context, domain.WebhookDelivery) error) *Repository_SaveDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveWebhook provides a mock function with given fields: ctx, _a1
+func (_m *Repository) SaveWebhook(ctx context.Context, _a1 domain.Webhook) error {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Webhook) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_SaveWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveWebhook'
+type Repository_SaveWebhook_Call struct {
+	*mock.Call
+}
+
+// SaveWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - _a1 domain.Webhook
+func (_e *Repository_Expecter) SaveWebhook(ctx interface{}, _a1 interface{}) *Repository_SaveWebhook_Call {
+	return &Repository_SaveWebhook_Call{Call: _e.mock.On("SaveWebhook", ctx, _a1)}
+}
+
+func (_c *Repository_SaveWebhook_Call) Run(run func(ctx context.Context, _a1 domain.Webhook)) *Repository_SaveWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Webhook))
+	})
+	return _c
+}
+
+func (_c *Repository_SaveWebhook_Call) Return(_a0 error) *Repository_SaveWebhook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_SaveWebhook_Call) RunAndReturn(run func(context.Context, domain.Webhook) error) *Repository_SaveWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
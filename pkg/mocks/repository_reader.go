@@ -7,6 +7,8 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 	domain "github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+
+	time "time"
 )
 
 // RepositoryReader is an autogenerated mock type for the RepositoryReader type
@@ -22,23 +24,147 @@ func (_m *RepositoryReader) EXPECT() *RepositoryReader_Expecter {
 	return &RepositoryReader_Expecter{mock: &_m.Mock}
 }
 
+// GetBlockRange provides a mock function with given fields: ctx, from, to
+func (_m *RepositoryReader) GetBlockRange(ctx context.Context, from time.Time, to time.Time) (uint64, uint64, error) {
+	ret := _m.Called(ctx, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlockRange")
+	}
+
+	var r0 uint64
+	var r1 uint64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) (uint64, uint64, error)); ok {
+		return rf(ctx, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) uint64); ok {
+		r0 = rf(ctx, from, to)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) uint64); ok {
+		r1 = rf(ctx, from, to)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, time.Time, time.Time) error); ok {
+		r2 = rf(ctx, from, to)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// RepositoryReader_GetBlockRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBlockRange'
+type RepositoryReader_GetBlockRange_Call struct {
+	*mock.Call
+}
+
+// GetBlockRange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - from time.Time
+//   - to time.Time
+func (_e *RepositoryReader_Expecter) GetBlockRange(ctx interface{}, from interface{}, to interface{}) *RepositoryReader_GetBlockRange_Call {
+	return &RepositoryReader_GetBlockRange_Call{Call: _e.mock.On("GetBlockRange", ctx, from, to)}
+}
+
+func (_c *RepositoryReader_GetBlockRange_Call) Run(run func(ctx context.Context, from time.Time, to time.Time)) *RepositoryReader_GetBlockRange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *RepositoryReader_GetBlockRange_Call) Return(_a0 uint64, _a1 uint64, _a2 error) *RepositoryReader_GetBlockRange_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *RepositoryReader_GetBlockRange_Call) RunAndReturn(run func(context.Context, time.Time, time.Time) (uint64, uint64, error)) *RepositoryReader_GetBlockRange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetGaps provides a mock function with given fields: ctx, address
+func (_m *RepositoryReader) GetGaps(ctx context.Context, address domain.Address) ([]domain.Gap, error) {
+	ret := _m.Called(ctx, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGaps")
+	}
+
+	var r0 []domain.Gap
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address) ([]domain.Gap, error)); ok {
+		return rf(ctx, address)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address) []domain.Gap); ok {
+		r0 = rf(ctx, address)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Gap)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Address) error); ok {
+		r1 = rf(ctx, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RepositoryReader_GetGaps_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetGaps'
+type RepositoryReader_GetGaps_Call struct {
+	*mock.Call
+}
+
+// GetGaps is a helper method to define mock.On call
+//   - ctx context.Context
+//   - address domain.Address
+func (_e *RepositoryReader_Expecter) GetGaps(ctx interface{}, address interface{}) *RepositoryReader_GetGaps_Call {
+	return &RepositoryReader_GetGaps_Call{Call: _e.mock.On("GetGaps", ctx, address)}
+}
+
+func (_c *RepositoryReader_GetGaps_Call) Run(run func(ctx context.Context, address domain.Address)) *RepositoryReader_GetGaps_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Address))
+	})
+	return _c
+}
+
+func (_c *RepositoryReader_GetGaps_Call) Return(_a0 []domain.Gap, _a1 error) *RepositoryReader_GetGaps_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RepositoryReader_GetGaps_Call) RunAndReturn(run func(context.Context, domain.Address) ([]domain.Gap, error)) *RepositoryReader_GetGaps_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetLatestBlock provides a mock function with given fields: ctx
-func (_m *RepositoryReader) GetLatestBlock(ctx context.Context) (int64, error) {
+func (_m *RepositoryReader) GetLatestBlock(ctx context.Context) (uint64, error) {
 	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetLatestBlock")
 	}
 
-	var r0 int64
+	var r0 uint64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) (uint64, error)); ok {
 		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) uint64); ok {
 		r0 = rf(ctx)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Get(0).(uint64)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
@@ -68,18 +194,77 @@ func (_c *RepositoryReader_GetLatestBlock_Call) Run(run func(ctx context.Context
 	return _c
 }
 
-func (_c *RepositoryReader_GetLatestBlock_Call) Return(_a0 int64, _a1 error) *RepositoryReader_GetLatestBlock_Call {
+func (_c *RepositoryReader_GetLatestBlock_Call) Return(_a0 uint64, _a1 error) *RepositoryReader_GetLatestBlock_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *RepositoryReader_GetLatestBlock_Call) RunAndReturn(run func(context.Context) (int64, error)) *RepositoryReader_GetLatestBlock_Call {
+func (_c *RepositoryReader_GetLatestBlock_Call) RunAndReturn(run func(context.Context) (uint64, error)) *RepositoryReader_GetLatestBlock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPendingTransactions provides a mock function with given fields: ctx, address
+func (_m *RepositoryReader) GetPendingTransactions(ctx context.Context, address domain.Address) ([]domain.PendingTransaction, error) {
+	ret := _m.Called(ctx, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingTransactions")
+	}
+
+	var r0 []domain.PendingTransaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address) ([]domain.PendingTransaction, error)); ok {
+		return rf(ctx, address)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address) []domain.PendingTransaction); ok {
+		r0 = rf(ctx, address)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PendingTransaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Address) error); ok {
+		r1 = rf(ctx, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RepositoryReader_GetPendingTransactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingTransactions'
+type RepositoryReader_GetPendingTransactions_Call struct {
+	*mock.Call
+}
+
+// GetPendingTransactions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - address domain.Address
+func (_e *RepositoryReader_Expecter) GetPendingTransactions(ctx interface{}, address interface{}) *RepositoryReader_GetPendingTransactions_Call {
+	return &RepositoryReader_GetPendingTransactions_Call{Call: _e.mock.On("GetPendingTransactions", ctx, address)}
+}
+
+func (_c *RepositoryReader_GetPendingTransactions_Call) Run(run func(ctx context.Context, address domain.Address)) *RepositoryReader_GetPendingTransactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Address))
+	})
+	return _c
+}
+
+func (_c *RepositoryReader_GetPendingTransactions_Call) Return(_a0 []domain.PendingTransaction, _a1 error) *RepositoryReader_GetPendingTransactions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RepositoryReader_GetPendingTransactions_Call) RunAndReturn(run func(context.Context, domain.Address) ([]domain.PendingTransaction, error)) *RepositoryReader_GetPendingTransactions_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // GetTransactions provides a mock function with given fields: ctx, address
-func (_m *RepositoryReader) GetTransactions(ctx context.Context, address string) ([]domain.Transaction, error) {
+func (_m *RepositoryReader) GetTransactions(ctx context.Context, address domain.Address) ([]domain.Transaction, error) {
 	ret := _m.Called(ctx, address)
 
 	if len(ret) == 0 {
@@ -88,10 +273,10 @@ func (_m *RepositoryReader) GetTransactions(ctx context.Context, address string)
 
 	var r0 []domain.Transaction
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.Transaction, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address) ([]domain.Transaction, error)); ok {
 		return rf(ctx, address)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.Transaction); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address) []domain.Transaction); ok {
 		r0 = rf(ctx, address)
 	} else {
 		if ret.Get(0) != nil {
@@ -99,7 +284,7 @@ func (_m *RepositoryReader) GetTransactions(ctx context.Context, address string)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Address) error); ok {
 		r1 = rf(ctx, address)
 	} else {
 		r1 = ret.Error(1)
@@ -115,14 +300,14 @@ type RepositoryReader_GetTransactions_Call struct {
 
 // GetTransactions is a helper method to define mock.On call
 //   - ctx context.Context
-//   - address string
+//   - address domain.Address
 func (_e *RepositoryReader_Expecter) GetTransactions(ctx interface{}, address interface{}) *RepositoryReader_GetTransactions_Call {
 	return &RepositoryReader_GetTransactions_Call{Call: _e.mock.On("GetTransactions", ctx, address)}
 }
 
-func (_c *RepositoryReader_GetTransactions_Call) Run(run func(ctx context.Context, address string)) *RepositoryReader_GetTransactions_Call {
+func (_c *RepositoryReader_GetTransactions_Call) Run(run func(ctx context.Context, address domain.Address)) *RepositoryReader_GetTransactions_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(domain.Address))
 	})
 	return _c
 }
@@ -132,7 +317,243 @@ func (_c *RepositoryReader_GetTransactions_Call) Return(_a0 []domain.Transaction
 	return _c
 }
 
-func (_c *RepositoryReader_GetTransactions_Call) RunAndReturn(run func(context.Context, string) ([]domain.Transaction, error)) *RepositoryReader_GetTransactions_Call {
+func (_c *RepositoryReader_GetTransactions_Call) RunAndReturn(run func(context.Context, domain.Address) ([]domain.Transaction, error)) *RepositoryReader_GetTransactions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTransactionsByBlock provides a mock function with given fields: ctx, blockNumber
+func (_m *RepositoryReader) GetTransactionsByBlock(ctx context.Context, blockNumber uint64) ([]domain.Transaction, error) {
+	ret := _m.Called(ctx, blockNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionsByBlock")
+	}
+
+	var r0 []domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) ([]domain.Transaction, error)); ok {
+		return rf(ctx, blockNumber)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) []domain.Transaction); ok {
+		r0 = rf(ctx, blockNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, blockNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RepositoryReader_GetTransactionsByBlock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransactionsByBlock'
+type RepositoryReader_GetTransactionsByBlock_Call struct {
+	*mock.Call
+}
+
+// GetTransactionsByBlock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - blockNumber uint64
+func (_e *RepositoryReader_Expecter) GetTransactionsByBlock(ctx interface{}, blockNumber interface{}) *RepositoryReader_GetTransactionsByBlock_Call {
+	return &RepositoryReader_GetTransactionsByBlock_Call{Call: _e.mock.On("GetTransactionsByBlock", ctx, blockNumber)}
+}
+
+func (_c *RepositoryReader_GetTransactionsByBlock_Call) Run(run func(ctx context.Context, blockNumber uint64)) *RepositoryReader_GetTransactionsByBlock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64))
+	})
+	return _c
+}
+
+func (_c *RepositoryReader_GetTransactionsByBlock_Call) Return(_a0 []domain.Transaction, _a1 error) *RepositoryReader_GetTransactionsByBlock_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RepositoryReader_GetTransactionsByBlock_Call) RunAndReturn(run func(context.Context, uint64) ([]domain.Transaction, error)) *RepositoryReader_GetTransactionsByBlock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTransactionsByBlockRange provides a mock function with given fields: ctx, address, fromBlock, toBlock
+func (_m *RepositoryReader) GetTransactionsByBlockRange(ctx context.Context, address domain.Address, fromBlock uint64, toBlock uint64) ([]domain.Transaction, error) {
+	ret := _m.Called(ctx, address, fromBlock, toBlock)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionsByBlockRange")
+	}
+
+	var r0 []domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address, uint64, uint64) ([]domain.Transaction, error)); ok {
+		return rf(ctx, address, fromBlock, toBlock)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address, uint64, uint64) []domain.Transaction); ok {
+		r0 = rf(ctx, address, fromBlock, toBlock)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Address, uint64, uint64) error); ok {
+		r1 = rf(ctx, address, fromBlock, toBlock)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RepositoryReader_GetTransactionsByBlockRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransactionsByBlockRange'
+type RepositoryReader_GetTransactionsByBlockRange_Call struct {
+	*mock.Call
+}
+
+// GetTransactionsByBlockRange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - address domain.Address
+//   - fromBlock uint64
+//   - toBlock uint64
+func (_e *RepositoryReader_Expecter) GetTransactionsByBlockRange(ctx interface{}, address interface{}, fromBlock interface{}, toBlock interface{}) *RepositoryReader_GetTransactionsByBlockRange_Call {
+	return &RepositoryReader_GetTransactionsByBlockRange_Call{Call: _e.mock.On("GetTransactionsByBlockRange", ctx, address, fromBlock, toBlock)}
+}
+
+func (_c *RepositoryReader_GetTransactionsByBlockRange_Call) Run(run func(ctx context.Context, address domain.Address, fromBlock uint64, toBlock uint64)) *RepositoryReader_GetTransactionsByBlockRange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Address), args[2].(uint64), args[3].(uint64))
+	})
+	return _c
+}
+
+func (_c *RepositoryReader_GetTransactionsByBlockRange_Call) Return(_a0 []domain.Transaction, _a1 error) *RepositoryReader_GetTransactionsByBlockRange_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RepositoryReader_GetTransactionsByBlockRange_Call) RunAndReturn(run func(context.Context, domain.Address, uint64, uint64) ([]domain.Transaction, error)) *RepositoryReader_GetTransactionsByBlockRange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTransactionsByHash provides a mock function with given fields: ctx, hash
+func (_m *RepositoryReader) GetTransactionsByHash(ctx context.Context, hash string) ([]domain.Transaction, error) {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionsByHash")
+	}
+
+	var r0 []domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.Transaction, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.Transaction); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RepositoryReader_GetTransactionsByHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransactionsByHash'
+type RepositoryReader_GetTransactionsByHash_Call struct {
+	*mock.Call
+}
+
+// GetTransactionsByHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hash string
+func (_e *RepositoryReader_Expecter) GetTransactionsByHash(ctx interface{}, hash interface{}) *RepositoryReader_GetTransactionsByHash_Call {
+	return &RepositoryReader_GetTransactionsByHash_Call{Call: _e.mock.On("GetTransactionsByHash", ctx, hash)}
+}
+
+func (_c *RepositoryReader_GetTransactionsByHash_Call) Run(run func(ctx context.Context, hash string)) *RepositoryReader_GetTransactionsByHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *RepositoryReader_GetTransactionsByHash_Call) Return(_a0 []domain.Transaction, _a1 error) *RepositoryReader_GetTransactionsByHash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RepositoryReader_GetTransactionsByHash_Call) RunAndReturn(run func(context.Context, string) ([]domain.Transaction, error)) *RepositoryReader_GetTransactionsByHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// QueryTransactions provides a mock function with given fields: ctx, query
+func (_m *RepositoryReader) QueryTransactions(ctx context.Context, query domain.TransactionQuery) (domain.TransactionPage, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueryTransactions")
+	}
+
+	var r0 domain.TransactionPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TransactionQuery) (domain.TransactionPage, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TransactionQuery) domain.TransactionPage); ok {
+		r0 = rf(ctx, query)
+	} else {
+		r0 = ret.Get(0).(domain.TransactionPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.TransactionQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RepositoryReader_QueryTransactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryTransactions'
+type RepositoryReader_QueryTransactions_Call struct {
+	*mock.Call
+}
+
+// QueryTransactions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query domain.TransactionQuery
+func (_e *RepositoryReader_Expecter) QueryTransactions(ctx interface{}, query interface{}) *RepositoryReader_QueryTransactions_Call {
+	return &RepositoryReader_QueryTransactions_Call{Call: _e.mock.On("QueryTransactions", ctx, query)}
+}
+
+func (_c *RepositoryReader_QueryTransactions_Call) Run(run func(ctx context.Context, query domain.TransactionQuery)) *RepositoryReader_QueryTransactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.TransactionQuery))
+	})
+	return _c
+}
+
+func (_c *RepositoryReader_QueryTransactions_Call) Return(_a0 domain.TransactionPage, _a1 error) *RepositoryReader_QueryTransactions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RepositoryReader_QueryTransactions_Call) RunAndReturn(run func(context.Context, domain.TransactionQuery) (domain.TransactionPage, error)) *RepositoryReader_QueryTransactions_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -24,7 +24,7 @@ func (_m *RepositoryWriter) EXPECT() *RepositoryWriter_Expecter {
 }
 
 // Add provides a mock function with given fields: ctx, address, transactions
-func (_m *RepositoryWriter) Add(ctx context.Context, address string, transactions []domain.Transaction) error {
+func (_m *RepositoryWriter) Add(ctx context.Context, address domain.Address, transactions []domain.Transaction) error {
 	ret := _m.Called(ctx, address, transactions)
 
 	if len(ret) == 0 {
@@ -32,7 +32,7 @@ func (_m *RepositoryWriter) Add(ctx context.Context, address string, transaction
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, []domain.Transaction) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address, []domain.Transaction) error); ok {
 		r0 = rf(ctx, address, transactions)
 	} else {
 		r0 = ret.Error(0)
@@ -48,15 +48,15 @@ type RepositoryWriter_Add_Call struct {
 
 // Add is a helper method to define mock.On call
 //   - ctx context.Context
-//   - address string
+//   - address domain.Address
 //   - transactions []domain.Transaction
 func (_e *RepositoryWriter_Expecter) Add(ctx interface{}, address interface{}, transactions interface{}) *RepositoryWriter_Add_Call {
 	return &RepositoryWriter_Add_Call{Call: _e.mock.On("Add", ctx, address, transactions)}
 }
 
-func (_c *RepositoryWriter_Add_Call) Run(run func(ctx context.Context, address string, transactions []domain.Transaction)) *RepositoryWriter_Add_Call {
+func (_c *RepositoryWriter_Add_Call) Run(run func(ctx context.Context, address domain.Address, transactions []domain.Transaction)) *RepositoryWriter_Add_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].([]domain.Transaction))
+		run(args[0].(context.Context), args[1].(domain.Address), args[2].([]domain.Transaction))
 	})
 	return _c
 }
@@ -66,13 +66,123 @@ func (_c *RepositoryWriter_Add_Call) Return(_a0 error) *RepositoryWriter_Add_Cal
 	return _c
 }
 
-func (_c *RepositoryWriter_Add_Call) RunAndReturn(run func(context.Context, string, []domain.Transaction) error) *RepositoryWriter_Add_Call {
+func (_c *RepositoryWriter_Add_Call) RunAndReturn(run func(context.Context, domain.Address, []domain.Transaction) error) *RepositoryWriter_Add_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Remove provides a mock function with given fields: ctx, address, transactions
+func (_m *RepositoryWriter) Remove(ctx context.Context, address domain.Address, transactions []domain.Transaction) error {
+	ret := _m.Called(ctx, address, transactions)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Remove")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address, []domain.Transaction) error); ok {
+		r0 = rf(ctx, address, transactions)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RepositoryWriter_Remove_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Remove'
+type RepositoryWriter_Remove_Call struct {
+	*mock.Call
+}
+
+// Remove is a helper method to define mock.On call
+//   - ctx context.Context
+//   - address domain.Address
+//   - transactions []domain.Transaction
+func (_e *RepositoryWriter_Expecter) Remove(ctx interface{}, address interface{}, transactions interface{}) *RepositoryWriter_Remove_Call {
+	return &RepositoryWriter_Remove_Call{Call: _e.mock.On("Remove", ctx, address, transactions)}
+}
+
+func (_c *RepositoryWriter_Remove_Call) Run(run func(ctx context.Context, address domain.Address, transactions []domain.Transaction)) *RepositoryWriter_Remove_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Address), args[2].([]domain.Transaction))
+	})
+	return _c
+}
+
+func (_c *RepositoryWriter_Remove_Call) Return(_a0 error) *RepositoryWriter_Remove_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RepositoryWriter_Remove_Call) RunAndReturn(run func(context.Context, domain.Address, []domain.Transaction) error) *RepositoryWriter_Remove_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplaceBlockRange provides a mock function with given fields: ctx, address, fromBlock, toBlock, transactions
+func (_m *RepositoryWriter) ReplaceBlockRange(ctx context.Context, address domain.Address, fromBlock uint64, toBlock uint64, transactions []domain.Transaction) ([]domain.Transaction, error) {
+	ret := _m.Called(ctx, address, fromBlock, toBlock, transactions)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceBlockRange")
+	}
+
+	var r0 []domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address, uint64, uint64, []domain.Transaction) ([]domain.Transaction, error)); ok {
+		return rf(ctx, address, fromBlock, toBlock, transactions)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address, uint64, uint64, []domain.Transaction) []domain.Transaction); ok {
+		r0 = rf(ctx, address, fromBlock, toBlock, transactions)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Address, uint64, uint64, []domain.Transaction) error); ok {
+		r1 = rf(ctx, address, fromBlock, toBlock, transactions)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RepositoryWriter_ReplaceBlockRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceBlockRange'
+type RepositoryWriter_ReplaceBlockRange_Call struct {
+	*mock.Call
+}
+
+// ReplaceBlockRange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - address domain.Address
+//   - fromBlock uint64
+//   - toBlock uint64
+//   - transactions []domain.Transaction
+func (_e *RepositoryWriter_Expecter) ReplaceBlockRange(ctx interface{}, address interface{}, fromBlock interface{}, toBlock interface{}, transactions interface{}) *RepositoryWriter_ReplaceBlockRange_Call {
+	return &RepositoryWriter_ReplaceBlockRange_Call{Call: _e.mock.On("ReplaceBlockRange", ctx, address, fromBlock, toBlock, transactions)}
+}
+
+func (_c *RepositoryWriter_ReplaceBlockRange_Call) Run(run func(ctx context.Context, address domain.Address, fromBlock uint64, toBlock uint64, transactions []domain.Transaction)) *RepositoryWriter_ReplaceBlockRange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Address), args[2].(uint64), args[3].(uint64), args[4].([]domain.Transaction))
+	})
+	return _c
+}
+
+func (_c *RepositoryWriter_ReplaceBlockRange_Call) Return(_a0 []domain.Transaction, _a1 error) *RepositoryWriter_ReplaceBlockRange_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RepositoryWriter_ReplaceBlockRange_Call) RunAndReturn(run func(context.Context, domain.Address, uint64, uint64, []domain.Transaction) ([]domain.Transaction, error)) *RepositoryWriter_ReplaceBlockRange_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // UpdateLastBlock provides a mock function with given fields: ctx, address, blockNumber
-func (_m *RepositoryWriter) UpdateLastBlock(ctx context.Context, address string, blockNumber int64) error {
+func (_m *RepositoryWriter) UpdateLastBlock(ctx context.Context, address domain.Address, blockNumber uint64) error {
 	ret := _m.Called(ctx, address, blockNumber)
 
 	if len(ret) == 0 {
@@ -80,7 +190,7 @@ func (_m *RepositoryWriter) UpdateLastBlock(ctx context.Context, address string,
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address, uint64) error); ok {
 		r0 = rf(ctx, address, blockNumber)
 	} else {
 		r0 = ret.Error(0)
@@ -96,15 +206,15 @@ type RepositoryWriter_UpdateLastBlock_Call struct {
 
 // UpdateLastBlock is a helper method to define mock.On call
 //   - ctx context.Context
-//   - address string
-//   - blockNumber int64
+//   - address domain.Address
+//   - blockNumber uint64
 func (_e *RepositoryWriter_Expecter) UpdateLastBlock(ctx interface{}, address interface{}, blockNumber interface{}) *RepositoryWriter_UpdateLastBlock_Call {
 	return &RepositoryWriter_UpdateLastBlock_Call{Call: _e.mock.On("UpdateLastBlock", ctx, address, blockNumber)}
 }
 
-func (_c *RepositoryWriter_UpdateLastBlock_Call) Run(run func(ctx context.Context, address string, blockNumber int64)) *RepositoryWriter_UpdateLastBlock_Call {
+func (_c *RepositoryWriter_UpdateLastBlock_Call) Run(run func(ctx context.Context, address domain.Address, blockNumber uint64)) *RepositoryWriter_UpdateLastBlock_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(int64))
+		run(args[0].(context.Context), args[1].(domain.Address), args[2].(uint64))
 	})
 	return _c
 }
@@ -114,7 +224,7 @@ func (_c *RepositoryWriter_UpdateLastBlock_Call) Return(_a0 error) *RepositoryWr
 	return _c
 }
 
-func (_c *RepositoryWriter_UpdateLastBlock_Call) RunAndReturn(run func(context.Context, string, int64) error) *RepositoryWriter_UpdateLastBlock_Call {
+func (_c *RepositoryWriter_UpdateLastBlock_Call) RunAndReturn(run func(context.Context, domain.Address, uint64) error) *RepositoryWriter_UpdateLastBlock_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,83 @@
+// Code generated by mockery v2.39.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Subscriptions is an autogenerated mock type for the Subscriptions type
+type Subscriptions struct {
+	mock.Mock
+}
+
+type Subscriptions_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Subscriptions) EXPECT() *Subscriptions_Expecter {
+	return &Subscriptions_Expecter{mock: &_m.Mock}
+}
+
+// Addresses provides a mock function with given fields:
+func (_m *Subscriptions) Addresses() []domain.Address {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Addresses")
+	}
+
+	var r0 []domain.Address
+	if rf, ok := ret.Get(0).(func() []domain.Address); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Address)
+		}
+	}
+
+	return r0
+}
+
+// Subscriptions_Addresses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Addresses'
+type Subscriptions_Addresses_Call struct {
+	*mock.Call
+}
+
+// Addresses is a helper method to define mock.On call
+func (_e *Subscriptions_Expecter) Addresses() *Subscriptions_Addresses_Call {
+	return &Subscriptions_Addresses_Call{Call: _e.mock.On("Addresses")}
+}
+
+func (_c *Subscriptions_Addresses_Call) Run(run func()) *Subscriptions_Addresses_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Subscriptions_Addresses_Call) Return(_a0 []domain.Address) *Subscriptions_Addresses_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Subscriptions_Addresses_Call) RunAndReturn(run func() []domain.Address) *Subscriptions_Addresses_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewSubscriptions creates a new instance of Subscriptions. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSubscriptions(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Subscriptions {
+	mock := &Subscriptions{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
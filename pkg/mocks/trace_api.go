@@ -0,0 +1,210 @@
+// Code generated by mockery v2.39.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TraceAPI is an autogenerated mock type for the TraceAPI type
+type TraceAPI struct {
+	mock.Mock
+}
+
+type TraceAPI_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *TraceAPI) EXPECT() *TraceAPI_Expecter {
+	return &TraceAPI_Expecter{mock: &_m.Mock}
+}
+
+// BlockNumber provides a mock function with given fields: ctx
+func (_m *TraceAPI) BlockNumber(ctx context.Context) (uint64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BlockNumber")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (uint64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) uint64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TraceAPI_BlockNumber_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BlockNumber'
+type TraceAPI_BlockNumber_Call struct {
+	*mock.Call
+}
+
+// BlockNumber is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *TraceAPI_Expecter) BlockNumber(ctx interface{}) *TraceAPI_BlockNumber_Call {
+	return &TraceAPI_BlockNumber_Call{Call: _e.mock.On("BlockNumber", ctx)}
+}
+
+func (_c *TraceAPI_BlockNumber_Call) Run(run func(ctx context.Context)) *TraceAPI_BlockNumber_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *TraceAPI_BlockNumber_Call) Return(_a0 uint64, _a1 error) *TraceAPI_BlockNumber_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TraceAPI_BlockNumber_Call) RunAndReturn(run func(context.Context) (uint64, error)) *TraceAPI_BlockNumber_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBlockByNumber provides a mock function with given fields: ctx, number
+func (_m *TraceAPI) GetBlockByNumber(ctx context.Context, number uint64) (domain.BlockHeader, error) {
+	ret := _m.Called(ctx, number)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlockByNumber")
+	}
+
+	var r0 domain.BlockHeader
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (domain.BlockHeader, error)); ok {
+		return rf(ctx, number)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) domain.BlockHeader); ok {
+		r0 = rf(ctx, number)
+	} else {
+		r0 = ret.Get(0).(domain.BlockHeader)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, number)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TraceAPI_GetBlockByNumber_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBlockByNumber'
+type TraceAPI_GetBlockByNumber_Call struct {
+	*mock.Call
+}
+
+// GetBlockByNumber is a helper method to define mock.On call
+//   - ctx context.Context
+//   - number uint64
+func (_e *TraceAPI_Expecter) GetBlockByNumber(ctx interface{}, number interface{}) *TraceAPI_GetBlockByNumber_Call {
+	return &TraceAPI_GetBlockByNumber_Call{Call: _e.mock.On("GetBlockByNumber", ctx, number)}
+}
+
+func (_c *TraceAPI_GetBlockByNumber_Call) Run(run func(ctx context.Context, number uint64)) *TraceAPI_GetBlockByNumber_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64))
+	})
+	return _c
+}
+
+func (_c *TraceAPI_GetBlockByNumber_Call) Return(_a0 domain.BlockHeader, _a1 error) *TraceAPI_GetBlockByNumber_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TraceAPI_GetBlockByNumber_Call) RunAndReturn(run func(context.Context, uint64) (domain.BlockHeader, error)) *TraceAPI_GetBlockByNumber_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TraceBlock provides a mock function with given fields: ctx, number
+func (_m *TraceAPI) TraceBlock(ctx context.Context, number uint64) ([]domain.InternalCall, error) {
+	ret := _m.Called(ctx, number)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TraceBlock")
+	}
+
+	var r0 []domain.InternalCall
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) ([]domain.InternalCall, error)); ok {
+		return rf(ctx, number)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) []domain.InternalCall); ok {
+		r0 = rf(ctx, number)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.InternalCall)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, number)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TraceAPI_TraceBlock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TraceBlock'
+type TraceAPI_TraceBlock_Call struct {
+	*mock.Call
+}
+
+// TraceBlock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - number uint64
+func (_e *TraceAPI_Expecter) TraceBlock(ctx interface{}, number interface{}) *TraceAPI_TraceBlock_Call {
+	return &TraceAPI_TraceBlock_Call{Call: _e.mock.On("TraceBlock", ctx, number)}
+}
+
+func (_c *TraceAPI_TraceBlock_Call) Run(run func(ctx context.Context, number uint64)) *TraceAPI_TraceBlock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64))
+	})
+	return _c
+}
+
+func (_c *TraceAPI_TraceBlock_Call) Return(_a0 []domain.InternalCall, _a1 error) *TraceAPI_TraceBlock_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TraceAPI_TraceBlock_Call) RunAndReturn(run func(context.Context, uint64) ([]domain.InternalCall, error)) *TraceAPI_TraceBlock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewTraceAPI creates a new instance of TraceAPI. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTraceAPI(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TraceAPI {
+	mock := &TraceAPI{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
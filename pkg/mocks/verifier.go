@@ -0,0 +1,97 @@
+// Code generated by mockery v2.39.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	domain "github.com/tonytcb/ethereum-blockchain-parser/pkg/domain"
+)
+
+// Verifier is an autogenerated mock type for the Verifier type
+type Verifier struct {
+	mock.Mock
+}
+
+type Verifier_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Verifier) EXPECT() *Verifier_Expecter {
+	return &Verifier_Expecter{mock: &_m.Mock}
+}
+
+// Verify provides a mock function with given fields: ctx, address, fromBlock, toBlock, repair
+func (_m *Verifier) Verify(ctx context.Context, address domain.Address, fromBlock uint64, toBlock uint64, repair bool) (domain.IntegrityReport, error) {
+	ret := _m.Called(ctx, address, fromBlock, toBlock, repair)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Verify")
+	}
+
+	var r0 domain.IntegrityReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address, uint64, uint64, bool) (domain.IntegrityReport, error)); ok {
+		return rf(ctx, address, fromBlock, toBlock, repair)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address, uint64, uint64, bool) domain.IntegrityReport); ok {
+		r0 = rf(ctx, address, fromBlock, toBlock, repair)
+	} else {
+		r0 = ret.Get(0).(domain.IntegrityReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Address, uint64, uint64, bool) error); ok {
+		r1 = rf(ctx, address, fromBlock, toBlock, repair)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Verifier_Verify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Verify'
+type Verifier_Verify_Call struct {
+	*mock.Call
+}
+
+// Verify is a helper method to define mock.On call
+//   - ctx context.Context
+//   - address domain.Address
+//   - fromBlock uint64
+//   - toBlock uint64
+//   - repair bool
+func (_e *Verifier_Expecter) Verify(ctx interface{}, address interface{}, fromBlock interface{}, toBlock interface{}, repair interface{}) *Verifier_Verify_Call {
+	return &Verifier_Verify_Call{Call: _e.mock.On("Verify", ctx, address, fromBlock, toBlock, repair)}
+}
+
+func (_c *Verifier_Verify_Call) Run(run func(ctx context.Context, address domain.Address, fromBlock uint64, toBlock uint64, repair bool)) *Verifier_Verify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Address), args[2].(uint64), args[3].(uint64), args[4].(bool))
+	})
+	return _c
+}
+
+func (_c *Verifier_Verify_Call) Return(_a0 domain.IntegrityReport, _a1 error) *Verifier_Verify_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Verifier_Verify_Call) RunAndReturn(run func(context.Context, domain.Address, uint64, uint64, bool) (domain.IntegrityReport, error)) *Verifier_Verify_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewVerifier creates a new instance of Verifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewVerifier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Verifier {
+	mock := &Verifier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}